@@ -3,7 +3,6 @@ package app
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"net/http"
 	"os"
 	"strconv"
@@ -13,6 +12,7 @@ import (
 	_ "github.com/jackc/pgx/v5/stdlib"
 	
 	authhttp "final/auth/internal/http"
+	"final/auth/internal/keys"
 )
 
 func Build(ctx context.Context) (*http.Server, func() error, error) {
@@ -21,11 +21,6 @@ func Build(ctx context.Context) (*http.Server, func() error, error) {
 		dsn = buildDSNFromParts()
 	}
 
-	jwtSecret := strings.TrimSpace(os.Getenv("JWT_SECRET"))
-	if jwtSecret == "" {
-		return nil, nil, errors.New("JWT_SECRET is required")
-	}
-
 	addr := strings.TrimSpace(os.Getenv("AUTH_ADDR"))
 	if addr == "" {
 		addr = "0.0.0.0:8081"
@@ -47,11 +42,29 @@ func Build(ctx context.Context) (*http.Server, func() error, error) {
 		return nil, nil, err
 	}
 
-	h := authhttp.New(db, []byte(jwtSecret))
+	accessTTL := time.Duration(getenvInt("ACCESS_TOKEN_TTL_MIN", 15)) * time.Minute
+	refreshTTL := time.Duration(getenvInt("REFRESH_TOKEN_TTL_HOURS", 720)) * time.Hour
+
+	rotateEvery := time.Duration(getenvInt("KEY_ROTATION_HOURS", 24*7)) * time.Hour
+
+	keyManager := keys.NewManager(db, rotateEvery, accessTTL)
+	if err := keyManager.EnsureCurrent(ctx); err != nil {
+		_ = db.Close()
+		return nil, nil, err
+	}
+	rotCtx, stopRotation := context.WithCancel(context.Background())
+	go keyManager.Run(rotCtx, time.Minute)
+
+	h := authhttp.New(db, keyManager, accessTTL, refreshTTL)
+	go h.PruneRevoked(rotCtx, time.Hour)
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("POST /auth/register", h.Register)
 	mux.HandleFunc("POST /auth/login", h.Login)
+	mux.HandleFunc("POST /auth/refresh", h.Refresh)
+	mux.HandleFunc("POST /auth/logout", h.Logout)
+	mux.HandleFunc("GET /auth/revoked/{jti}", h.Revoked)
+	mux.HandleFunc("GET /.well-known/jwks.json", h.JWKS)
 	mux.HandleFunc("GET /ping", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("pong"))
@@ -67,6 +80,7 @@ func Build(ctx context.Context) (*http.Server, func() error, error) {
 	}
 
 	closeFn := func() error {
+		stopRotation()
 		return db.Close()
 	}
 
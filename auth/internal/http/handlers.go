@@ -7,19 +7,21 @@ import (
 	"strings"
 	"time"
 
+	"final/auth/internal/keys"
 	"final/auth/internal/store/pg"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type Server struct {
-	db        *sql.DB
-	jwtSecret []byte
+	db         *sql.DB
+	keys       *keys.Manager
+	accessTTL  time.Duration
+	refreshTTL time.Duration
 }
 
-func New(db *sql.DB, jwtSecret []byte) *Server {
-	return &Server{db: db, jwtSecret: jwtSecret}
+func New(db *sql.DB, keyManager *keys.Manager, accessTTL, refreshTTL time.Duration) *Server {
+	return &Server{db: db, keys: keyManager, accessTTL: accessTTL, refreshTTL: refreshTTL}
 }
 
 type registerReq struct {
@@ -33,7 +35,9 @@ type loginReq struct {
 }
 
 type tokenResp struct {
-	AccessToken string `json:"access_token"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
 }
 
 func (s *Server) Register(w http.ResponseWriter, r *http.Request) {
@@ -101,19 +105,13 @@ func (s *Server) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	claims := jwt.MapClaims{
-		"sub": u.ID.String(),
-		"iat": time.Now().Unix(),
-		"exp": time.Now().Add(24 * time.Hour).Unix(),
-	}
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := t.SignedString(s.jwtSecret)
+	resp, err := s.issueTokens(r.Context(), s.db, u.ID)
 	if err != nil {
 		writeErr(w, http.StatusInternalServerError, "internal error")
 		return
 	}
 
-	_ = json.NewEncoder(w).Encode(tokenResp{AccessToken: signed})
+	_ = json.NewEncoder(w).Encode(resp)
 }
 
 func writeErr(w http.ResponseWriter, status int, msg string) {
@@ -0,0 +1,238 @@
+package http
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"final/auth/internal/store/pg"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+type refreshReq struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (s *Server) Refresh(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	var req refreshReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErr(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	if strings.TrimSpace(req.RefreshToken) == "" {
+		writeErr(w, http.StatusBadRequest, "refresh_token required")
+		return
+	}
+
+	rt, err := pg.GetRefreshToken(r.Context(), s.db, hashToken(req.RefreshToken))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			writeErr(w, http.StatusUnauthorized, "invalid refresh token")
+			return
+		}
+		writeErr(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	// Повторное использование уже ротированного токена — признак утечки:
+	// отзываем все refresh-токены пользователя.
+	if rt.Revoked {
+		_ = pg.RevokeUserRefreshTokens(r.Context(), s.db, rt.UserID)
+		writeErr(w, http.StatusUnauthorized, "invalid refresh token")
+		return
+	}
+	if time.Now().After(rt.ExpiresAt) {
+		writeErr(w, http.StatusUnauthorized, "refresh token expired")
+		return
+	}
+
+	// Отзыв старого токена и выпуск нового — в одной транзакции: если выпуск
+	// не удался, старый токен остаётся действующим.
+	tx, err := s.db.BeginTx(r.Context(), nil)
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	ok, err := pg.RevokeRefreshToken(r.Context(), tx, rt.ID)
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if !ok {
+		writeErr(w, http.StatusUnauthorized, "invalid refresh token")
+		return
+	}
+
+	resp, err := s.issueTokens(r.Context(), tx, rt.UserID)
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		writeErr(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) Logout(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	var req refreshReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErr(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+
+	if strings.TrimSpace(req.RefreshToken) != "" {
+		rt, err := pg.GetRefreshToken(r.Context(), s.db, hashToken(req.RefreshToken))
+		if err != nil && err != sql.ErrNoRows {
+			writeErr(w, http.StatusInternalServerError, "internal error")
+			return
+		}
+		if err == nil {
+			if _, err := pg.RevokeRefreshToken(r.Context(), s.db, rt.ID); err != nil {
+				writeErr(w, http.StatusInternalServerError, "internal error")
+				return
+			}
+		}
+	}
+
+	if raw, ok := bearerToken(r); ok {
+		jti, exp, err := s.parseAccessToken(raw)
+		if err == nil && jti != "" {
+			if err := pg.RevokeJTI(r.Context(), s.db, jti, exp); err != nil {
+				writeErr(w, http.StatusInternalServerError, "internal error")
+				return
+			}
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) Revoked(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	jti := strings.TrimSpace(r.PathValue("jti"))
+	if jti == "" {
+		writeErr(w, http.StatusBadRequest, "jti required")
+		return
+	}
+
+	revoked, err := pg.IsJTIRevoked(r.Context(), s.db, jti)
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]bool{"revoked": revoked})
+}
+
+// PruneRevoked раз в every удаляет из denylist jti истёкших токенов.
+func (s *Server) PruneRevoked(ctx context.Context, every time.Duration) {
+	t := time.NewTicker(every)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := pg.DeleteRevokedJTIBefore(ctx, s.db, time.Now()); err != nil {
+				log.Printf("[auth] revoked jti cleanup failed: %v", err)
+			}
+		}
+	}
+}
+
+func (s *Server) JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	_ = json.NewEncoder(w).Encode(s.keys.JWKS())
+}
+
+func (s *Server) issueTokens(ctx context.Context, db pg.Querier, userID uuid.UUID) (tokenResp, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub": userID.String(),
+		"jti": uuid.New().String(),
+		"iat": now.Unix(),
+		"exp": now.Add(s.accessTTL).Unix(),
+	}
+	key, err := s.keys.Current()
+	if err != nil {
+		return tokenResp{}, err
+	}
+	t := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	t.Header["kid"] = key.KID
+	access, err := t.SignedString(key.Private)
+	if err != nil {
+		return tokenResp{}, err
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return tokenResp{}, err
+	}
+	refresh := base64.RawURLEncoding.EncodeToString(buf)
+
+	if err := pg.CreateRefreshToken(ctx, db, uuid.New(), userID, hashToken(refresh), now.Add(s.refreshTTL)); err != nil {
+		return tokenResp{}, err
+	}
+
+	return tokenResp{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		ExpiresIn:    int64(s.accessTTL.Seconds()),
+	}, nil
+}
+
+func (s *Server) parseAccessToken(raw string) (string, time.Time, error) {
+	tok, err := jwt.Parse(raw, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return s.keys.PublicKey(kid)
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}))
+	if err != nil || !tok.Valid {
+		return "", time.Time{}, errors.New("invalid token")
+	}
+
+	claims, ok := tok.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", time.Time{}, errors.New("invalid token")
+	}
+	jti, _ := claims["jti"].(string)
+	exp, err := claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		return "", time.Time{}, errors.New("invalid token")
+	}
+	return jti, exp.Time, nil
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return "", false
+	}
+	raw := strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	return raw, raw != ""
+}
+
+func hashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,206 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"final/auth/internal/keys"
+	"final/auth/internal/store/pg"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// newPGServer собирает Server на настоящем Postgres из AUTH_TEST_DATABASE_URL.
+// Каждый тест получает свою схему с применёнными миграциями; без переменной
+// тест пропускается.
+func newPGServer(t *testing.T) *Server {
+	t.Helper()
+	dsn := os.Getenv("AUTH_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("AUTH_TEST_DATABASE_URL is not set")
+	}
+
+	admin, err := sql.Open("pgx", dsn)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	schema := "auth_test_" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		_ = admin.Close()
+	})
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		t.Fatalf("parse dsn: %v", err)
+	}
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+	db, err := sql.Open("pgx", u.String())
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	files, err := filepath.Glob("../../migrations/*.sql")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("migrations not found: %v", err)
+	}
+	sort.Strings(files)
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatalf("read %s: %v", f, err)
+		}
+		up, _, _ := strings.Cut(string(b), "-- +goose Down")
+		if _, err := db.Exec(up); err != nil {
+			t.Fatalf("migrate %s: %v", filepath.Base(f), err)
+		}
+	}
+
+	km := keys.NewManager(db, time.Hour, 15*time.Minute)
+	if err := km.EnsureCurrent(context.Background()); err != nil {
+		t.Fatalf("keys: %v", err)
+	}
+	return New(db, km, 15*time.Minute, time.Hour)
+}
+
+func post(t *testing.T, h http.HandlerFunc, body any, bearer string) *httptest.ResponseRecorder {
+	t.Helper()
+	b, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(b))
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	rr := httptest.NewRecorder()
+	h(rr, req)
+	return rr
+}
+
+func login(t *testing.T, s *Server) tokenResp {
+	t.Helper()
+	rr := post(t, s.Login, loginReq{Email: "a@example.com", Password: "secret"}, "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("login: %d %s", rr.Code, rr.Body.String())
+	}
+	var out tokenResp
+	if err := json.NewDecoder(rr.Body).Decode(&out); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return out
+}
+
+func revoked(t *testing.T, s *Server, jti string) bool {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/auth/revoked/"+jti, nil)
+	req.SetPathValue("jti", jti)
+	rr := httptest.NewRecorder()
+	s.Revoked(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("revoked: %d %s", rr.Code, rr.Body.String())
+	}
+	var out struct {
+		Revoked bool `json:"revoked"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&out); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return out.Revoked
+}
+
+func TestPGTokens(t *testing.T) {
+	s := newPGServer(t)
+	if rr := post(t, s.Register, registerReq{Email: "a@example.com", Password: "secret"}, ""); rr.Code != http.StatusCreated {
+		t.Fatalf("register: %d %s", rr.Code, rr.Body.String())
+	}
+
+	t.Run("refresh_rotation", func(t *testing.T) {
+		first := login(t, s)
+		rr := post(t, s.Refresh, refreshReq{RefreshToken: first.RefreshToken}, "")
+		if rr.Code != http.StatusOK {
+			t.Fatalf("refresh: %d %s", rr.Code, rr.Body.String())
+		}
+		var next tokenResp
+		if err := json.NewDecoder(rr.Body).Decode(&next); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if next.RefreshToken == "" || next.RefreshToken == first.RefreshToken || next.AccessToken == "" {
+			t.Fatalf("expected a new token pair, got %+v", next)
+		}
+		if rr := post(t, s.Refresh, refreshReq{RefreshToken: next.RefreshToken}, ""); rr.Code != http.StatusOK {
+			t.Fatalf("refresh with rotated token: %d %s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("reuse_detection", func(t *testing.T) {
+		first := login(t, s)
+		rr := post(t, s.Refresh, refreshReq{RefreshToken: first.RefreshToken}, "")
+		if rr.Code != http.StatusOK {
+			t.Fatalf("refresh: %d %s", rr.Code, rr.Body.String())
+		}
+		var next tokenResp
+		if err := json.NewDecoder(rr.Body).Decode(&next); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+
+		// Повторный обмен старого токена отзывает и выданный по нему новый.
+		if rr := post(t, s.Refresh, refreshReq{RefreshToken: first.RefreshToken}, ""); rr.Code != http.StatusUnauthorized {
+			t.Fatalf("expected %d on reuse, got %d", http.StatusUnauthorized, rr.Code)
+		}
+		if rr := post(t, s.Refresh, refreshReq{RefreshToken: next.RefreshToken}, ""); rr.Code != http.StatusUnauthorized {
+			t.Fatalf("expected %d after reuse, got %d", http.StatusUnauthorized, rr.Code)
+		}
+	})
+
+	t.Run("logout", func(t *testing.T) {
+		tokens := login(t, s)
+		jti, _, err := s.parseAccessToken(tokens.AccessToken)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		if revoked(t, s, jti) {
+			t.Fatal("fresh token must not be revoked")
+		}
+
+		if rr := post(t, s.Logout, refreshReq{RefreshToken: tokens.RefreshToken}, tokens.AccessToken); rr.Code != http.StatusNoContent {
+			t.Fatalf("logout: %d %s", rr.Code, rr.Body.String())
+		}
+		if !revoked(t, s, jti) {
+			t.Fatal("access token must be revoked after logout")
+		}
+		if rr := post(t, s.Refresh, refreshReq{RefreshToken: tokens.RefreshToken}, ""); rr.Code != http.StatusUnauthorized {
+			t.Fatalf("expected %d after logout, got %d", http.StatusUnauthorized, rr.Code)
+		}
+	})
+
+	t.Run("revoked_cleanup", func(t *testing.T) {
+		ctx := context.Background()
+		if err := pg.RevokeJTI(ctx, s.db, "expired", time.Now().Add(-time.Minute)); err != nil {
+			t.Fatalf("revoke: %v", err)
+		}
+		if err := pg.RevokeJTI(ctx, s.db, "alive", time.Now().Add(time.Minute)); err != nil {
+			t.Fatalf("revoke: %v", err)
+		}
+		if err := pg.DeleteRevokedJTIBefore(ctx, s.db, time.Now()); err != nil {
+			t.Fatalf("cleanup: %v", err)
+		}
+		if revoked(t, s, "expired") || !revoked(t, s, "alive") {
+			t.Fatal("cleanup must drop only expired jti")
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package keys
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"final/auth/internal/store/pg"
+	"github.com/google/uuid"
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+type Key struct {
+	KID       string
+	Private   ed25519.PrivateKey
+	CreatedAt time.Time
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// Manager хранит Ed25519-ключи подписи в Postgres и ротирует их.
+// Новый ключ выпускается раз в rotateEvery; ключ подписывает токены, пока
+// не появится преемник, и публикуется в JWKS ещё maxTokenTTL после этого,
+// пока подписанные им токены живы. Самый новый ключ не снимается никогда,
+// даже если ротация долго не удаётся.
+type Manager struct {
+	db          *sql.DB
+	rotateEvery time.Duration
+	maxTokenTTL time.Duration
+
+	mu   sync.RWMutex
+	keys []Key
+}
+
+func NewManager(db *sql.DB, rotateEvery, maxTokenTTL time.Duration) *Manager {
+	return &Manager{db: db, rotateEvery: rotateEvery, maxTokenTTL: maxTokenTTL}
+}
+
+func (m *Manager) Load(ctx context.Context) error {
+	rows, err := pg.ListSigningKeys(ctx, m.db)
+	if err != nil {
+		return err
+	}
+
+	ks := liveKeys(rows, time.Now(), m.maxTokenTTL)
+	m.mu.Lock()
+	m.keys = ks
+	m.mu.Unlock()
+	return nil
+}
+
+// liveKeys отбирает из rows (от новых к старым) ключи, которые ещё нужны:
+// самый новый и те, чей преемник появился меньше maxTokenTTL назад.
+func liveKeys(rows []pg.SigningKey, now time.Time, maxTokenTTL time.Duration) []Key {
+	ks := make([]Key, 0, len(rows))
+	for i, r := range rows {
+		// Преемник rows[i] — rows[i-1].
+		if i > 0 && !now.Before(rows[i-1].CreatedAt.Add(maxTokenTTL)) {
+			break
+		}
+		if len(r.PrivateKey) != ed25519.PrivateKeySize {
+			continue
+		}
+		ks = append(ks, Key{KID: r.KID, Private: ed25519.PrivateKey(r.PrivateKey), CreatedAt: r.CreatedAt})
+	}
+	return ks
+}
+
+func (m *Manager) Rotate(ctx context.Context) error {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if err := pg.CreateSigningKey(ctx, m.db, uuid.New().String(), priv, now); err != nil {
+		return err
+	}
+	if err := pg.DeleteSigningKeysReplacedBefore(ctx, m.db, now.Add(-m.maxTokenTTL)); err != nil {
+		return err
+	}
+	return m.Load(ctx)
+}
+
+// EnsureCurrent загружает ключи и выпускает новый, если текущий устарел.
+func (m *Manager) EnsureCurrent(ctx context.Context) error {
+	if err := m.Load(ctx); err != nil {
+		return err
+	}
+	if cur, ok := m.current(); ok && time.Since(cur.CreatedAt) < m.rotateEvery {
+		return nil
+	}
+	return m.Rotate(ctx)
+}
+
+func (m *Manager) Run(ctx context.Context, checkEvery time.Duration) {
+	t := time.NewTicker(checkEvery)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := m.EnsureCurrent(ctx); err != nil {
+				log.Printf("[auth] key rotation failed: %v", err)
+			}
+		}
+	}
+}
+
+func (m *Manager) current() (Key, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if len(m.keys) == 0 {
+		return Key{}, false
+	}
+	return m.keys[0], true
+}
+
+func (m *Manager) Current() (Key, error) {
+	k, ok := m.current()
+	if !ok {
+		return Key{}, ErrUnknownKey
+	}
+	return k, nil
+}
+
+func (m *Manager) PublicKey(kid string) (ed25519.PublicKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, k := range m.keys {
+		if k.KID == kid {
+			return k.Private.Public().(ed25519.PublicKey), nil
+		}
+	}
+	return nil, ErrUnknownKey
+}
+
+func (m *Manager) JWKS() JWKS {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	out := JWKS{Keys: make([]JWK, 0, len(m.keys))}
+	for _, k := range m.keys {
+		pub := k.Private.Public().(ed25519.PublicKey)
+		out.Keys = append(out.Keys, JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(pub),
+			Kid: k.KID,
+			Alg: "EdDSA",
+			Use: "sig",
+		})
+	}
+	return out
+}
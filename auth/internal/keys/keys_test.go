@@ -0,0 +1,54 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"final/auth/internal/store/pg"
+)
+
+func TestLiveKeys(t *testing.T) {
+	now := time.Date(2025, 12, 19, 12, 0, 0, 0, time.UTC)
+	ttl := 15 * time.Minute
+	key := func(kid string, age time.Duration) pg.SigningKey {
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("generate: %v", err)
+		}
+		return pg.SigningKey{KID: kid, PrivateKey: priv, CreatedAt: now.Add(-age)}
+	}
+	kids := func(ks []Key) []string {
+		out := make([]string, len(ks))
+		for i, k := range ks {
+			out[i] = k.KID
+		}
+		return out
+	}
+
+	cases := []struct {
+		name string
+		rows []pg.SigningKey
+		want []string
+	}{
+		// Ротация не удаётся дольше maxTokenTTL — текущий ключ остаётся.
+		{"newest_never_dropped", []pg.SigningKey{key("a", 30*24*time.Hour)}, []string{"a"}},
+		// Старый ключ создан давно, но сменён лишь 5 минут назад: его токены живы.
+		{"counted_from_successor", []pg.SigningKey{key("b", 5*time.Minute), key("a", 8*24*time.Hour)}, []string{"b", "a"}},
+		{"retired", []pg.SigningKey{key("c", time.Minute), key("b", 20*time.Minute), key("a", 40*time.Minute)}, []string{"c", "b"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := kids(liveKeys(tc.rows, now, ttl))
+			if len(got) != len(tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("expected %v, got %v", tc.want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type SigningKey struct {
+	KID        string
+	PrivateKey []byte
+	CreatedAt  time.Time
+}
+
+func CreateSigningKey(ctx context.Context, db *sql.DB, kid string, privateKey []byte, createdAt time.Time) error {
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO signing_keys(kid, private_key, created_at) VALUES($1,$2,$3)`,
+		kid, privateKey, createdAt,
+	)
+	return err
+}
+
+func ListSigningKeys(ctx context.Context, db *sql.DB) ([]SigningKey, error) {
+	rows, err := db.QueryContext(ctx,
+		`SELECT kid, private_key, created_at FROM signing_keys ORDER BY created_at DESC`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]SigningKey, 0)
+	for rows.Next() {
+		var k SigningKey
+		if err := rows.Scan(&k.KID, &k.PrivateKey, &k.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteSigningKeysReplacedBefore удаляет ключи, сменённые преемником не
+// позже before. Самый новый ключ преемника не имеет и не удаляется.
+func DeleteSigningKeysReplacedBefore(ctx context.Context, db *sql.DB, before time.Time) error {
+	_, err := db.ExecContext(ctx,
+		`DELETE FROM signing_keys k
+		 WHERE EXISTS (SELECT 1 FROM signing_keys s WHERE s.created_at > k.created_at AND s.created_at <= $1)`,
+		before,
+	)
+	return err
+}
@@ -0,0 +1,90 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Querier — *sql.DB или *sql.Tx: функции токенов можно вызывать в транзакции.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type RefreshToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	ExpiresAt time.Time
+	Revoked   bool
+}
+
+func CreateRefreshToken(ctx context.Context, db Querier, id, userID uuid.UUID, tokenHash string, expiresAt time.Time) error {
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO refresh_tokens(id, user_id, token_hash, expires_at) VALUES($1,$2,$3,$4)`,
+		id, userID, tokenHash, expiresAt,
+	)
+	return err
+}
+
+func GetRefreshToken(ctx context.Context, db Querier, tokenHash string) (RefreshToken, error) {
+	var t RefreshToken
+	var revokedAt sql.NullTime
+	err := db.QueryRowContext(ctx,
+		`SELECT id, user_id, expires_at, revoked_at FROM refresh_tokens WHERE token_hash=$1`,
+		tokenHash,
+	).Scan(&t.ID, &t.UserID, &t.ExpiresAt, &revokedAt)
+	t.Revoked = revokedAt.Valid
+	return t, err
+}
+
+// RevokeRefreshToken сообщает, был ли токен ещё активен: два параллельных
+// refresh одним токеном не пройдут оба.
+func RevokeRefreshToken(ctx context.Context, db Querier, id uuid.UUID) (bool, error) {
+	res, err := db.ExecContext(ctx,
+		`UPDATE refresh_tokens SET revoked_at=now() WHERE id=$1 AND revoked_at IS NULL`,
+		id,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func RevokeUserRefreshTokens(ctx context.Context, db Querier, userID uuid.UUID) error {
+	_, err := db.ExecContext(ctx,
+		`UPDATE refresh_tokens SET revoked_at=now() WHERE user_id=$1 AND revoked_at IS NULL`,
+		userID,
+	)
+	return err
+}
+
+func RevokeJTI(ctx context.Context, db Querier, jti string, expiresAt time.Time) error {
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO revoked_jti(jti, expires_at) VALUES($1,$2) ON CONFLICT(jti) DO NOTHING`,
+		jti, expiresAt,
+	)
+	return err
+}
+
+func IsJTIRevoked(ctx context.Context, db Querier, jti string) (bool, error) {
+	var exists bool
+	err := db.QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM revoked_jti WHERE jti=$1)`,
+		jti,
+	).Scan(&exists)
+	return exists, err
+}
+
+// DeleteRevokedJTIBefore удаляет из denylist jti токенов, истёкших до before:
+// такие токены и так не пройдут проверку exp.
+func DeleteRevokedJTIBefore(ctx context.Context, db Querier, before time.Time) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM revoked_jti WHERE expires_at <= $1`, before)
+	return err
+}
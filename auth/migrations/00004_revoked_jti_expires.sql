@@ -0,0 +1,5 @@
+-- +goose Up
+CREATE INDEX IF NOT EXISTS idx_revoked_jti_expires ON revoked_jti(expires_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_revoked_jti_expires;
@@ -1,28 +1,336 @@
 package api
 
 type CreateTransactionRequest struct {
-	Amount      float64 `json:"amount"`
-	Category    string  `json:"category"`
-	Description string  `json:"description"`
-	Date        string  `json:"date"`
+	AccountID   int64    `json:"account_id"`
+	Kind        string   `json:"kind"`
+	Amount      Money    `json:"amount"`
+	Category    string   `json:"category"`
+	Description string   `json:"description"`
+	Date        string   `json:"date"`
+	Currency    string   `json:"currency"`
+	Tags        []string `json:"tags"`
+	// AutoCategory — категорию назначают правила, category — запасная.
+	AutoCategory bool `json:"auto_category"`
+	// CheckAnomaly — вернуть anomaly, если расход необычен для категории.
+	CheckAnomaly bool `json:"check_anomaly"`
+}
+
+type PatchTransactionRequest struct {
+	AccountID   *int64  `json:"account_id"`
+	Kind        *string `json:"kind"`
+	Amount      *Money  `json:"amount"`
+	Category    *string `json:"category"`
+	Description *string `json:"description"`
+	Date        *string `json:"date"`
+	Currency    *string `json:"currency"`
+	// Tags задан — метки заменяются целиком, [] снимает все.
+	Tags *[]string `json:"tags"`
 }
 
 type TransactionResponse struct {
-	ID          int     `json:"id"`
-	Amount      float64 `json:"amount"`
-	Category    string  `json:"category"`
-	Description string  `json:"description"`
-	Date        string  `json:"date"`
+	ID          int                     `json:"id"`
+	AccountID   int64                   `json:"account_id"`
+	TransferID  int64                   `json:"transfer_id,omitempty"`
+	Kind        string                  `json:"kind"`
+	Amount      Money                   `json:"amount"`
+	Category    string                  `json:"category"`
+	Description string                  `json:"description"`
+	Date        string                  `json:"date"`
+	Currency    string                  `json:"currency"`
+	Tags        []string                `json:"tags,omitempty"`
+	Warnings    []BudgetWarningResponse `json:"warnings,omitempty"`
+	Anomaly     *AnomalyResponse        `json:"anomaly,omitempty"`
+}
+
+type BudgetWarningResponse struct {
+	Category  string  `json:"category"`
+	Threshold int     `json:"threshold"`
+	Limit     Money   `json:"limit"`
+	Spent     Money   `json:"spent"`
+	Percent   float64 `json:"percent"`
+	Exceeded  bool    `json:"exceeded"`
+	Currency  string  `json:"currency"`
 }
 
 type CreateBudgetRequest struct {
-	Category string  `json:"category"`
-	Limit    float64 `json:"limit"`
-	Period   string  `json:"period"`
+	Category       string `json:"category"`
+	Limit          Money  `json:"limit"`
+	Period         string `json:"period"`
+	StartDay       int    `json:"start_day"`
+	Timezone       string `json:"timezone"`
+	Enforcement    string `json:"enforcement"`
+	WarnThresholds []int  `json:"warn_thresholds"`
+	Currency       string `json:"currency"`
 }
 
 type BudgetResponse struct {
-	Category string  `json:"category"`
-	Limit    float64 `json:"limit"`
-	Period   string  `json:"period"`
+	Category       string `json:"category"`
+	Limit          Money  `json:"limit"`
+	Period         string `json:"period"`
+	StartDay       int    `json:"start_day"`
+	Timezone       string `json:"timezone"`
+	Enforcement    string `json:"enforcement"`
+	WarnThresholds []int  `json:"warn_thresholds"`
+	Currency       string `json:"currency"`
+}
+
+type SettingsRequest struct {
+	BaseCurrency string `json:"base_currency"`
+}
+
+type SettingsResponse struct {
+	BaseCurrency string `json:"base_currency"`
+}
+
+type CashFlowPeriodResponse struct {
+	From        string  `json:"from"`
+	To          string  `json:"to"`
+	Income      Money   `json:"income"`
+	Expense     Money   `json:"expense"`
+	Net         Money   `json:"net"`
+	SavingsRate float64 `json:"savings_rate"`
+}
+
+type CashFlowResponse struct {
+	Currency string                   `json:"currency"`
+	Periods  []CashFlowPeriodResponse `json:"periods"`
+	Total    CashFlowPeriodResponse   `json:"total"`
+}
+
+// BudgetProgressResponse — исполнение бюджета за диапазон в валюте бюджета.
+type BudgetProgressResponse struct {
+	Category  string  `json:"category"`
+	Period    string  `json:"period"`
+	Currency  string  `json:"currency"`
+	Limit     Money   `json:"limit"`
+	Spent     Money   `json:"spent"`
+	Remaining Money   `json:"remaining"`
+	Percent   float64 `json:"percent"`
+	Projected Money   `json:"projected"`
+}
+
+type SeriesPointResponse struct {
+	From       string           `json:"from"`
+	To         string           `json:"to"`
+	Label      string           `json:"label"`
+	Total      Money            `json:"total"`
+	ByCategory map[string]Money `json:"by_category,omitempty"`
+}
+
+type TimeSeriesResponse struct {
+	Bucket     string                `json:"bucket"`
+	Currency   string                `json:"currency"`
+	Categories []string              `json:"categories,omitempty"`
+	Points     []SeriesPointResponse `json:"points"`
+}
+
+type CategoryDeltaResponse struct {
+	Category string `json:"category,omitempty"`
+	Current  Money  `json:"current"`
+	Previous Money  `json:"previous"`
+	Delta    Money  `json:"delta"`
+	// DeltaPercent — null, если в предыдущем периоде трат не было.
+	DeltaPercent *float64 `json:"delta_percent"`
+	Status       string   `json:"status,omitempty"`
+}
+
+type ComparisonResponse struct {
+	From                  string                  `json:"from"`
+	To                    string                  `json:"to"`
+	CompareFrom           string                  `json:"compare_from"`
+	CompareTo             string                  `json:"compare_to"`
+	Currency              string                  `json:"currency"`
+	Items                 []CategoryDeltaResponse `json:"items"`
+	Total                 CategoryDeltaResponse   `json:"total"`
+	NewCategories         []string                `json:"new_categories"`
+	DisappearedCategories []string                `json:"disappeared_categories"`
+}
+
+type AnomalyResponse struct {
+	Kind          string `json:"kind"`
+	Category      string `json:"category"`
+	TransactionID int64  `json:"transaction_id,omitempty"`
+	From          string `json:"from"`
+	To            string `json:"to"`
+	Amount        Money  `json:"amount"`
+	Typical       Money  `json:"typical"`
+	// Score больше нуля — потрачено больше обычного.
+	Score    float64 `json:"score"`
+	Currency string  `json:"currency"`
+}
+
+type AnomaliesResponse struct {
+	Items []AnomalyResponse `json:"items"`
+}
+
+type CreateAccountRequest struct {
+	Name           string `json:"name"`
+	Currency       string `json:"currency"`
+	OpeningBalance Money  `json:"opening_balance"`
+}
+
+type AccountResponse struct {
+	ID             int64  `json:"id"`
+	Name           string `json:"name"`
+	Currency       string `json:"currency"`
+	OpeningBalance Money  `json:"opening_balance"`
+	IsDefault      bool   `json:"is_default"`
+}
+
+type TransferRequest struct {
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        Money  `json:"amount"`
+	ToAmount      *Money `json:"to_amount"`
+	Date          string `json:"date"`
+	Description   string `json:"description"`
+}
+
+type TransferResponse struct {
+	Withdrawal TransactionResponse `json:"withdrawal"`
+	Deposit    TransactionResponse `json:"deposit"`
+}
+
+type AccountBalanceResponse struct {
+	Account AccountResponse `json:"account"`
+	Balance Money           `json:"balance"`
+}
+
+type BalancesResponse struct {
+	Items    []AccountBalanceResponse `json:"items"`
+	Total    Money                    `json:"total"`
+	Currency string                   `json:"currency"`
+}
+
+type TrialBalanceLineResponse struct {
+	Account  string `json:"account"`
+	Currency string `json:"currency"`
+	Debit    Money  `json:"debit"`
+	Credit   Money  `json:"credit"`
+	Balance  Money  `json:"balance"`
+}
+
+type TrialBalanceResponse struct {
+	On       string                     `json:"on"`
+	Lines    []TrialBalanceLineResponse `json:"lines"`
+	Balanced bool                       `json:"balanced"`
+}
+
+type StatementLineResponse struct {
+	EntryID       int64  `json:"entry_id"`
+	TransactionID int64  `json:"transaction_id,omitempty"`
+	Date          string `json:"date"`
+	Description   string `json:"description"`
+	Amount        Money  `json:"amount"`
+	Balance       Money  `json:"balance"`
+	RecordedAt    string `json:"recorded_at"`
+}
+
+type StatementResponse struct {
+	Account  string                  `json:"account"`
+	Currency string                  `json:"currency"`
+	Opening  Money                   `json:"opening"`
+	Closing  Money                   `json:"closing"`
+	Lines    []StatementLineResponse `json:"lines"`
+}
+
+type CreateRecurringRequest struct {
+	AccountID   int64  `json:"account_id"`
+	Kind        string `json:"kind"`
+	Amount      Money  `json:"amount"`
+	Currency    string `json:"currency"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+	Frequency   string `json:"frequency"`
+	Interval    int32  `json:"interval"`
+	StartDate   string `json:"start_date"`
+	EndDate     string `json:"end_date"`
+}
+
+type RecurringResponse struct {
+	ID          int64  `json:"id"`
+	AccountID   int64  `json:"account_id"`
+	Kind        string `json:"kind"`
+	Amount      Money  `json:"amount"`
+	Currency    string `json:"currency"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+	Frequency   string `json:"frequency"`
+	Interval    int32  `json:"interval"`
+	StartDate   string `json:"start_date"`
+	EndDate     string `json:"end_date,omitempty"`
+	NextDate    string `json:"next_date,omitempty"`
+	Paused      bool   `json:"paused"`
+}
+
+type RecurringPreviewResponse struct {
+	Dates []string `json:"dates"`
+}
+
+type CreateCategoryRequest struct {
+	Name     string `json:"name"`
+	ParentID int64  `json:"parent_id"`
+	Icon     string `json:"icon"`
+}
+
+type PatchCategoryRequest struct {
+	Name     *string `json:"name"`
+	ParentID *int64  `json:"parent_id"`
+	Icon     *string `json:"icon"`
+	Archived *bool   `json:"archived"`
+}
+
+type MergeCategoryRequest struct {
+	IntoID int64 `json:"into_id"`
+}
+
+type CategoryResponse struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	ParentID int64  `json:"parent_id,omitempty"`
+	Icon     string `json:"icon,omitempty"`
+	Archived bool   `json:"archived"`
+}
+
+type MergeCategoryNamesRequest struct {
+	From string `json:"from"`
+	Into string `json:"into"`
+}
+
+type CategoryAlias struct {
+	Alias    string `json:"alias"`
+	Category string `json:"category"`
+}
+
+type CategoryMergeSuggestion struct {
+	From      string `json:"from"`
+	Into      string `json:"into"`
+	Reason    string `json:"reason"`
+	FromCount int64  `json:"from_count"`
+	IntoCount int64  `json:"into_count"`
+}
+
+type Rule struct {
+	ID                  int64    `json:"id"`
+	Name                string   `json:"name"`
+	Priority            int32    `json:"priority"`
+	DescriptionContains string   `json:"description_contains,omitempty"`
+	DescriptionRegex    string   `json:"description_regex,omitempty"`
+	Merchant            string   `json:"merchant,omitempty"`
+	MinAmount           Money    `json:"min_amount,omitempty"`
+	MaxAmount           Money    `json:"max_amount,omitempty"`
+	Category            string   `json:"category"`
+	Tags                []string `json:"tags"`
+	Disabled            bool     `json:"disabled"`
+}
+
+type TestRulesRequest struct {
+	Description string `json:"description"`
+	Amount      Money  `json:"amount"`
+}
+
+type TestRulesResponse struct {
+	// Fired — сработавшее правило, null — ни одно не подошло.
+	Fired    *Rule  `json:"fired"`
+	Matching []Rule `json:"matching"`
 }
@@ -18,36 +18,65 @@ func ToLedgerTransaction(r CreateTransactionRequest) (ledger.Transaction, error)
 		t = parsed
 	}
 
+	amount, err := ledger.ParseMoney(string(r.Amount))
+	if err != nil {
+		return ledger.Transaction{}, err
+	}
+
 	return ledger.Transaction{
-		Amount:      r.Amount,
+		AccountID:   int(r.AccountID),
+		Kind:        r.Kind,
+		Amount:      amount,
 		Category:    r.Category,
 		Description: r.Description,
 		Date:        t,
+		Currency:    r.Currency,
 	}, nil
 }
 
 func ToTransactionResponse(tx ledger.Transaction) TransactionResponse {
 	return TransactionResponse{
 		ID:          tx.ID,
-		Amount:      tx.Amount,
+		AccountID:   int64(tx.AccountID),
+		TransferID:  int64(tx.TransferID),
+		Kind:        tx.Kind,
+		Amount:      Money(tx.Amount.String()),
 		Category:    tx.Category,
 		Description: tx.Description,
 		Date:        tx.Date.Format(time.RFC3339),
+		Currency:    tx.Currency,
 	}
 }
 
-func ToLedgerBudget(r CreateBudgetRequest) ledger.Budget {
+func ToLedgerBudget(r CreateBudgetRequest) (ledger.Budget, error) {
+	limit, err := ledger.ParseMoney(string(r.Limit))
+	if err != nil {
+		return ledger.Budget{}, err
+	}
+
 	return ledger.Budget{
 		Category: r.Category,
-		Limit:    r.Limit,
-		Period:   "fixed",
-	}
+		Limit:    limit,
+		Period:   r.Period,
+		StartDay: r.StartDay,
+		Timezone: r.Timezone,
+
+		Enforcement:    r.Enforcement,
+		WarnThresholds: r.WarnThresholds,
+		Currency:       r.Currency,
+	}, nil
 }
 
 func ToBudgetResponse(b ledger.Budget) BudgetResponse {
 	return BudgetResponse{
 		Category: b.Category,
-		Limit:    b.Limit,
+		Limit:    Money(b.Limit.String()),
 		Period:   b.Period,
+		StartDay: b.StartDay,
+		Timezone: b.Timezone,
+
+		Enforcement:    b.Enforcement,
+		WarnThresholds: b.WarnThresholds,
+		Currency:       b.Currency,
 	}
 }
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"errors"
+	"strings"
+)
+
+// Money — денежная сумма в виде десятичной записи ("1500", "12.30").
+// Gateway не делает арифметики над суммами и передаёт запись в Ledger
+// как есть, поэтому значение не проходит через float64.
+// В JSON принимается как число или строка, отдаётся числом.
+type Money string
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	if m == "" {
+		return []byte("0"), nil
+	}
+	return []byte(m), nil
+}
+
+func (m *Money) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	s := strings.Trim(string(b), `"`)
+	if !IsDecimal(s) {
+		return errors.New("invalid amount")
+	}
+	*m = Money(s)
+	return nil
+}
+
+// IsDecimal проверяет запись вида [-]digits[.digits] без экспоненты.
+func IsDecimal(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	intPart, frac, hasDot := strings.Cut(s, ".")
+	if intPart == "" && frac == "" {
+		return false
+	}
+	if hasDot && frac == "" {
+		return false
+	}
+	for _, r := range intPart + frac {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package authclient
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+type JWKSClient struct {
+	url    string
+	client *http.Client
+	ttl    time.Duration
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+func NewJWKSClient(base string, ttl time.Duration) *JWKSClient {
+	return &JWKSClient{
+		url:    strings.TrimRight(base, "/") + "/.well-known/jwks.json",
+		client: &http.Client{Timeout: 3 * time.Second},
+		ttl:    ttl,
+		keys:   map[string]crypto.PublicKey{},
+	}
+}
+
+// PublicKey возвращает ключ по kid. JWKS перечитывается по истечении ttl,
+// а также при незнакомом kid (после ротации), но не чаще раза в 10 секунд.
+func (c *JWKSClient) PublicKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k, ok := c.keys[kid]
+	stale := time.Since(c.fetchedAt) > c.ttl
+	if ok && !stale {
+		return k, nil
+	}
+	if !ok && !stale && time.Since(c.fetchedAt) < 10*time.Second {
+		return nil, ErrUnknownKey
+	}
+
+	keys, err := c.fetch(ctx)
+	if err != nil {
+		if ok {
+			return k, nil
+		}
+		return nil, err
+	}
+	c.keys = keys
+	c.fetchedAt = time.Now()
+
+	if k, ok := c.keys[kid]; ok {
+		return k, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+func (c *JWKSClient) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jwks: status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Crv string `json:"crv"`
+			X   string `json:"x"`
+			Kid string `json:"kid"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "OKP" || k.Crv != "Ed25519" || k.Kid == "" {
+			continue
+		}
+		raw, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(raw) != ed25519.PublicKeySize {
+			continue
+		}
+		out[k.Kid] = ed25519.PublicKey(raw)
+	}
+	return out, nil
+}
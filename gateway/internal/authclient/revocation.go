@@ -0,0 +1,98 @@
+package authclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+type RevocationClient struct {
+	base   string
+	client *http.Client
+	ttl    time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+	swept time.Time
+}
+
+type cacheEntry struct {
+	revoked bool
+	until   time.Time
+}
+
+func NewRevocationClient(base string, ttl time.Duration) *RevocationClient {
+	return &RevocationClient{
+		base:   strings.TrimRight(base, "/"),
+		client: &http.Client{Timeout: 2 * time.Second},
+		ttl:    ttl,
+		cache:  map[string]cacheEntry{},
+	}
+}
+
+// IsRevoked спрашивает auth-сервис, находится ли jti в denylist.
+// Отозванные jti кэшируются до exp токена — позже он и так не пройдёт
+// проверку подписи, неотозванные — на ttl, но не дольше exp. Истёкшие
+// записи вычищаются не реже раза в ttl.
+func (c *RevocationClient) IsRevoked(ctx context.Context, jti string, exp time.Time) (bool, error) {
+	now := time.Now()
+
+	c.mu.Lock()
+	if e, ok := c.cache[jti]; ok && now.Before(e.until) {
+		c.mu.Unlock()
+		return e.revoked, nil
+	}
+	c.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.base+"/auth/revoked/"+url.PathEscape(jti), nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("auth revocation check: status %d", resp.StatusCode)
+	}
+
+	var out struct {
+		Revoked bool `json:"revoked"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return false, err
+	}
+
+	until := now.Add(c.ttl)
+	if !exp.IsZero() && (out.Revoked || exp.Before(until)) {
+		until = exp
+	}
+
+	c.mu.Lock()
+	c.cache[jti] = cacheEntry{revoked: out.Revoked, until: until}
+	c.sweep(now)
+	c.mu.Unlock()
+
+	return out.Revoked, nil
+}
+
+// sweep удаляет истёкшие записи, если с прошлой чистки прошло больше ttl.
+// Вызывается под mu.
+func (c *RevocationClient) sweep(now time.Time) {
+	if now.Sub(c.swept) < c.ttl {
+		return
+	}
+	for jti, e := range c.cache {
+		if !now.Before(e.until) {
+			delete(c.cache, jti)
+		}
+	}
+	c.swept = now
+}
@@ -0,0 +1,50 @@
+package authclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRevocationCache(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		revoked := strings.HasSuffix(r.URL.Path, "/revoked-jti")
+		_, _ = w.Write([]byte(`{"revoked":` + strconv.FormatBool(revoked) + `}`))
+	}))
+	defer srv.Close()
+
+	c := NewRevocationClient(srv.URL, time.Minute)
+	ctx := context.Background()
+	exp := time.Now().Add(time.Hour)
+
+	for i := 0; i < 2; i++ {
+		if revoked, err := c.IsRevoked(ctx, "revoked-jti", exp); err != nil || !revoked {
+			t.Fatalf("expected revoked, got %v (%v)", revoked, err)
+		}
+		if revoked, err := c.IsRevoked(ctx, "ok-jti", exp); err != nil || revoked {
+			t.Fatalf("expected not revoked, got %v (%v)", revoked, err)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("expected answers to be cached, got %d calls", calls)
+	}
+
+	// Через ttl остаётся только отозванный jti: он хранится до exp токена.
+	if _, err := c.IsRevoked(ctx, "old-jti", time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	c.mu.Lock()
+	c.sweep(time.Now().Add(2 * time.Minute))
+	_, ok := c.cache["revoked-jti"]
+	n := len(c.cache)
+	c.mu.Unlock()
+	if n != 1 || !ok {
+		t.Fatalf("expected only revoked-jti after sweep, got %d entries", n)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"final/gateway/internal/middleware"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -18,3 +19,21 @@ func OutgoingContext(ctx context.Context) (context.Context, error) {
 	}
 	return metadata.AppendToOutgoingContext(ctx, "x-user-id", uid), nil
 }
+
+func UserIDUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if out, err := OutgoingContext(ctx); err == nil {
+			ctx = out
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func UserIDStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if out, err := OutgoingContext(ctx); err == nil {
+			ctx = out
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"net/http"
+
+	"final/gateway/internal/api"
+	"final/gateway/internal/httpx"
+	ledgerv1 "final/gen/ledger/v1"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (h *Handler) CreateAccount(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateAccountRequest
+	if err := httpx.DecodeJSON(r, &req); err != nil {
+		httpx.WriteError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+
+	resp, err := h.client.CreateAccount(r.Context(), &ledgerv1.CreateAccountRequest{
+		Name:           req.Name,
+		Currency:       req.Currency,
+		OpeningBalance: string(req.OpeningBalance),
+	})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, accountFromPB(resp))
+}
+
+func (h *Handler) ListAccounts(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.client.ListAccounts(r.Context(), &emptypb.Empty{})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	out := make([]api.AccountResponse, 0, len(resp.GetItems()))
+	for _, a := range resp.GetItems() {
+		out = append(out, accountFromPB(a))
+	}
+	httpx.WriteJSON(w, http.StatusOK, out)
+}
+
+func (h *Handler) GetBalances(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	resp, err := h.client.GetBalances(r.Context(), &ledgerv1.GetBalancesRequest{On: r.URL.Query().Get("on")})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	out := api.BalancesResponse{
+		Items:    make([]api.AccountBalanceResponse, 0, len(resp.GetItems())),
+		Total:    api.Money(resp.GetTotal()),
+		Currency: resp.GetCurrency(),
+	}
+	for _, it := range resp.GetItems() {
+		out.Items = append(out.Items, api.AccountBalanceResponse{
+			Account: accountFromPB(it.GetAccount()),
+			Balance: api.Money(it.GetBalance()),
+		})
+	}
+	httpx.WriteJSON(w, http.StatusOK, out)
+}
+
+func (h *Handler) Transfer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req api.TransferRequest
+	if err := httpx.DecodeJSON(r, &req); err != nil {
+		httpx.WriteError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+
+	resp, err := h.client.Transfer(r.Context(), &ledgerv1.TransferRequest{
+		FromAccountId: req.FromAccountID,
+		ToAccountId:   req.ToAccountID,
+		Amount:        string(req.Amount),
+		ToAmount:      (*string)(req.ToAmount),
+		Date:          req.Date,
+		Description:   req.Description,
+	})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, api.TransferResponse{
+		Withdrawal: transactionFromPB(resp.GetWithdrawal()),
+		Deposit:    transactionFromPB(resp.GetDeposit()),
+	})
+}
+
+func accountFromPB(a *ledgerv1.Account) api.AccountResponse {
+	return api.AccountResponse{
+		ID:             a.GetId(),
+		Name:           a.GetName(),
+		Currency:       a.GetCurrency(),
+		OpeningBalance: api.Money(a.GetOpeningBalance()),
+		IsDefault:      a.GetIsDefault(),
+	}
+}
@@ -19,6 +19,14 @@ func (h *Handler) AuthLogin(w http.ResponseWriter, r *http.Request) {
 	h.proxyAuth(w, r, "/auth/login")
 }
 
+func (h *Handler) AuthRefresh(w http.ResponseWriter, r *http.Request) {
+	h.proxyAuth(w, r, "/auth/refresh")
+}
+
+func (h *Handler) AuthLogout(w http.ResponseWriter, r *http.Request) {
+	h.proxyAuth(w, r, "/auth/logout")
+}
+
 func (h *Handler) proxyAuth(w http.ResponseWriter, r *http.Request, path string) {
 	if r.Method != http.MethodPost {
 		httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
@@ -43,6 +51,9 @@ func (h *Handler) proxyAuth(w http.ResponseWriter, r *http.Request, path string)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
 
 	client := &http.Client{
 		Timeout: 10 * time.Second,
@@ -4,7 +4,6 @@ import (
 	"net/http"
 
 	"final/gateway/internal/api"
-	"final/gateway/internal/grpcx"
 	"final/gateway/internal/httpx"
 	ledgerv1 "final/gen/ledger/v1"
 
@@ -18,16 +17,20 @@ func (h *Handler) CreateBudget(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx, err := grpcx.OutgoingContext(r.Context())
-	if err != nil {
-		httpx.WriteError(w, http.StatusUnauthorized, "missing user")
-		return
+	thresholds := make([]int32, 0, len(req.WarnThresholds))
+	for _, th := range req.WarnThresholds {
+		thresholds = append(thresholds, int32(th))
 	}
 
-	resp, err := h.client.SetBudget(ctx, &ledgerv1.CreateBudgetRequest{
-		Category: req.Category,
-		Limit:    req.Limit,
-		Period:   "fixed",
+	resp, err := h.client.SetBudget(r.Context(), &ledgerv1.CreateBudgetRequest{
+		Category:       req.Category,
+		Limit:          string(req.Limit),
+		Period:         req.Period,
+		StartDay:       int32(req.StartDay),
+		Timezone:       req.Timezone,
+		Enforcement:    req.Enforcement,
+		WarnThresholds: thresholds,
+		Currency:       req.Currency,
 	})
 	if err != nil {
 		code, msg := grpcToHTTP(err)
@@ -35,21 +38,11 @@ func (h *Handler) CreateBudget(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	httpx.WriteJSON(w, http.StatusCreated, api.BudgetResponse{
-		Category: resp.GetCategory(),
-		Limit:    resp.GetLimit(),
-		Period:   resp.GetPeriod(),
-	})
+	httpx.WriteJSON(w, http.StatusCreated, budgetFromPB(resp))
 }
 
 func (h *Handler) ListBudgets(w http.ResponseWriter, r *http.Request) {
-	ctx, err := grpcx.OutgoingContext(r.Context())
-	if err != nil {
-		httpx.WriteError(w, http.StatusUnauthorized, "missing user")
-		return
-	}
-
-	resp, err := h.client.ListBudgets(ctx, &emptypb.Empty{})
+	resp, err := h.client.ListBudgets(r.Context(), &emptypb.Empty{})
 	if err != nil {
 		code, msg := grpcToHTTP(err)
 		httpx.WriteError(w, code, msg)
@@ -58,11 +51,24 @@ func (h *Handler) ListBudgets(w http.ResponseWriter, r *http.Request) {
 
 	out := make([]api.BudgetResponse, 0, len(resp.GetItems()))
 	for _, b := range resp.GetItems() {
-		out = append(out, api.BudgetResponse{
-			Category: b.GetCategory(),
-			Limit:    b.GetLimit(),
-			Period:   b.GetPeriod(),
-		})
+		out = append(out, budgetFromPB(b))
 	}
 	httpx.WriteJSON(w, http.StatusOK, out)
 }
+
+func budgetFromPB(b *ledgerv1.Budget) api.BudgetResponse {
+	thresholds := make([]int, 0, len(b.GetWarnThresholds()))
+	for _, th := range b.GetWarnThresholds() {
+		thresholds = append(thresholds, int(th))
+	}
+	return api.BudgetResponse{
+		Category:       b.GetCategory(),
+		Limit:          api.Money(b.GetLimit()),
+		Period:         b.GetPeriod(),
+		StartDay:       int(b.GetStartDay()),
+		Timezone:       b.GetTimezone(),
+		Enforcement:    b.GetEnforcement(),
+		WarnThresholds: thresholds,
+		Currency:       b.GetCurrency(),
+	}
+}
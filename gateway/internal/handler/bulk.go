@@ -31,10 +31,13 @@ func (h *Handler) BulkImportTransactions(w http.ResponseWriter, r *http.Request)
 	items := make([]*ledgerv1.CreateTransactionRequest, 0, len(req))
 	for _, it := range req {
 		items = append(items, &ledgerv1.CreateTransactionRequest{
-			Amount:      it.Amount,
+			Amount:      string(it.Amount),
 			Category:    it.Category,
 			Description: it.Description,
 			Date:        it.Date,
+			Tags:        it.Tags,
+
+			AutoCategory: it.AutoCategory,
 		})
 	}
 
@@ -56,9 +59,18 @@ func (h *Handler) BulkImportTransactions(w http.ResponseWriter, r *http.Request)
 		})
 	}
 
+	warningsOut := make([]map[string]any, 0, len(resp.GetWarnings()))
+	for _, wr := range resp.GetWarnings() {
+		warningsOut = append(warningsOut, map[string]any{
+			"index":   int(wr.GetIndex()),
+			"warning": warningFromPB(wr.GetWarning()),
+		})
+	}
+
 	httpx.WriteJSON(w, http.StatusOK, map[string]any{
 		"accepted": resp.GetAccepted(),
 		"rejected": resp.GetRejected(),
 		"errors":   errorsOut,
+		"warnings": warningsOut,
 	})
 }
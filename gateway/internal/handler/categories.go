@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"net/http"
+
+	"final/gateway/internal/api"
+	"final/gateway/internal/httpx"
+	ledgerv1 "final/gen/ledger/v1"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (h *Handler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateCategoryRequest
+	if err := httpx.DecodeJSON(r, &req); err != nil {
+		httpx.WriteError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+
+	resp, err := h.client.CreateCategory(r.Context(), &ledgerv1.CreateCategoryRequest{
+		Name:     req.Name,
+		ParentId: req.ParentID,
+		Icon:     req.Icon,
+	})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, categoryFromPB(resp))
+}
+
+func (h *Handler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.client.ListCategories(r.Context(), &emptypb.Empty{})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	out := make([]api.CategoryResponse, 0, len(resp.GetItems()))
+	for _, it := range resp.GetItems() {
+		out = append(out, categoryFromPB(it))
+	}
+	httpx.WriteJSON(w, http.StatusOK, out)
+}
+
+func (h *Handler) PatchCategory(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.PatchCategoryRequest
+	if err := httpx.DecodeJSON(r, &req); err != nil {
+		httpx.WriteError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+
+	resp, err := h.client.UpdateCategory(r.Context(), &ledgerv1.UpdateCategoryRequest{
+		Id:       id,
+		Name:     req.Name,
+		ParentId: req.ParentID,
+		Icon:     req.Icon,
+		Archived: req.Archived,
+	})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, categoryFromPB(resp))
+}
+
+// MergeCategory обслуживает POST /api/categories/{id}/merge: категория {id}
+// со всей историей переносится в into_id и удаляется.
+func (h *Handler) MergeCategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.MergeCategoryRequest
+	if err := httpx.DecodeJSON(r, &req); err != nil {
+		httpx.WriteError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+
+	resp, err := h.client.MergeCategories(r.Context(), &ledgerv1.MergeCategoriesRequest{FromId: id, IntoId: req.IntoID})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, categoryFromPB(resp))
+}
+
+// MergeCategoryNames обслуживает POST /api/categories/merge: слияние по
+// имени, в том числе категорий, которых нет в дереве.
+func (h *Handler) MergeCategoryNames(w http.ResponseWriter, r *http.Request) {
+	var req api.MergeCategoryNamesRequest
+	if err := httpx.DecodeJSON(r, &req); err != nil {
+		httpx.WriteError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+
+	resp, err := h.client.MergeCategories(r.Context(), &ledgerv1.MergeCategoriesRequest{FromName: req.From, IntoName: req.Into})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, categoryFromPB(resp))
+}
+
+func (h *Handler) SetCategoryAlias(w http.ResponseWriter, r *http.Request) {
+	var req api.CategoryAlias
+	if err := httpx.DecodeJSON(r, &req); err != nil {
+		httpx.WriteError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+
+	resp, err := h.client.SetCategoryAlias(r.Context(), &ledgerv1.CategoryAlias{Alias: req.Alias, Category: req.Category})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, api.CategoryAlias{Alias: resp.GetAlias(), Category: resp.GetCategory()})
+}
+
+func (h *Handler) ListCategoryAliases(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.client.ListCategoryAliases(r.Context(), &emptypb.Empty{})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	out := make([]api.CategoryAlias, 0, len(resp.GetItems()))
+	for _, it := range resp.GetItems() {
+		out = append(out, api.CategoryAlias{Alias: it.GetAlias(), Category: it.GetCategory()})
+	}
+	httpx.WriteJSON(w, http.StatusOK, out)
+}
+
+// DeleteCategoryAlias обслуживает DELETE /api/categories/aliases?alias=...
+func (h *Handler) DeleteCategoryAlias(w http.ResponseWriter, r *http.Request) {
+	alias := r.URL.Query().Get("alias")
+	if alias == "" {
+		httpx.WriteError(w, http.StatusBadRequest, "alias is required")
+		return
+	}
+
+	if _, err := h.client.DeleteCategoryAlias(r.Context(), &ledgerv1.DeleteCategoryAliasRequest{Alias: alias}); err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) SuggestCategoryMerges(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	resp, err := h.client.SuggestCategoryMerges(r.Context(), &emptypb.Empty{})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	out := make([]api.CategoryMergeSuggestion, 0, len(resp.GetItems()))
+	for _, it := range resp.GetItems() {
+		out = append(out, api.CategoryMergeSuggestion{
+			From:      it.GetFrom(),
+			Into:      it.GetInto(),
+			Reason:    it.GetReason(),
+			FromCount: it.GetFromCount(),
+			IntoCount: it.GetIntoCount(),
+		})
+	}
+	httpx.WriteJSON(w, http.StatusOK, out)
+}
+
+func categoryFromPB(c *ledgerv1.Category) api.CategoryResponse {
+	return api.CategoryResponse{
+		ID:       c.GetId(),
+		Name:     c.GetName(),
+		ParentID: c.GetParentId(),
+		Icon:     c.GetIcon(),
+		Archived: c.GetArchived(),
+	}
+}
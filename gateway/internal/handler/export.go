@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"final/gateway/internal/httpx"
+	ledgerv1 "final/gen/ledger/v1"
+)
+
+// exportFlushEvery — через сколько строк ответ сбрасывается клиенту.
+const exportFlushEvery = 100
+
+// ExportTransactions стримит транзакции под фильтрами списка в NDJSON или CSV,
+// не собирая выгрузку в памяти. Ошибка посреди выгрузки передаётся в трейлере
+// X-Export-Error, так как статус к этому моменту уже отправлен.
+func (h *Handler) ExportTransactions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "ndjson"
+	}
+	if format != "ndjson" && format != "csv" {
+		httpx.WriteError(w, http.StatusBadRequest, "format must be ndjson or csv")
+		return
+	}
+
+	req, err := listTransactionsRequest(r)
+	if err != nil {
+		httpx.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	stream, err := h.client.StreamTransactions(r.Context(), req)
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	// Ошибки валидации и авторизации приходят с первым Recv, поэтому читаем
+	// первое сообщение до отправки заголовков.
+	t, err := stream.Recv()
+	if err != nil && !errors.Is(err, io.EOF) {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	w.Header().Set("Trailer", "X-Export-Error")
+	var enc exportEncoder
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="transactions.csv"`)
+		enc = newCSVEncoder(w)
+	} else {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		enc = &ndjsonEncoder{enc: json.NewEncoder(w)}
+	}
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+	for n := 1; err == nil; n++ {
+		if err = enc.encode(t); err != nil {
+			break
+		}
+		if n%exportFlushEvery == 0 {
+			enc.flush()
+			_ = rc.Flush()
+		}
+		t, err = stream.Recv()
+	}
+	enc.flush()
+	_ = rc.Flush()
+
+	if !errors.Is(err, io.EOF) {
+		_, msg := grpcToHTTP(err)
+		w.Header().Set("X-Export-Error", msg)
+	}
+}
+
+type exportEncoder interface {
+	encode(t *ledgerv1.Transaction) error
+	flush()
+}
+
+type ndjsonEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonEncoder) encode(t *ledgerv1.Transaction) error {
+	return e.enc.Encode(transactionFromPB(t))
+}
+
+func (e *ndjsonEncoder) flush() {}
+
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func newCSVEncoder(w io.Writer) *csvEncoder {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"id", "account_id", "kind", "amount", "currency", "category", "description", "date"})
+	return &csvEncoder{w: cw}
+}
+
+func (e *csvEncoder) encode(t *ledgerv1.Transaction) error {
+	return e.w.Write([]string{
+		strconv.FormatInt(t.GetId(), 10),
+		strconv.FormatInt(t.GetAccountId(), 10),
+		t.GetKind(),
+		t.GetAmount(),
+		t.GetCurrency(),
+		t.GetCategory(),
+		t.GetDescription(),
+		t.GetDate(),
+	})
+}
+
+func (e *csvEncoder) flush() {
+	e.w.Flush()
+}
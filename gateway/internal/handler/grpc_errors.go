@@ -19,6 +19,12 @@ func grpcToHTTP(err error) (int, string) {
 	switch st.Code() {
 	case codes.InvalidArgument:
 		return http.StatusBadRequest, st.Message()
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized, st.Message()
+	case codes.PermissionDenied:
+		return http.StatusForbidden, st.Message()
+	case codes.NotFound:
+		return http.StatusNotFound, st.Message()
 	case codes.FailedPrecondition, codes.Aborted:
 		return http.StatusConflict, st.Message()
 	case codes.DeadlineExceeded:
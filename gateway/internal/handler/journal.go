@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"net/http"
+
+	"final/gateway/internal/api"
+	"final/gateway/internal/httpx"
+	ledgerv1 "final/gen/ledger/v1"
+)
+
+func (h *Handler) TrialBalance(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	resp, err := h.client.GetTrialBalance(r.Context(), &ledgerv1.TrialBalanceRequest{On: r.URL.Query().Get("on")})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	out := api.TrialBalanceResponse{
+		On:       resp.GetOn(),
+		Lines:    make([]api.TrialBalanceLineResponse, 0, len(resp.GetLines())),
+		Balanced: resp.GetBalanced(),
+	}
+	for _, l := range resp.GetLines() {
+		out.Lines = append(out.Lines, api.TrialBalanceLineResponse{
+			Account:  l.GetAccount(),
+			Currency: l.GetCurrency(),
+			Debit:    api.Money(l.GetDebit()),
+			Credit:   api.Money(l.GetCredit()),
+			Balance:  api.Money(l.GetBalance()),
+		})
+	}
+	httpx.WriteJSON(w, http.StatusOK, out)
+}
+
+func (h *Handler) AccountStatement(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	q := r.URL.Query()
+	if q.Get("account") == "" || q.Get("from") == "" || q.Get("to") == "" {
+		httpx.WriteError(w, http.StatusBadRequest, "account, from and to are required")
+		return
+	}
+
+	resp, err := h.client.GetAccountStatement(r.Context(), &ledgerv1.AccountStatementRequest{
+		Account:  q.Get("account"),
+		Currency: q.Get("currency"),
+		From:     q.Get("from"),
+		To:       q.Get("to"),
+	})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	out := api.StatementResponse{
+		Account:  resp.GetAccount(),
+		Currency: resp.GetCurrency(),
+		Opening:  api.Money(resp.GetOpening()),
+		Closing:  api.Money(resp.GetClosing()),
+		Lines:    make([]api.StatementLineResponse, 0, len(resp.GetLines())),
+	}
+	for _, l := range resp.GetLines() {
+		out.Lines = append(out.Lines, api.StatementLineResponse{
+			EntryID:       l.GetEntryId(),
+			TransactionID: l.GetTransactionId(),
+			Date:          l.GetDate(),
+			Description:   l.GetDescription(),
+			Amount:        api.Money(l.GetAmount()),
+			Balance:       api.Money(l.GetBalance()),
+			RecordedAt:    l.GetRecordedAt(),
+		})
+	}
+	httpx.WriteJSON(w, http.StatusOK, out)
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"final/gateway/internal/api"
+	"final/gateway/internal/httpx"
+	ledgerv1 "final/gen/ledger/v1"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (h *Handler) CreateRecurring(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateRecurringRequest
+	if err := httpx.DecodeJSON(r, &req); err != nil {
+		httpx.WriteError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+
+	resp, err := h.client.CreateRecurring(r.Context(), &ledgerv1.CreateRecurringRequest{
+		AccountId:   req.AccountID,
+		Kind:        req.Kind,
+		Amount:      string(req.Amount),
+		Currency:    req.Currency,
+		Category:    req.Category,
+		Description: req.Description,
+		Frequency:   req.Frequency,
+		Interval:    req.Interval,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+	})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, recurringFromPB(resp))
+}
+
+func (h *Handler) ListRecurring(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.client.ListRecurring(r.Context(), &emptypb.Empty{})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	out := make([]api.RecurringResponse, 0, len(resp.GetItems()))
+	for _, it := range resp.GetItems() {
+		out = append(out, recurringFromPB(it))
+	}
+	httpx.WriteJSON(w, http.StatusOK, out)
+}
+
+// SetRecurringPaused обслуживает POST /api/recurring/{id}/pause и /resume.
+func (h *Handler) SetRecurringPaused(paused bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		id, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+
+		resp, err := h.client.SetRecurringPaused(r.Context(), &ledgerv1.SetRecurringPausedRequest{Id: id, Paused: paused})
+		if err != nil {
+			code, msg := grpcToHTTP(err)
+			httpx.WriteError(w, code, msg)
+			return
+		}
+		httpx.WriteJSON(w, http.StatusOK, recurringFromPB(resp))
+	}
+}
+
+func (h *Handler) PreviewRecurring(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var count int64
+	if v := r.URL.Query().Get("count"); v != "" {
+		var err error
+		if count, err = strconv.ParseInt(v, 10, 32); err != nil {
+			httpx.WriteError(w, http.StatusBadRequest, "invalid count")
+			return
+		}
+	}
+
+	resp, err := h.client.PreviewRecurring(r.Context(), &ledgerv1.PreviewRecurringRequest{Id: id, Count: int32(count)})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+	dates := resp.GetDates()
+	if dates == nil {
+		dates = []string{}
+	}
+	httpx.WriteJSON(w, http.StatusOK, api.RecurringPreviewResponse{Dates: dates})
+}
+
+func recurringFromPB(r *ledgerv1.RecurringTransaction) api.RecurringResponse {
+	return api.RecurringResponse{
+		ID:          r.GetId(),
+		AccountID:   r.GetAccountId(),
+		Kind:        r.GetKind(),
+		Amount:      api.Money(r.GetAmount()),
+		Currency:    r.GetCurrency(),
+		Category:    r.GetCategory(),
+		Description: r.GetDescription(),
+		Frequency:   r.GetFrequency(),
+		Interval:    r.GetInterval(),
+		StartDate:   r.GetStartDate(),
+		EndDate:     r.GetEndDate(),
+		NextDate:    r.GetNextDate(),
+		Paused:      r.GetPaused(),
+	}
+}
@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"encoding/csv"
 	"net/http"
+	"strconv"
 
+	"final/gateway/internal/api"
 	"final/gateway/internal/httpx"
 	ledgerv1 "final/gen/ledger/v1"
 )
@@ -26,6 +29,310 @@ func (h *Handler) ReportSummary(w http.ResponseWriter, r *http.Request) {
 		httpx.WriteError(w, code, msg)
 		return
 	}
+	writeTotals(w, resp)
+}
+
+// TagReport отдаёт траты по меткам в том же виде, что и ReportSummary.
+func (h *Handler) TagReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	from := r.URL.Query().Get("from")
+	to := r.URL.Query().Get("to")
+	if from == "" || to == "" {
+		httpx.WriteError(w, http.StatusBadRequest, "from and to are required")
+		return
+	}
+
+	resp, err := h.client.GetTagReport(r.Context(), &ledgerv1.ReportSummaryRequest{From: from, To: to})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+	writeTotals(w, resp)
+}
+
+// writeTotals пишет карту имя → сумма; валюта сумм — в заголовке.
+func writeTotals(w http.ResponseWriter, resp *ledgerv1.ReportSummaryResponse) {
+	if cur := resp.GetCurrency(); cur != "" {
+		w.Header().Set("X-Report-Currency", cur)
+	}
+	totals := make(map[string]api.Money, len(resp.GetTotals()))
+	for name, sum := range resp.GetTotals() {
+		totals[name] = api.Money(sum)
+	}
+	httpx.WriteJSON(w, http.StatusOK, totals)
+}
+
+// BudgetProgress отдаёт исполнение бюджетов за диапазон дат.
+func (h *Handler) BudgetProgress(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	from := r.URL.Query().Get("from")
+	to := r.URL.Query().Get("to")
+	if from == "" || to == "" {
+		httpx.WriteError(w, http.StatusBadRequest, "from and to are required")
+		return
+	}
+
+	resp, err := h.client.GetBudgetProgress(r.Context(), &ledgerv1.ReportSummaryRequest{From: from, To: to})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	out := make([]api.BudgetProgressResponse, 0, len(resp.GetItems()))
+	for _, p := range resp.GetItems() {
+		out = append(out, api.BudgetProgressResponse{
+			Category:  p.GetCategory(),
+			Period:    p.GetPeriod(),
+			Currency:  p.GetCurrency(),
+			Limit:     api.Money(p.GetLimit()),
+			Spent:     api.Money(p.GetSpent()),
+			Remaining: api.Money(p.GetRemaining()),
+			Percent:   p.GetPercent(),
+			Projected: api.Money(p.GetProjected()),
+		})
+	}
+	httpx.WriteJSON(w, http.StatusOK, out)
+}
+
+// SpendingTimeSeries отдаёт ряд трат в JSON или, с format=csv, таблицей
+// «корзина × категория» для импорта в таблицы.
+func (h *Handler) SpendingTimeSeries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	q := r.URL.Query()
+	if q.Get("from") == "" {
+		httpx.WriteError(w, http.StatusBadRequest, "from is required")
+		return
+	}
+	format := q.Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		httpx.WriteError(w, http.StatusBadRequest, "format must be json or csv")
+		return
+	}
+	var byCategory bool
+	if v := q.Get("by_category"); v != "" {
+		var err error
+		if byCategory, err = strconv.ParseBool(v); err != nil {
+			httpx.WriteError(w, http.StatusBadRequest, "invalid by_category")
+			return
+		}
+	}
+
+	resp, err := h.client.GetSpendingTimeSeries(r.Context(), &ledgerv1.SpendingTimeSeriesRequest{
+		From:       q.Get("from"),
+		To:         q.Get("to"),
+		Bucket:     q.Get("bucket"),
+		Timezone:   q.Get("timezone"),
+		ByCategory: byCategory,
+	})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	if format == "csv" {
+		writeSeriesCSV(w, resp)
+		return
+	}
+	out := api.TimeSeriesResponse{
+		Bucket:     resp.GetBucket(),
+		Currency:   resp.GetCurrency(),
+		Categories: resp.GetCategories(),
+		Points:     make([]api.SeriesPointResponse, 0, len(resp.GetPoints())),
+	}
+	for _, p := range resp.GetPoints() {
+		pt := api.SeriesPointResponse{
+			From:  p.GetFrom(),
+			To:    p.GetTo(),
+			Label: p.GetLabel(),
+			Total: api.Money(p.GetTotal()),
+		}
+		if len(p.GetByCategory()) > 0 {
+			pt.ByCategory = make(map[string]api.Money, len(p.GetByCategory()))
+			for cat, sum := range p.GetByCategory() {
+				pt.ByCategory[cat] = api.Money(sum)
+			}
+		}
+		out.Points = append(out.Points, pt)
+	}
+	httpx.WriteJSON(w, http.StatusOK, out)
+}
 
-	httpx.WriteJSON(w, http.StatusOK, resp.GetTotals())
+// writeSeriesCSV пишет строку на корзину: label, from, to, total и по
+// колонке на каждую категорию разбивки.
+func writeSeriesCSV(w http.ResponseWriter, resp *ledgerv1.SpendingTimeSeriesResponse) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("X-Report-Currency", resp.GetCurrency())
+	w.WriteHeader(http.StatusOK)
+
+	cw := csv.NewWriter(w)
+	_ = cw.Write(append([]string{"label", "from", "to", "total"}, resp.GetCategories()...))
+	for _, p := range resp.GetPoints() {
+		row := []string{p.GetLabel(), p.GetFrom(), p.GetTo(), p.GetTotal()}
+		for _, cat := range resp.GetCategories() {
+			row = append(row, p.GetByCategory()[cat])
+		}
+		_ = cw.Write(row)
+	}
+	cw.Flush()
+}
+
+// ComparePeriods сравнивает траты по категориям за два диапазона; без
+// compare_from и compare_to — с предыдущим периодом.
+func (h *Handler) ComparePeriods(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	q := r.URL.Query()
+	if q.Get("from") == "" || q.Get("to") == "" {
+		httpx.WriteError(w, http.StatusBadRequest, "from and to are required")
+		return
+	}
+	if (q.Get("compare_from") == "") != (q.Get("compare_to") == "") {
+		httpx.WriteError(w, http.StatusBadRequest, "compare_from and compare_to must be set together")
+		return
+	}
+
+	resp, err := h.client.ComparePeriods(r.Context(), &ledgerv1.ComparePeriodsRequest{
+		From:        q.Get("from"),
+		To:          q.Get("to"),
+		CompareFrom: q.Get("compare_from"),
+		CompareTo:   q.Get("compare_to"),
+	})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	out := api.ComparisonResponse{
+		From:                  resp.GetFrom(),
+		To:                    resp.GetTo(),
+		CompareFrom:           resp.GetCompareFrom(),
+		CompareTo:             resp.GetCompareTo(),
+		Currency:              resp.GetCurrency(),
+		Items:                 make([]api.CategoryDeltaResponse, 0, len(resp.GetItems())),
+		Total:                 categoryDeltaFromPB(resp.GetTotal()),
+		NewCategories:         append([]string{}, resp.GetNewCategories()...),
+		DisappearedCategories: append([]string{}, resp.GetDisappearedCategories()...),
+	}
+	for _, d := range resp.GetItems() {
+		out.Items = append(out.Items, categoryDeltaFromPB(d))
+	}
+	httpx.WriteJSON(w, http.StatusOK, out)
+}
+
+func categoryDeltaFromPB(d *ledgerv1.CategoryDelta) api.CategoryDeltaResponse {
+	return api.CategoryDeltaResponse{
+		Category:     d.GetCategory(),
+		Current:      api.Money(d.GetCurrent()),
+		Previous:     api.Money(d.GetPrevious()),
+		Delta:        api.Money(d.GetDelta()),
+		DeltaPercent: d.DeltaPercent,
+		Status:       d.GetStatus(),
+	}
+}
+
+func (h *Handler) ListAnomalies(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	q := r.URL.Query()
+	if q.Get("from") == "" || q.Get("to") == "" {
+		httpx.WriteError(w, http.StatusBadRequest, "from and to are required")
+		return
+	}
+
+	resp, err := h.client.ListAnomalies(r.Context(), &ledgerv1.ReportSummaryRequest{From: q.Get("from"), To: q.Get("to")})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	out := api.AnomaliesResponse{Items: make([]api.AnomalyResponse, 0, len(resp.GetItems()))}
+	for _, a := range resp.GetItems() {
+		out.Items = append(out.Items, anomalyFromPB(a))
+	}
+	httpx.WriteJSON(w, http.StatusOK, out)
+}
+
+func anomalyFromPB(a *ledgerv1.Anomaly) api.AnomalyResponse {
+	return api.AnomalyResponse{
+		Kind:          a.GetKind(),
+		Category:      a.GetCategory(),
+		TransactionID: a.GetTransactionId(),
+		From:          a.GetFrom(),
+		To:            a.GetTo(),
+		Amount:        api.Money(a.GetAmount()),
+		Typical:       api.Money(a.GetTypical()),
+		Score:         a.GetScore(),
+		Currency:      a.GetCurrency(),
+	}
+}
+
+func (h *Handler) CashFlow(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	q := r.URL.Query()
+	if q.Get("from") == "" || q.Get("to") == "" {
+		httpx.WriteError(w, http.StatusBadRequest, "from and to are required")
+		return
+	}
+
+	resp, err := h.client.GetCashFlow(r.Context(), &ledgerv1.CashFlowRequest{
+		From:        q.Get("from"),
+		To:          q.Get("to"),
+		Granularity: q.Get("granularity"),
+	})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	out := api.CashFlowResponse{
+		Currency: resp.GetCurrency(),
+		Periods:  make([]api.CashFlowPeriodResponse, 0, len(resp.GetPeriods())),
+		Total:    cashFlowPeriodFromPB(resp.GetTotal()),
+	}
+	for _, p := range resp.GetPeriods() {
+		out.Periods = append(out.Periods, cashFlowPeriodFromPB(p))
+	}
+	httpx.WriteJSON(w, http.StatusOK, out)
+}
+
+func cashFlowPeriodFromPB(p *ledgerv1.CashFlowPeriod) api.CashFlowPeriodResponse {
+	return api.CashFlowPeriodResponse{
+		From:        p.GetFrom(),
+		To:          p.GetTo(),
+		Income:      api.Money(p.GetIncome()),
+		Expense:     api.Money(p.GetExpense()),
+		Net:         api.Money(p.GetNet()),
+		SavingsRate: p.GetSavingsRate(),
+	}
 }
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"net/http"
+
+	"final/gateway/internal/api"
+	"final/gateway/internal/httpx"
+	ledgerv1 "final/gen/ledger/v1"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (h *Handler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	var req api.Rule
+	if err := httpx.DecodeJSON(r, &req); err != nil {
+		httpx.WriteError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+	req.ID = 0
+
+	resp, err := h.client.CreateRule(r.Context(), ruleToPB(req))
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, ruleFromPB(resp))
+}
+
+func (h *Handler) ListRules(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.client.ListRules(r.Context(), &emptypb.Empty{})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	out := make([]api.Rule, 0, len(resp.GetItems()))
+	for _, it := range resp.GetItems() {
+		out = append(out, ruleFromPB(it))
+	}
+	httpx.WriteJSON(w, http.StatusOK, out)
+}
+
+// UpdateRule обслуживает PUT /api/rules/{id}: правило заменяется целиком.
+func (h *Handler) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.Rule
+	if err := httpx.DecodeJSON(r, &req); err != nil {
+		httpx.WriteError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+	req.ID = id
+
+	resp, err := h.client.UpdateRule(r.Context(), ruleToPB(req))
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, ruleFromPB(resp))
+}
+
+func (h *Handler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.client.DeleteRule(r.Context(), &ledgerv1.DeleteRuleRequest{Id: id}); err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TestRules обслуживает POST /api/rules/test: показывает, какое правило
+// сработало бы для описания и суммы, ничего не сохраняя.
+func (h *Handler) TestRules(w http.ResponseWriter, r *http.Request) {
+	var req api.TestRulesRequest
+	if err := httpx.DecodeJSON(r, &req); err != nil {
+		httpx.WriteError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+
+	resp, err := h.client.TestRules(r.Context(), &ledgerv1.TestRulesRequest{
+		Description: req.Description,
+		Amount:      string(req.Amount),
+	})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	out := api.TestRulesResponse{Matching: make([]api.Rule, 0, len(resp.GetMatching()))}
+	for _, it := range resp.GetMatching() {
+		out.Matching = append(out.Matching, ruleFromPB(it))
+	}
+	if resp.GetFired() != nil {
+		fired := ruleFromPB(resp.GetFired())
+		out.Fired = &fired
+	}
+	httpx.WriteJSON(w, http.StatusOK, out)
+}
+
+func ruleToPB(r api.Rule) *ledgerv1.Rule {
+	return &ledgerv1.Rule{
+		Id:                  r.ID,
+		Name:                r.Name,
+		Priority:            r.Priority,
+		DescriptionContains: r.DescriptionContains,
+		DescriptionRegex:    r.DescriptionRegex,
+		Merchant:            r.Merchant,
+		MinAmount:           string(r.MinAmount),
+		MaxAmount:           string(r.MaxAmount),
+		Category:            r.Category,
+		Tags:                r.Tags,
+		Disabled:            r.Disabled,
+	}
+}
+
+func ruleFromPB(r *ledgerv1.Rule) api.Rule {
+	tags := r.GetTags()
+	if tags == nil {
+		tags = []string{}
+	}
+	return api.Rule{
+		ID:                  r.GetId(),
+		Name:                r.GetName(),
+		Priority:            r.GetPriority(),
+		DescriptionContains: r.GetDescriptionContains(),
+		DescriptionRegex:    r.GetDescriptionRegex(),
+		Merchant:            r.GetMerchant(),
+		MinAmount:           api.Money(r.GetMinAmount()),
+		MaxAmount:           api.Money(r.GetMaxAmount()),
+		Category:            r.GetCategory(),
+		Tags:                tags,
+		Disabled:            r.GetDisabled(),
+	}
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+
+	"final/gateway/internal/api"
+	"final/gateway/internal/httpx"
+	ledgerv1 "final/gen/ledger/v1"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (h *Handler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.client.GetSettings(r.Context(), &emptypb.Empty{})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, api.SettingsResponse{BaseCurrency: resp.GetBaseCurrency()})
+}
+
+func (h *Handler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	var req api.SettingsRequest
+	if err := httpx.DecodeJSON(r, &req); err != nil {
+		httpx.WriteError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+
+	resp, err := h.client.UpdateSettings(r.Context(), &ledgerv1.Settings{BaseCurrency: req.BaseCurrency})
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, api.SettingsResponse{BaseCurrency: resp.GetBaseCurrency()})
+}
@@ -1,13 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"final/gateway/internal/api"
 	"final/gateway/internal/httpx"
 	ledgerv1 "final/gen/ledger/v1"
-
-	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 func (h *Handler) CreateTransaction(w http.ResponseWriter, r *http.Request) {
@@ -18,10 +19,17 @@ func (h *Handler) CreateTransaction(w http.ResponseWriter, r *http.Request) {
 	}
 
 	txReq := &ledgerv1.CreateTransactionRequest{
-		Amount:      req.Amount,
+		AccountId:   req.AccountID,
+		Kind:        req.Kind,
+		Amount:      string(req.Amount),
 		Category:    req.Category,
 		Description: req.Description,
 		Date:        req.Date,
+		Currency:    req.Currency,
+		Tags:        req.Tags,
+
+		AutoCategory: req.AutoCategory,
+		CheckAnomaly: req.CheckAnomaly,
 	}
 
 	created, err := h.client.AddTransaction(r.Context(), txReq)
@@ -31,17 +39,17 @@ func (h *Handler) CreateTransaction(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	httpx.WriteJSON(w, http.StatusCreated, api.TransactionResponse{
-		ID:          int(created.GetId()),
-		Amount:      created.GetAmount(),
-		Category:    created.GetCategory(),
-		Description: created.GetDescription(),
-		Date:        created.GetDate(),
-	})
+	httpx.WriteJSON(w, http.StatusCreated, transactionFromPB(created))
 }
 
 func (h *Handler) ListTransactions(w http.ResponseWriter, r *http.Request) {
-	resp, err := h.client.ListTransactions(r.Context(), &emptypb.Empty{})
+	req, err := listTransactionsRequest(r)
+	if err != nil {
+		httpx.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.client.ListTransactions(r.Context(), req)
 	if err != nil {
 		code, msg := grpcToHTTP(err)
 		httpx.WriteError(w, code, msg)
@@ -50,13 +58,217 @@ func (h *Handler) ListTransactions(w http.ResponseWriter, r *http.Request) {
 
 	out := make([]api.TransactionResponse, 0, len(resp.GetItems()))
 	for _, t := range resp.GetItems() {
-		out = append(out, api.TransactionResponse{
-			ID:          int(t.GetId()),
-			Amount:      t.GetAmount(),
-			Category:    t.GetCategory(),
-			Description: t.GetDescription(),
-			Date:        t.GetDate(),
-		})
+		out = append(out, transactionFromPB(t))
+	}
+	// Тело остаётся массивом для совместимости, токен следующей страницы — в заголовке.
+	if next := resp.GetNextPageToken(); next != "" {
+		w.Header().Set("X-Next-Page-Token", next)
 	}
 	httpx.WriteJSON(w, http.StatusOK, out)
 }
+
+func listTransactionsRequest(r *http.Request) (*ledgerv1.ListTransactionsRequest, error) {
+	q := r.URL.Query()
+	req := &ledgerv1.ListTransactionsRequest{
+		From:      q.Get("from"),
+		To:        q.Get("to"),
+		Query:     q.Get("q"),
+		Sort:      q.Get("sort"),
+		PageToken: q.Get("page_token"),
+	}
+	req.Categories = splitList(q["category"])
+	req.Kinds = splitList(q["kind"])
+	req.Tags = splitList(q["tag"])
+	for _, v := range splitList(q["account_id"]) {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, errors.New("invalid account_id")
+		}
+		req.AccountIds = append(req.AccountIds, id)
+	}
+	if v := q.Get("min_amount"); v != "" {
+		if !api.IsDecimal(v) {
+			return nil, errors.New("invalid min_amount")
+		}
+		req.MinAmount = &v
+	}
+	if v := q.Get("max_amount"); v != "" {
+		if !api.IsDecimal(v) {
+			return nil, errors.New("invalid max_amount")
+		}
+		req.MaxAmount = &v
+	}
+	if v := q.Get("page_size"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return nil, errors.New("invalid page_size")
+		}
+		req.PageSize = int32(n)
+	}
+	return req, nil
+}
+
+// splitList собирает значения параметра, переданного через запятую или несколько раз.
+func splitList(values []string) []string {
+	var out []string
+	for _, v := range values {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
+
+func (h *Handler) ReplaceTransaction(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.CreateTransactionRequest
+	if err := httpx.DecodeJSON(r, &req); err != nil {
+		httpx.WriteError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+
+	h.updateTransaction(w, r, &ledgerv1.UpdateTransactionRequest{
+		Id:          id,
+		AccountId:   optionalID(req.AccountID),
+		Kind:        optionalString(req.Kind),
+		Amount:      (*string)(&req.Amount),
+		Category:    &req.Category,
+		Description: &req.Description,
+		Date:        &req.Date,
+		Currency:    optionalString(req.Currency),
+		Tags:        &ledgerv1.TagList{Values: req.Tags},
+	})
+}
+
+func (h *Handler) PatchTransaction(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.PatchTransactionRequest
+	if err := httpx.DecodeJSON(r, &req); err != nil {
+		httpx.WriteError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+
+	h.updateTransaction(w, r, &ledgerv1.UpdateTransactionRequest{
+		Id:          id,
+		AccountId:   req.AccountID,
+		Kind:        req.Kind,
+		Amount:      (*string)(req.Amount),
+		Category:    req.Category,
+		Description: req.Description,
+		Date:        req.Date,
+		Currency:    req.Currency,
+		Tags:        tagList(req.Tags),
+	})
+}
+
+func (h *Handler) updateTransaction(w http.ResponseWriter, r *http.Request, req *ledgerv1.UpdateTransactionRequest) {
+	updated, err := h.client.UpdateTransaction(r.Context(), req)
+	if err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, transactionFromPB(updated))
+}
+
+func (h *Handler) DeleteTransaction(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.client.DeleteTransaction(r.Context(), &ledgerv1.DeleteTransactionRequest{Id: id}); err != nil {
+		code, msg := grpcToHTTP(err)
+		httpx.WriteError(w, code, msg)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// optionalString и optionalID превращают нулевое значение в «не задано»,
+// чтобы PUT без валюты, вида или счёта не сбрасывал их у транзакции.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func optionalID(id int64) *int64 {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
+
+func tagList(tags *[]string) *ledgerv1.TagList {
+	if tags == nil {
+		return nil
+	}
+	return &ledgerv1.TagList{Values: *tags}
+}
+
+func pathID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		httpx.WriteError(w, http.StatusBadRequest, "invalid id")
+		return 0, false
+	}
+	return id, true
+}
+
+func transactionFromPB(t *ledgerv1.Transaction) api.TransactionResponse {
+	out := api.TransactionResponse{
+		ID:          int(t.GetId()),
+		AccountID:   t.GetAccountId(),
+		TransferID:  t.GetTransferId(),
+		Kind:        t.GetKind(),
+		Amount:      api.Money(t.GetAmount()),
+		Category:    t.GetCategory(),
+		Description: t.GetDescription(),
+		Date:        t.GetDate(),
+		Currency:    t.GetCurrency(),
+		Tags:        t.GetTags(),
+		Warnings:    warningsFromPB(t.GetWarnings()),
+	}
+	if t.GetAnomaly() != nil {
+		a := anomalyFromPB(t.GetAnomaly())
+		out.Anomaly = &a
+	}
+	return out
+}
+
+func warningsFromPB(in []*ledgerv1.BudgetWarning) []api.BudgetWarningResponse {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]api.BudgetWarningResponse, 0, len(in))
+	for _, w := range in {
+		out = append(out, warningFromPB(w))
+	}
+	return out
+}
+
+func warningFromPB(w *ledgerv1.BudgetWarning) api.BudgetWarningResponse {
+	return api.BudgetWarningResponse{
+		Category:  w.GetCategory(),
+		Threshold: int(w.GetThreshold()),
+		Limit:     api.Money(w.GetLimit()),
+		Spent:     api.Money(w.GetSpent()),
+		Percent:   w.GetPercent(),
+		Exceeded:  w.GetExceeded(),
+		Currency:  w.GetCurrency(),
+	}
+}
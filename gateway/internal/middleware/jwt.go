@@ -2,9 +2,11 @@ package middleware
 
 import (
 	"context"
+	"crypto"
 	"encoding/json"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -19,7 +21,15 @@ func UserIDFromContext(ctx context.Context) (string, bool) {
 	return s, ok && s != ""
 }
 
-func JWT(secret []byte) func(http.Handler) http.Handler {
+type KeySource interface {
+	PublicKey(ctx context.Context, kid string) (crypto.PublicKey, error)
+}
+
+type RevocationChecker interface {
+	IsRevoked(ctx context.Context, jti string, exp time.Time) (bool, error)
+}
+
+func JWT(keys KeySource, revocation RevocationChecker) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if r.URL.Path == "/ping" || strings.HasPrefix(r.URL.Path, "/auth/") {
@@ -35,11 +45,9 @@ func JWT(secret []byte) func(http.Handler) http.Handler {
 			raw := strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
 
 			tok, err := jwt.Parse(raw, func(token *jwt.Token) (any, error) {
-				if token.Method != jwt.SigningMethodHS256 {
-					return nil, jwt.ErrSignatureInvalid
-				}
-				return secret, nil
-			})
+				kid, _ := token.Header["kid"].(string)
+				return keys.PublicKey(r.Context(), kid)
+			}, jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}))
 			if err != nil || !tok.Valid {
 				writeErr(w, http.StatusUnauthorized, "invalid token")
 				return
@@ -57,6 +65,22 @@ func JWT(secret []byte) func(http.Handler) http.Handler {
 				return
 			}
 
+			if jti, _ := claims["jti"].(string); jti != "" && revocation != nil {
+				var exp time.Time
+				if e, err := claims.GetExpirationTime(); err == nil && e != nil {
+					exp = e.Time
+				}
+				revoked, err := revocation.IsRevoked(r.Context(), jti, exp)
+				if err != nil {
+					writeErr(w, http.StatusServiceUnavailable, "auth service unavailable")
+					return
+				}
+				if revoked {
+					writeErr(w, http.StatusUnauthorized, "token revoked")
+					return
+				}
+			}
+
 			ctx := context.WithValue(r.Context(), userIDKey, sub)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
@@ -40,6 +40,10 @@ func (r *respRecorder) Write(b []byte) (int, error) {
 	return r.ResponseWriter.Write(b)
 }
 
+func (r *respRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 func timeoutFromEnv() time.Duration {
 	if v := os.Getenv("REQUEST_TIMEOUT_MS"); v != "" {
 		if ms, err := strconv.Atoi(v); err == nil && ms > 0 {
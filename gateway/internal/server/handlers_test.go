@@ -3,30 +3,57 @@ package server_test
 import (
 	"bytes"
 	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"final/gateway/internal/api"
+	"final/gateway/internal/middleware"
 	"final/gateway/internal/server"
 	ledgerv1 "final/gen/ledger/v1"
 
+	"github.com/golang-jwt/jwt/v5"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type fakeLedgerClient struct {
 	budgets      map[string]float64
 	transactions []*ledgerv1.Transaction
+	lastList     *ledgerv1.ListTransactionsRequest
+	baseCurrency string
+	accounts     []*ledgerv1.Account
+	lastStmt     *ledgerv1.AccountStatementRequest
+	recurring    []*ledgerv1.RecurringTransaction
+	categories   []*ledgerv1.Category
+	aliases      map[string]string
+	rules        []*ledgerv1.Rule
+	lastSeries   *ledgerv1.SpendingTimeSeriesRequest
+	lastCompare  *ledgerv1.ComparePeriodsRequest
+	// hasDeadline — был ли у контекста последнего List/StreamTransactions дедлайн.
+	hasDeadline bool
 }
 
 func newFakeClient() *fakeLedgerClient {
 	return &fakeLedgerClient{
 		budgets:      map[string]float64{},
 		transactions: []*ledgerv1.Transaction{},
+		baseCurrency: "RUB",
+		aliases:      map[string]string{},
 	}
 }
 
@@ -42,6 +69,11 @@ func errBudgetExceeded() error {
 	return status.Error(codes.FailedPrecondition, "budget exceeded")
 }
 
+func amount(s string) float64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
+
 func grpcMsg(err error) string {
 	st, ok := status.FromError(err)
 	if !ok {
@@ -53,56 +85,99 @@ func grpcMsg(err error) string {
 // --- LedgerServiceClient methods ---
 
 func (f *fakeLedgerClient) AddTransaction(ctx context.Context, in *ledgerv1.CreateTransactionRequest, opts ...grpc.CallOption) (*ledgerv1.Transaction, error) {
-	if in.GetAmount() <= 0 {
+	kind := in.GetKind()
+	switch kind {
+	case "":
+		kind = "expense"
+	case "expense", "income", "refund":
+	default:
+		return nil, errInvalid("invalid transaction kind")
+	}
+	if amount(in.GetAmount()) <= 0 {
 		return nil, errInvalid("amount must be > 0")
 	}
-	if strings.TrimSpace(in.GetCategory()) == "" {
+	cat := normalizeCat(in.GetCategory())
+	tags := in.GetTags()
+	if cat == "" || in.GetAutoCategory() {
+		if r := f.matchRule(in.GetDescription()); r != nil {
+			cat = r.GetCategory()
+			tags = append(slices.Clone(tags), r.GetTags()...)
+		}
+	}
+	if cat == "" {
 		return nil, errInvalid("category is required")
 	}
 	if strings.TrimSpace(in.GetDate()) == "" {
 		return nil, errInvalid("date is required")
 	}
 
-	cat := normalizeCat(in.GetCategory())
 	limit, ok := f.budgets[cat]
-	if ok {
+	if ok && kind == "expense" {
 		var spent float64
 		for _, t := range f.transactions {
 			if normalizeCat(t.GetCategory()) == cat {
-				spent += t.GetAmount()
+				spent += amount(t.GetAmount())
 			}
 		}
-		if spent+in.GetAmount() > limit {
+		if spent+amount(in.GetAmount()) > limit {
 			return nil, errBudgetExceeded()
 		}
 	}
 
+	currency := in.GetCurrency()
+	if currency == "" {
+		currency = f.baseCurrency
+	}
+	accountID := in.GetAccountId()
+	if accountID == 0 {
+		accountID = 1
+	}
+
 	id := int32(len(f.transactions) + 1)
 	tx := &ledgerv1.Transaction{
 		Id:          int64(id),
+		AccountId:   accountID,
+		Kind:        kind,
 		Amount:      in.GetAmount(),
-		Category:    in.GetCategory(),
+		Category:    cat,
 		Description: in.GetDescription(),
 		Date:        in.GetDate(),
+		Currency:    currency,
+		Tags:        tags,
 	}
 	f.transactions = append(f.transactions, tx)
+	// Для проверки ответа необычным считается любой расход от 10000.
+	if in.GetCheckAnomaly() && kind == "expense" && amount(in.GetAmount()) >= 10000 {
+		out := proto.Clone(tx).(*ledgerv1.Transaction)
+		out.Anomaly = &ledgerv1.Anomaly{
+			Kind: "transaction", Category: cat, TransactionId: tx.GetId(), From: in.GetDate()[:10], To: in.GetDate()[:10],
+			Amount: in.GetAmount(), Typical: "1000", Score: 12.5, Currency: currency,
+		}
+		return out, nil
+	}
 	return tx, nil
 }
 
-func (f *fakeLedgerClient) ListTransactions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ledgerv1.ListTransactionsResponse, error) {
-	return &ledgerv1.ListTransactionsResponse{Items: f.transactions}, nil
+func (f *fakeLedgerClient) ListTransactions(ctx context.Context, in *ledgerv1.ListTransactionsRequest, opts ...grpc.CallOption) (*ledgerv1.ListTransactionsResponse, error) {
+	f.lastList = in
+	_, f.hasDeadline = ctx.Deadline()
+	items := f.transactions
+	if in.GetPageSize() > 0 && int(in.GetPageSize()) < len(items) {
+		return &ledgerv1.ListTransactionsResponse{Items: items[:in.GetPageSize()], NextPageToken: "next"}, nil
+	}
+	return &ledgerv1.ListTransactionsResponse{Items: items}, nil
 }
 
 func (f *fakeLedgerClient) SetBudget(ctx context.Context, in *ledgerv1.CreateBudgetRequest, opts ...grpc.CallOption) (*ledgerv1.Budget, error) {
 	if strings.TrimSpace(in.GetCategory()) == "" {
 		return nil, errInvalid("budget category is empty")
 	}
-	if in.GetLimit() <= 0 {
+	if amount(in.GetLimit()) <= 0 {
 		return nil, errInvalid("budget limit must be > 0")
 	}
 
 	cat := normalizeCat(in.GetCategory())
-	f.budgets[cat] = in.GetLimit()
+	f.budgets[cat] = amount(in.GetLimit())
 
 	// Period в твоём DTO есть, но в CreateBudgetRequest может не быть.
 	// Возвращаем фикс.
@@ -116,7 +191,7 @@ func (f *fakeLedgerClient) SetBudget(ctx context.Context, in *ledgerv1.CreateBud
 func (f *fakeLedgerClient) ListBudgets(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ledgerv1.ListBudgetsResponse, error) {
 	items := make([]*ledgerv1.Budget, 0, len(f.budgets))
 	for k, v := range f.budgets {
-		items = append(items, &ledgerv1.Budget{Category: k, Limit: v, Period: "fixed"})
+		items = append(items, &ledgerv1.Budget{Category: k, Limit: strconv.FormatFloat(v, 'f', -1, 64), Period: "fixed"})
 	}
 	return &ledgerv1.ListBudgetsResponse{Items: items}, nil
 }
@@ -124,9 +199,110 @@ func (f *fakeLedgerClient) ListBudgets(ctx context.Context, in *emptypb.Empty, o
 func (f *fakeLedgerClient) GetReportSummary(ctx context.Context, in *ledgerv1.ReportSummaryRequest, opts ...grpc.CallOption) (*ledgerv1.ReportSummaryResponse, error) {
 	totals := map[string]float64{}
 	for _, t := range f.transactions {
-		totals[normalizeCat(t.GetCategory())] += t.GetAmount()
+		totals[normalizeCat(t.GetCategory())] += amount(t.GetAmount())
+	}
+	out := make(map[string]string, len(totals))
+	for k, v := range totals {
+		out[k] = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return &ledgerv1.ReportSummaryResponse{Totals: out, Currency: f.baseCurrency}, nil
+}
+
+func (f *fakeLedgerClient) GetTagReport(ctx context.Context, in *ledgerv1.ReportSummaryRequest, opts ...grpc.CallOption) (*ledgerv1.ReportSummaryResponse, error) {
+	totals := map[string]float64{}
+	for _, t := range f.transactions {
+		for _, tag := range t.GetTags() {
+			totals[tag] += amount(t.GetAmount())
+		}
+	}
+	out := make(map[string]string, len(totals))
+	for k, v := range totals {
+		out[k] = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return &ledgerv1.ReportSummaryResponse{Totals: out, Currency: f.baseCurrency}, nil
+}
+
+func (f *fakeLedgerClient) GetBudgetProgress(ctx context.Context, in *ledgerv1.ReportSummaryRequest, opts ...grpc.CallOption) (*ledgerv1.BudgetProgressResponse, error) {
+	if in.GetFrom() > in.GetTo() {
+		return nil, errInvalid("from must be <= to")
+	}
+	cats := make([]string, 0, len(f.budgets))
+	for cat := range f.budgets {
+		cats = append(cats, cat)
+	}
+	sort.Strings(cats)
+	out := &ledgerv1.BudgetProgressResponse{}
+	for _, cat := range cats {
+		var spent float64
+		for _, t := range f.transactions {
+			if normalizeCat(t.GetCategory()) == cat {
+				spent += amount(t.GetAmount())
+			}
+		}
+		limit := f.budgets[cat]
+		out.Items = append(out.Items, &ledgerv1.BudgetProgress{
+			Category:  cat,
+			Period:    "fixed",
+			Currency:  f.baseCurrency,
+			Limit:     strconv.FormatFloat(limit, 'f', -1, 64),
+			Spent:     strconv.FormatFloat(spent, 'f', -1, 64),
+			Remaining: strconv.FormatFloat(limit-spent, 'f', -1, 64),
+			Percent:   spent / limit * 100,
+			Projected: strconv.FormatFloat(spent, 'f', -1, 64),
+		})
 	}
-	return &ledgerv1.ReportSummaryResponse{Totals: totals}, nil
+	return out, nil
+}
+
+func (f *fakeLedgerClient) GetSpendingTimeSeries(ctx context.Context, in *ledgerv1.SpendingTimeSeriesRequest, opts ...grpc.CallOption) (*ledgerv1.SpendingTimeSeriesResponse, error) {
+	f.lastSeries = in
+	if in.GetBucket() == "hour" {
+		return nil, errInvalid("invalid bucket")
+	}
+	out := &ledgerv1.SpendingTimeSeriesResponse{
+		Bucket:   "week",
+		Currency: f.baseCurrency,
+		Points: []*ledgerv1.SeriesPoint{
+			{From: "2025-12-03", To: "2025-12-07", Label: "2025-W49", Total: "150"},
+			{From: "2025-12-08", To: "2025-12-10", Label: "2025-W50", Total: "0"},
+		},
+	}
+	if in.GetByCategory() {
+		out.Categories = []string{"еда", "кафе"}
+		out.Points[0].ByCategory = map[string]string{"еда": "100", "кафе": "50"}
+		out.Points[1].ByCategory = map[string]string{"еда": "0", "кафе": "0"}
+	}
+	return out, nil
+}
+
+func (f *fakeLedgerClient) ComparePeriods(ctx context.Context, in *ledgerv1.ComparePeriodsRequest, opts ...grpc.CallOption) (*ledgerv1.ComparePeriodsResponse, error) {
+	f.lastCompare = in
+	if in.GetFrom() > in.GetTo() {
+		return nil, errInvalid("from must be <= to")
+	}
+	compareFrom, compareTo := in.GetCompareFrom(), in.GetCompareTo()
+	if compareFrom == "" {
+		compareFrom, compareTo = "2025-11-01", "2025-11-30"
+	}
+	return &ledgerv1.ComparePeriodsResponse{
+		From: in.GetFrom(), To: in.GetTo(), CompareFrom: compareFrom, CompareTo: compareTo, Currency: f.baseCurrency,
+		Items: []*ledgerv1.CategoryDelta{
+			{Category: "кафе", Current: "300", Previous: "0", Delta: "300", Status: "new"},
+			{Category: "еда", Current: "1000", Previous: "800", Delta: "200", DeltaPercent: proto.Float64(25)},
+		},
+		Total:         &ledgerv1.CategoryDelta{Current: "1300", Previous: "800", Delta: "500", DeltaPercent: proto.Float64(62.5)},
+		NewCategories: []string{"кафе"},
+	}, nil
+}
+
+func (f *fakeLedgerClient) ListAnomalies(ctx context.Context, in *ledgerv1.ReportSummaryRequest, opts ...grpc.CallOption) (*ledgerv1.ListAnomaliesResponse, error) {
+	if in.GetFrom() > in.GetTo() {
+		return nil, errInvalid("from must be <= to")
+	}
+	return &ledgerv1.ListAnomaliesResponse{Items: []*ledgerv1.Anomaly{
+		{Kind: "transaction", Category: "еда", TransactionId: 7, From: "2025-12-09", To: "2025-12-09", Amount: "5000", Typical: "1000", Score: 54, Currency: f.baseCurrency},
+		{Kind: "weekly", Category: "такси", From: "2025-12-08", To: "2025-12-14", Amount: "0", Typical: "300", Score: -40.5, Currency: f.baseCurrency},
+	}}, nil
 }
 
 func (f *fakeLedgerClient) BulkImportTransactions(ctx context.Context, in *ledgerv1.BulkImportTransactionsRequest, opts ...grpc.CallOption) (*ledgerv1.BulkImportTransactionsResponse, error) {
@@ -154,6 +330,416 @@ func (f *fakeLedgerClient) BulkImportTransactions(ctx context.Context, in *ledge
 	}, nil
 }
 
+func (f *fakeLedgerClient) StreamTransactions(ctx context.Context, in *ledgerv1.ListTransactionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ledgerv1.Transaction], error) {
+	_, f.hasDeadline = ctx.Deadline()
+	if in.GetSort() == "bad" {
+		return &fakeTxStream{err: errInvalid("invalid sort")}, nil
+	}
+	return &fakeTxStream{items: f.transactions}, nil
+}
+
+type fakeTxStream struct {
+	grpc.ClientStream
+	items []*ledgerv1.Transaction
+	err   error
+}
+
+func (s *fakeTxStream) Recv() (*ledgerv1.Transaction, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if len(s.items) == 0 {
+		return nil, io.EOF
+	}
+	t := s.items[0]
+	s.items = s.items[1:]
+	return t, nil
+}
+
+func (f *fakeLedgerClient) UpdateTransaction(ctx context.Context, in *ledgerv1.UpdateTransactionRequest, opts ...grpc.CallOption) (*ledgerv1.Transaction, error) {
+	for _, t := range f.transactions {
+		if t.GetId() != in.GetId() {
+			continue
+		}
+		if in.Amount != nil {
+			if amount(in.GetAmount()) <= 0 {
+				return nil, errInvalid("amount must be > 0")
+			}
+			t.Amount = in.GetAmount()
+		}
+		if in.Category != nil {
+			t.Category = in.GetCategory()
+		}
+		if in.Description != nil {
+			t.Description = in.GetDescription()
+		}
+		if in.Date != nil {
+			t.Date = in.GetDate()
+		}
+		if in.Currency != nil {
+			t.Currency = in.GetCurrency()
+		}
+		if in.Tags != nil {
+			t.Tags = in.GetTags().GetValues()
+		}
+		return t, nil
+	}
+	return nil, status.Error(codes.NotFound, "transaction not found")
+}
+
+func (f *fakeLedgerClient) DeleteTransaction(ctx context.Context, in *ledgerv1.DeleteTransactionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	for i, t := range f.transactions {
+		if t.GetId() == in.GetId() {
+			f.transactions = append(f.transactions[:i], f.transactions[i+1:]...)
+			return &emptypb.Empty{}, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "transaction not found")
+}
+
+func (f *fakeLedgerClient) GetCashFlow(ctx context.Context, in *ledgerv1.CashFlowRequest, opts ...grpc.CallOption) (*ledgerv1.CashFlowResponse, error) {
+	if in.GetGranularity() == "daily" {
+		return nil, errInvalid("invalid granularity")
+	}
+	var income, expense float64
+	for _, t := range f.transactions {
+		switch t.GetKind() {
+		case "income":
+			income += amount(t.GetAmount())
+		case "expense":
+			expense += amount(t.GetAmount())
+		case "refund":
+			expense -= amount(t.GetAmount())
+		}
+	}
+	total := &ledgerv1.CashFlowPeriod{
+		From:    in.GetFrom(),
+		To:      in.GetTo(),
+		Income:  strconv.FormatFloat(income, 'f', -1, 64),
+		Expense: strconv.FormatFloat(expense, 'f', -1, 64),
+		Net:     strconv.FormatFloat(income-expense, 'f', -1, 64),
+	}
+	if income != 0 {
+		total.SavingsRate = (income - expense) / income * 100
+	}
+	return &ledgerv1.CashFlowResponse{Periods: []*ledgerv1.CashFlowPeriod{total}, Total: total, Currency: f.baseCurrency}, nil
+}
+
+func (f *fakeLedgerClient) CreateAccount(ctx context.Context, in *ledgerv1.CreateAccountRequest, opts ...grpc.CallOption) (*ledgerv1.Account, error) {
+	if strings.TrimSpace(in.GetName()) == "" {
+		return nil, errInvalid("account name is empty")
+	}
+	a := &ledgerv1.Account{
+		Id:             int64(len(f.accounts) + 1),
+		Name:           in.GetName(),
+		Currency:       in.GetCurrency(),
+		OpeningBalance: in.GetOpeningBalance(),
+		IsDefault:      len(f.accounts) == 0,
+	}
+	f.accounts = append(f.accounts, a)
+	return a, nil
+}
+
+func (f *fakeLedgerClient) ListAccounts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ledgerv1.ListAccountsResponse, error) {
+	return &ledgerv1.ListAccountsResponse{Items: f.accounts}, nil
+}
+
+func (f *fakeLedgerClient) Transfer(ctx context.Context, in *ledgerv1.TransferRequest, opts ...grpc.CallOption) (*ledgerv1.TransferResponse, error) {
+	if in.GetFromAccountId() == in.GetToAccountId() {
+		return nil, errInvalid("transfer accounts must differ")
+	}
+	if int(in.GetToAccountId()) > len(f.accounts) {
+		return nil, status.Error(codes.NotFound, "account not found")
+	}
+	toAmount := in.GetAmount()
+	if in.ToAmount != nil {
+		toAmount = in.GetToAmount()
+	}
+	out := &ledgerv1.Transaction{Id: int64(len(f.transactions) + 1), AccountId: in.GetFromAccountId(), Kind: "transfer", Amount: "-" + in.GetAmount(), Date: in.GetDate()}
+	dep := &ledgerv1.Transaction{Id: out.Id + 1, AccountId: in.GetToAccountId(), Kind: "transfer", Amount: toAmount, Date: in.GetDate()}
+	out.TransferId, dep.TransferId = dep.Id, out.Id
+	f.transactions = append(f.transactions, out, dep)
+	return &ledgerv1.TransferResponse{Withdrawal: out, Deposit: dep}, nil
+}
+
+func (f *fakeLedgerClient) GetBalances(ctx context.Context, in *ledgerv1.GetBalancesRequest, opts ...grpc.CallOption) (*ledgerv1.GetBalancesResponse, error) {
+	resp := &ledgerv1.GetBalancesResponse{Currency: f.baseCurrency}
+	var total float64
+	for _, a := range f.accounts {
+		balance := amount(a.GetOpeningBalance())
+		for _, t := range f.transactions {
+			if t.GetAccountId() != a.GetId() {
+				continue
+			}
+			if t.GetKind() == "expense" {
+				balance -= amount(t.GetAmount())
+			} else {
+				balance += amount(t.GetAmount())
+			}
+		}
+		total += balance
+		resp.Items = append(resp.Items, &ledgerv1.AccountBalance{Account: a, Balance: strconv.FormatFloat(balance, 'f', -1, 64)})
+	}
+	resp.Total = strconv.FormatFloat(total, 'f', -1, 64)
+	return resp, nil
+}
+
+func (f *fakeLedgerClient) GetTrialBalance(ctx context.Context, in *ledgerv1.TrialBalanceRequest, opts ...grpc.CallOption) (*ledgerv1.TrialBalanceResponse, error) {
+	resp := &ledgerv1.TrialBalanceResponse{On: in.GetOn(), Balanced: true}
+	for _, t := range f.transactions {
+		v := t.GetAmount()
+		resp.Lines = append(resp.Lines,
+			&ledgerv1.TrialBalanceLine{Account: "assets:" + strconv.FormatInt(t.GetAccountId(), 10), Currency: t.GetCurrency(), Credit: v, Balance: "-" + v},
+			&ledgerv1.TrialBalanceLine{Account: "expenses:" + t.GetCategory(), Currency: t.GetCurrency(), Debit: v, Balance: v},
+		)
+	}
+	return resp, nil
+}
+
+func (f *fakeLedgerClient) GetAccountStatement(ctx context.Context, in *ledgerv1.AccountStatementRequest, opts ...grpc.CallOption) (*ledgerv1.AccountStatementResponse, error) {
+	if !strings.Contains(in.GetAccount(), ":") {
+		return nil, errInvalid("invalid ledger account")
+	}
+	f.lastStmt = in
+	return &ledgerv1.AccountStatementResponse{
+		Account:  in.GetAccount(),
+		Currency: f.baseCurrency,
+		Opening:  "100",
+		Closing:  "70.5",
+		Lines: []*ledgerv1.StatementLine{
+			{EntryId: 1, TransactionId: 5, Date: "2025-12-19", Amount: "-29.5", Balance: "70.5", RecordedAt: "2025-12-19T10:00:00Z"},
+		},
+	}, nil
+}
+
+func (f *fakeLedgerClient) CreateRecurring(ctx context.Context, in *ledgerv1.CreateRecurringRequest, opts ...grpc.CallOption) (*ledgerv1.RecurringTransaction, error) {
+	if in.GetFrequency() != "weekly" && in.GetFrequency() != "monthly" && in.GetFrequency() != "yearly" {
+		return nil, errInvalid("invalid frequency")
+	}
+	interval := in.GetInterval()
+	if interval == 0 {
+		interval = 1
+	}
+	r := &ledgerv1.RecurringTransaction{
+		Id:        int64(len(f.recurring) + 1),
+		AccountId: in.GetAccountId(),
+		Kind:      in.GetKind(),
+		Amount:    in.GetAmount(),
+		Currency:  f.baseCurrency,
+		Category:  normalizeCat(in.GetCategory()),
+		Frequency: in.GetFrequency(),
+		Interval:  interval,
+		StartDate: in.GetStartDate(),
+		NextDate:  in.GetStartDate(),
+	}
+	f.recurring = append(f.recurring, r)
+	return r, nil
+}
+
+func (f *fakeLedgerClient) ListRecurring(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ledgerv1.ListRecurringResponse, error) {
+	return &ledgerv1.ListRecurringResponse{Items: f.recurring}, nil
+}
+
+func (f *fakeLedgerClient) SetRecurringPaused(ctx context.Context, in *ledgerv1.SetRecurringPausedRequest, opts ...grpc.CallOption) (*ledgerv1.RecurringTransaction, error) {
+	if in.GetId() < 1 || int(in.GetId()) > len(f.recurring) {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	r := f.recurring[in.GetId()-1]
+	r.Paused = in.GetPaused()
+	return r, nil
+}
+
+func (f *fakeLedgerClient) PreviewRecurring(ctx context.Context, in *ledgerv1.PreviewRecurringRequest, opts ...grpc.CallOption) (*ledgerv1.PreviewRecurringResponse, error) {
+	if in.GetId() < 1 || int(in.GetId()) > len(f.recurring) {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	count := in.GetCount()
+	if count == 0 {
+		count = 5
+	}
+	start, _ := time.Parse("2006-01-02", f.recurring[in.GetId()-1].GetNextDate())
+	resp := &ledgerv1.PreviewRecurringResponse{}
+	for i := 0; i < int(count); i++ {
+		resp.Dates = append(resp.Dates, start.AddDate(0, i, 0).Format("2006-01-02"))
+	}
+	return resp, nil
+}
+
+func (f *fakeLedgerClient) category(id int64) (*ledgerv1.Category, error) {
+	if id < 1 || int(id) > len(f.categories) || f.categories[id-1] == nil {
+		return nil, status.Error(codes.NotFound, "category not found")
+	}
+	return f.categories[id-1], nil
+}
+
+func (f *fakeLedgerClient) CreateCategory(ctx context.Context, in *ledgerv1.CreateCategoryRequest, opts ...grpc.CallOption) (*ledgerv1.Category, error) {
+	name := normalizeCat(in.GetName())
+	if name == "" {
+		return nil, errInvalid("category name is empty")
+	}
+	if in.GetParentId() != 0 {
+		if _, err := f.category(in.GetParentId()); err != nil {
+			return nil, err
+		}
+	}
+	c := &ledgerv1.Category{Id: int64(len(f.categories) + 1), Name: name, ParentId: in.GetParentId(), Icon: in.GetIcon()}
+	f.categories = append(f.categories, c)
+	return c, nil
+}
+
+func (f *fakeLedgerClient) ListCategories(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ledgerv1.ListCategoriesResponse, error) {
+	resp := &ledgerv1.ListCategoriesResponse{}
+	for _, c := range f.categories {
+		if c != nil {
+			resp.Items = append(resp.Items, c)
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeLedgerClient) UpdateCategory(ctx context.Context, in *ledgerv1.UpdateCategoryRequest, opts ...grpc.CallOption) (*ledgerv1.Category, error) {
+	c, err := f.category(in.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if in.Name != nil {
+		c.Name = normalizeCat(in.GetName())
+	}
+	if in.ParentId != nil {
+		if in.GetParentId() == c.GetId() {
+			return nil, errInvalid("category cannot be moved under itself")
+		}
+		c.ParentId = in.GetParentId()
+	}
+	if in.Icon != nil {
+		c.Icon = in.GetIcon()
+	}
+	if in.Archived != nil {
+		c.Archived = in.GetArchived()
+	}
+	return c, nil
+}
+
+func (f *fakeLedgerClient) MergeCategories(ctx context.Context, in *ledgerv1.MergeCategoriesRequest, opts ...grpc.CallOption) (*ledgerv1.Category, error) {
+	if in.GetFromId() == 0 {
+		if normalizeCat(in.GetFromName()) == normalizeCat(in.GetIntoName()) {
+			return nil, errInvalid("cannot merge category into itself")
+		}
+		return &ledgerv1.Category{Name: normalizeCat(in.GetIntoName())}, nil
+	}
+	if in.GetFromId() == in.GetIntoId() {
+		return nil, errInvalid("cannot merge category into itself")
+	}
+	if _, err := f.category(in.GetFromId()); err != nil {
+		return nil, err
+	}
+	into, err := f.category(in.GetIntoId())
+	if err != nil {
+		return nil, err
+	}
+	f.categories[in.GetFromId()-1] = nil
+	return into, nil
+}
+
+func (f *fakeLedgerClient) SetCategoryAlias(ctx context.Context, in *ledgerv1.CategoryAlias, opts ...grpc.CallOption) (*ledgerv1.CategoryAlias, error) {
+	alias, category := normalizeCat(in.GetAlias()), normalizeCat(in.GetCategory())
+	if alias == category {
+		return nil, errInvalid("alias must differ from category")
+	}
+	f.aliases[alias] = category
+	return &ledgerv1.CategoryAlias{Alias: alias, Category: category}, nil
+}
+
+func (f *fakeLedgerClient) ListCategoryAliases(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ledgerv1.ListCategoryAliasesResponse, error) {
+	resp := &ledgerv1.ListCategoryAliasesResponse{}
+	for alias, category := range f.aliases {
+		resp.Items = append(resp.Items, &ledgerv1.CategoryAlias{Alias: alias, Category: category})
+	}
+	return resp, nil
+}
+
+func (f *fakeLedgerClient) DeleteCategoryAlias(ctx context.Context, in *ledgerv1.DeleteCategoryAliasRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	if _, ok := f.aliases[normalizeCat(in.GetAlias())]; !ok {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	delete(f.aliases, normalizeCat(in.GetAlias()))
+	return &emptypb.Empty{}, nil
+}
+
+func (f *fakeLedgerClient) SuggestCategoryMerges(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ledgerv1.SuggestCategoryMergesResponse, error) {
+	return &ledgerv1.SuggestCategoryMergesResponse{Items: []*ledgerv1.CategoryMergeSuggestion{
+		{From: "taxi", Into: "такси", Reason: "alias", FromCount: 2, IntoCount: 10},
+	}}, nil
+}
+
+// matchRule — упрощённые правила: только description_contains, по порядку создания.
+func (f *fakeLedgerClient) matchRule(description string) *ledgerv1.Rule {
+	for _, r := range f.rules {
+		if r != nil && !r.GetDisabled() && strings.Contains(normalizeCat(description), normalizeCat(r.GetDescriptionContains())) {
+			return r
+		}
+	}
+	return nil
+}
+
+func (f *fakeLedgerClient) CreateRule(ctx context.Context, in *ledgerv1.Rule, opts ...grpc.CallOption) (*ledgerv1.Rule, error) {
+	if in.GetDescriptionContains() == "" {
+		return nil, errInvalid("rule has no conditions")
+	}
+	r := proto.Clone(in).(*ledgerv1.Rule)
+	r.Id = int64(len(f.rules) + 1)
+	r.Category = normalizeCat(r.GetCategory())
+	f.rules = append(f.rules, r)
+	return r, nil
+}
+
+func (f *fakeLedgerClient) ListRules(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ledgerv1.ListRulesResponse, error) {
+	resp := &ledgerv1.ListRulesResponse{}
+	for _, r := range f.rules {
+		if r != nil {
+			resp.Items = append(resp.Items, r)
+		}
+	}
+	return resp, nil
+}
+
+func (f *fakeLedgerClient) UpdateRule(ctx context.Context, in *ledgerv1.Rule, opts ...grpc.CallOption) (*ledgerv1.Rule, error) {
+	if in.GetId() < 1 || int(in.GetId()) > len(f.rules) || f.rules[in.GetId()-1] == nil {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	f.rules[in.GetId()-1] = proto.Clone(in).(*ledgerv1.Rule)
+	return in, nil
+}
+
+func (f *fakeLedgerClient) DeleteRule(ctx context.Context, in *ledgerv1.DeleteRuleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	if in.GetId() < 1 || int(in.GetId()) > len(f.rules) || f.rules[in.GetId()-1] == nil {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	f.rules[in.GetId()-1] = nil
+	return &emptypb.Empty{}, nil
+}
+
+func (f *fakeLedgerClient) TestRules(ctx context.Context, in *ledgerv1.TestRulesRequest, opts ...grpc.CallOption) (*ledgerv1.TestRulesResponse, error) {
+	resp := &ledgerv1.TestRulesResponse{}
+	if r := f.matchRule(in.GetDescription()); r != nil {
+		resp.Fired = r
+		resp.Matching = []*ledgerv1.Rule{r}
+	}
+	return resp, nil
+}
+
+func (f *fakeLedgerClient) GetSettings(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ledgerv1.Settings, error) {
+	return &ledgerv1.Settings{BaseCurrency: f.baseCurrency}, nil
+}
+
+func (f *fakeLedgerClient) UpdateSettings(ctx context.Context, in *ledgerv1.Settings, opts ...grpc.CallOption) (*ledgerv1.Settings, error) {
+	if len(in.GetBaseCurrency()) != 3 {
+		return nil, errInvalid("invalid currency")
+	}
+	f.baseCurrency = strings.ToUpper(in.GetBaseCurrency())
+	return &ledgerv1.Settings{BaseCurrency: f.baseCurrency}, nil
+}
+
 // --- helpers ---
 
 func doReq(t *testing.T, h http.Handler, method, path, body string) *httptest.ResponseRecorder {
@@ -279,3 +865,875 @@ func TestTransactionsFlow(t *testing.T) {
 		}
 	})
 }
+
+func TestTransactionUpdateDelete(t *testing.T) {
+	fc := newFakeClient()
+	h := server.NewRouter(fc)
+
+	_ = doReq(t, h, http.MethodPost, "/api/transactions",
+		`{"amount":1200,"category":"food","description":"groceries","date":"2025-12-19T21:29:42+03:00"}`)
+
+	rr := doReq(t, h, http.MethodPatch, "/api/transactions/1", `{"amount":800}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var got map[string]any
+	_ = json.NewDecoder(bytes.NewReader(rr.Body.Bytes())).Decode(&got)
+	if got["amount"] != float64(800) || got["category"] != "food" {
+		t.Fatalf("unexpected patched transaction: %v", got)
+	}
+
+	rr = doReq(t, h, http.MethodPut, "/api/transactions/1",
+		`{"amount":500,"category":"cafe","description":"coffee","date":"2025-12-20T09:00:00+03:00"}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodPatch, "/api/transactions/abc", `{}`)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodDelete, "/api/transactions/1", "")
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusNoContent, rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodDelete, "/api/transactions/1", "")
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusNotFound, rr.Code, rr.Body.String())
+	}
+}
+
+func TestListTransactionsQuery(t *testing.T) {
+	fc := newFakeClient()
+	h := server.NewRouter(fc)
+
+	for i := 0; i < 3; i++ {
+		_ = doReq(t, h, http.MethodPost, "/api/transactions",
+			`{"amount":100,"category":"food","description":"x","date":"2025-12-19T00:00:00+03:00"}`)
+	}
+
+	rr := doReq(t, h, http.MethodGet,
+		"/api/transactions?from=2025-12-01&to=2025-12-31&category=food,cafe&category=taxi&min_amount=10&max_amount=500.5&q=lunch&sort=amount_asc&page_size=2&page_token=abc", "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	if got := rr.Header().Get("X-Next-Page-Token"); got != "next" {
+		t.Fatalf("expected next page token, got %q", got)
+	}
+	var arr []map[string]any
+	_ = json.NewDecoder(bytes.NewReader(rr.Body.Bytes())).Decode(&arr)
+	if len(arr) != 2 {
+		t.Fatalf("expected 2 transactions, got %d", len(arr))
+	}
+
+	req := fc.lastList
+	if req.GetFrom() != "2025-12-01" || req.GetTo() != "2025-12-31" || req.GetQuery() != "lunch" ||
+		req.GetSort() != "amount_asc" || req.GetPageSize() != 2 || req.GetPageToken() != "abc" {
+		t.Fatalf("unexpected request: %v", req)
+	}
+	if strings.Join(req.GetCategories(), ",") != "food,cafe,taxi" {
+		t.Fatalf("unexpected categories: %v", req.GetCategories())
+	}
+	if req.MinAmount == nil || req.GetMinAmount() != "10" || req.MaxAmount == nil || req.GetMaxAmount() != "500.5" {
+		t.Fatalf("unexpected amount range: %v", req)
+	}
+
+	rr = doReq(t, h, http.MethodGet, "/api/transactions?page_size=abc", "")
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+}
+
+func TestExportTransactions(t *testing.T) {
+	fc := newFakeClient()
+	h := server.NewRouter(fc)
+
+	_ = doReq(t, h, http.MethodPost, "/api/transactions",
+		`{"amount":100,"category":"food","description":"lunch, big","date":"2025-12-19T00:00:00+03:00"}`)
+	_ = doReq(t, h, http.MethodPost, "/api/transactions",
+		`{"amount":2.5,"category":"taxi","description":"ride","date":"2025-12-20T00:00:00+03:00"}`)
+
+	t.Run("ndjson", func(t *testing.T) {
+		rr := doReq(t, h, http.MethodGet, "/api/transactions/export", "")
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		lines := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("expected 2 lines, got %q", rr.Body.String())
+		}
+		var got map[string]any
+		if err := json.Unmarshal([]byte(lines[1]), &got); err != nil || got["category"] != "taxi" {
+			t.Fatalf("unexpected line %q: %v", lines[1], err)
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		rr := doReq(t, h, http.MethodGet, "/api/transactions/export?format=csv", "")
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		want := "id,account_id,kind,amount,currency,category,description,date\n" +
+			"1,1,expense,100,RUB,food,\"lunch, big\",2025-12-19T00:00:00+03:00\n" +
+			"2,1,expense,2.5,RUB,taxi,ride,2025-12-20T00:00:00+03:00\n"
+		if rr.Body.String() != want {
+			t.Fatalf("unexpected csv:\n%s", rr.Body.String())
+		}
+	})
+
+	t.Run("error_before_data", func(t *testing.T) {
+		rr := doReq(t, h, http.MethodGet, "/api/transactions/export?sort=bad", "")
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("bad_format", func(t *testing.T) {
+		rr := doReq(t, h, http.MethodGet, "/api/transactions/export?format=xml", "")
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("only_export_skips_timeout", func(t *testing.T) {
+		_ = doReq(t, h, http.MethodGet, "/api/transactions/export", "")
+		if fc.hasDeadline {
+			t.Fatal("export must not be limited by the request timeout")
+		}
+		_ = doReq(t, h, http.MethodGet, "/api/transactions", "")
+		if !fc.hasDeadline {
+			t.Fatal("list must be limited by the request timeout")
+		}
+	})
+}
+
+func TestMoneyPassthrough(t *testing.T) {
+	fc := newFakeClient()
+	h := server.NewRouter(fc)
+
+	rr := doReq(t, h, http.MethodPost, "/api/transactions",
+		`{"amount":"12.30","category":"food","description":"x","date":"2025-12-19T00:00:00+03:00"}`)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	if fc.transactions[0].GetAmount() != "12.30" {
+		t.Fatalf("amount must reach ledger unchanged, got %q", fc.transactions[0].GetAmount())
+	}
+	if !strings.Contains(rr.Body.String(), `"amount":12.30`) {
+		t.Fatalf("amount must be returned as a number, body=%s", rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodPost, "/api/transactions",
+		`{"amount":1e3,"category":"food","description":"x","date":"2025-12-19T00:00:00+03:00"}`)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+}
+
+func TestSettingsAndCurrency(t *testing.T) {
+	fc := newFakeClient()
+	h := server.NewRouter(fc)
+
+	rr := doReq(t, h, http.MethodGet, "/api/settings", "")
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"base_currency":"RUB"`) {
+		t.Fatalf("unexpected settings: %d %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodPut, "/api/settings", `{"base_currency":"eur"}`)
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"base_currency":"EUR"`) {
+		t.Fatalf("unexpected settings: %d %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodPut, "/api/settings", `{"base_currency":"euro"}`)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodPost, "/api/transactions",
+		`{"amount":10,"currency":"USD","category":"food","description":"x","date":"2025-12-19T00:00:00+03:00"}`)
+	if rr.Code != http.StatusCreated || !strings.Contains(rr.Body.String(), `"currency":"USD"`) {
+		t.Fatalf("unexpected create: %d %s", rr.Code, rr.Body.String())
+	}
+	if fc.transactions[0].GetCurrency() != "USD" {
+		t.Fatalf("currency must reach ledger, got %q", fc.transactions[0].GetCurrency())
+	}
+
+	// PUT без валюты не должен сбрасывать валюту транзакции.
+	rr = doReq(t, h, http.MethodPut, "/api/transactions/1",
+		`{"amount":12,"category":"food","description":"x","date":"2025-12-19T00:00:00+03:00"}`)
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"currency":"USD"`) {
+		t.Fatalf("unexpected update: %d %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodPost, "/api/transactions",
+		`{"amount":5,"category":"food","description":"y","date":"2025-12-19T00:00:00+03:00"}`)
+	if rr.Code != http.StatusCreated || !strings.Contains(rr.Body.String(), `"currency":"EUR"`) {
+		t.Fatalf("base currency must be the default: %d %s", rr.Code, rr.Body.String())
+	}
+	rr = doReq(t, h, http.MethodGet, "/api/reports/summary?from=2025-12-01&to=2025-12-31", "")
+	if rr.Code != http.StatusOK || rr.Header().Get("X-Report-Currency") != "EUR" {
+		t.Fatalf("report must name its currency: %d %q", rr.Code, rr.Header().Get("X-Report-Currency"))
+	}
+}
+
+func TestCashFlow(t *testing.T) {
+	fc := newFakeClient()
+	h := server.NewRouter(fc)
+
+	for _, body := range []string{
+		`{"kind":"income","amount":1000,"category":"salary","date":"2025-12-01T00:00:00+03:00"}`,
+		`{"amount":300,"category":"food","date":"2025-12-02T00:00:00+03:00"}`,
+		`{"kind":"refund","amount":50,"category":"food","date":"2025-12-03T00:00:00+03:00"}`,
+	} {
+		if rr := doReq(t, h, http.MethodPost, "/api/transactions", body); rr.Code != http.StatusCreated {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusCreated, rr.Code, rr.Body.String())
+		}
+	}
+
+	for _, body := range []string{
+		`{"kind":"gift","amount":1,"category":"x","date":"2025-12-04T00:00:00+03:00"}`,
+		// Переводы создаются только через /api/transfers.
+		`{"kind":"transfer","amount":-200,"category":"savings","date":"2025-12-04T00:00:00+03:00"}`,
+	} {
+		if rr := doReq(t, h, http.MethodPost, "/api/transactions", body); rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+		}
+	}
+
+	rr := doReq(t, h, http.MethodGet, "/api/reports/cashflow?from=2025-12-01&to=2025-12-31", "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var got struct {
+		Currency string `json:"currency"`
+		Total    struct {
+			Income      float64 `json:"income"`
+			Expense     float64 `json:"expense"`
+			Net         float64 `json:"net"`
+			SavingsRate float64 `json:"savings_rate"`
+		} `json:"total"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.Currency != "RUB" || got.Total.Income != 1000 || got.Total.Expense != 250 || got.Total.Net != 750 || got.Total.SavingsRate != 75 {
+		t.Fatalf("unexpected cash flow: %s", rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodGet, "/api/reports/cashflow?from=2025-12-01", "")
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodGet, "/api/transactions?kind=income,refund", "")
+	if rr.Code != http.StatusOK || len(fc.lastList.GetKinds()) != 2 {
+		t.Fatalf("kinds must reach ledger, got %v", fc.lastList.GetKinds())
+	}
+}
+
+func TestAccountsAndTransfers(t *testing.T) {
+	fc := newFakeClient()
+	h := server.NewRouter(fc)
+
+	rr := doReq(t, h, http.MethodPost, "/api/accounts", `{"name":"card","currency":"RUB","opening_balance":"1000.50"}`)
+	if rr.Code != http.StatusCreated || !strings.Contains(rr.Body.String(), `"opening_balance":1000.50`) {
+		t.Fatalf("unexpected create: %d %s", rr.Code, rr.Body.String())
+	}
+	if fc.accounts[0].GetOpeningBalance() != "1000.50" {
+		t.Fatalf("opening balance must reach ledger unchanged, got %q", fc.accounts[0].GetOpeningBalance())
+	}
+	rr = doReq(t, h, http.MethodPost, "/api/accounts", `{"name":"cash"}`)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	rr = doReq(t, h, http.MethodPost, "/api/accounts", `{"name":" "}`)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodGet, "/api/accounts", "")
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"name":"cash"`) {
+		t.Fatalf("unexpected list: %d %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodPost, "/api/transactions",
+		`{"account_id":1,"amount":100.5,"category":"food","date":"2025-12-19T00:00:00+03:00"}`)
+	if rr.Code != http.StatusCreated || !strings.Contains(rr.Body.String(), `"account_id":1`) {
+		t.Fatalf("unexpected create: %d %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodPost, "/api/transfers",
+		`{"from_account_id":1,"to_account_id":2,"amount":300,"date":"2025-12-20T00:00:00+03:00"}`)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	var tr struct {
+		Withdrawal struct {
+			Amount     float64 `json:"amount"`
+			TransferID int64   `json:"transfer_id"`
+		} `json:"withdrawal"`
+		Deposit struct {
+			AccountID int64 `json:"account_id"`
+		} `json:"deposit"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &tr); err != nil || tr.Withdrawal.Amount != -300 || tr.Withdrawal.TransferID == 0 || tr.Deposit.AccountID != 2 {
+		t.Fatalf("unexpected transfer: %s (%v)", rr.Body.String(), err)
+	}
+
+	rr = doReq(t, h, http.MethodPost, "/api/transfers",
+		`{"from_account_id":1,"to_account_id":9,"amount":1,"date":"2025-12-20T00:00:00+03:00"}`)
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusNotFound, rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodGet, "/api/accounts/balances", "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var bal struct {
+		Items []struct {
+			Balance float64 `json:"balance"`
+		} `json:"items"`
+		Total float64 `json:"total"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &bal); err != nil || len(bal.Items) != 2 || bal.Items[0].Balance != 600 || bal.Items[1].Balance != 300 || bal.Total != 900 {
+		t.Fatalf("unexpected balances: %s (%v)", rr.Body.String(), err)
+	}
+
+	rr = doReq(t, h, http.MethodGet, "/api/transactions?account_id=1,2", "")
+	if rr.Code != http.StatusOK || len(fc.lastList.GetAccountIds()) != 2 {
+		t.Fatalf("account ids must reach ledger, got %v", fc.lastList.GetAccountIds())
+	}
+	rr = doReq(t, h, http.MethodGet, "/api/transactions?account_id=x", "")
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+}
+
+func TestLedgerJournal(t *testing.T) {
+	fc := newFakeClient()
+	h := server.NewRouter(fc)
+
+	rr := doReq(t, h, http.MethodPost, "/api/transactions",
+		`{"amount":29.5,"category":"food","date":"2025-12-19T00:00:00+03:00"}`)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusCreated, rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodGet, "/api/ledger/trial-balance?on=2025-12-31", "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var tb struct {
+		On    string `json:"on"`
+		Lines []struct {
+			Account string  `json:"account"`
+			Debit   float64 `json:"debit"`
+			Balance float64 `json:"balance"`
+		} `json:"lines"`
+		Balanced bool `json:"balanced"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &tb); err != nil || tb.On != "2025-12-31" || !tb.Balanced || len(tb.Lines) != 2 ||
+		tb.Lines[1].Account != "expenses:food" || tb.Lines[1].Debit != 29.5 || tb.Lines[0].Balance != -29.5 {
+		t.Fatalf("unexpected trial balance: %s (%v)", rr.Body.String(), err)
+	}
+
+	rr = doReq(t, h, http.MethodGet, "/api/ledger/statement?account=assets:1&from=2025-12-01&to=2025-12-31", "")
+	if rr.Code != http.StatusOK || fc.lastStmt.GetAccount() != "assets:1" || fc.lastStmt.GetFrom() != "2025-12-01" {
+		t.Fatalf("unexpected statement: %d %s", rr.Code, rr.Body.String())
+	}
+	if !strings.Contains(rr.Body.String(), `"opening":100`) || !strings.Contains(rr.Body.String(), `"balance":70.5`) ||
+		!strings.Contains(rr.Body.String(), `"transaction_id":5`) {
+		t.Fatalf("unexpected statement: %s", rr.Body.String())
+	}
+
+	for _, url := range []string{
+		"/api/ledger/statement?from=2025-12-01&to=2025-12-31",
+		"/api/ledger/statement?account=wallet&from=2025-12-01&to=2025-12-31",
+	} {
+		if rr = doReq(t, h, http.MethodGet, url, ""); rr.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected %d, got %d, body=%s", url, http.StatusBadRequest, rr.Code, rr.Body.String())
+		}
+	}
+	if rr = doReq(t, h, http.MethodPost, "/api/ledger/trial-balance", ""); rr.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected %d, got %d", http.StatusMethodNotAllowed, rr.Code)
+	}
+}
+
+func TestRecurring(t *testing.T) {
+	fc := newFakeClient()
+	h := server.NewRouter(fc)
+
+	rr := doReq(t, h, http.MethodPost, "/api/recurring",
+		`{"amount":30000,"category":" Rent ","frequency":"monthly","start_date":"2025-01-10"}`)
+	if rr.Code != http.StatusCreated || !strings.Contains(rr.Body.String(), `"next_date":"2025-01-10"`) ||
+		!strings.Contains(rr.Body.String(), `"interval":1`) || strings.Contains(rr.Body.String(), "end_date") {
+		t.Fatalf("unexpected create: %d %s", rr.Code, rr.Body.String())
+	}
+	rr = doReq(t, h, http.MethodPost, "/api/recurring", `{"amount":1,"category":"x","frequency":"hourly","start_date":"2025-01-10"}`)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodGet, "/api/recurring/1/preview?count=3", "")
+	if rr.Code != http.StatusOK || rr.Body.String() != `{"dates":["2025-01-10","2025-02-10","2025-03-10"]}`+"\n" {
+		t.Fatalf("unexpected preview: %d %q", rr.Code, rr.Body.String())
+	}
+	if rr = doReq(t, h, http.MethodGet, "/api/recurring/1/preview?count=x", ""); rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodPost, "/api/recurring/1/pause", "")
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"paused":true`) {
+		t.Fatalf("unexpected pause: %d %s", rr.Code, rr.Body.String())
+	}
+	rr = doReq(t, h, http.MethodGet, "/api/recurring", "")
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"paused":true`) {
+		t.Fatalf("unexpected list: %d %s", rr.Code, rr.Body.String())
+	}
+	rr = doReq(t, h, http.MethodPost, "/api/recurring/1/resume", "")
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"paused":false`) {
+		t.Fatalf("unexpected resume: %d %s", rr.Code, rr.Body.String())
+	}
+
+	if rr = doReq(t, h, http.MethodPost, "/api/recurring/9/pause", ""); rr.Code != http.StatusNotFound {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusNotFound, rr.Code, rr.Body.String())
+	}
+	if rr = doReq(t, h, http.MethodGet, "/api/recurring/1/pause", ""); rr.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected %d, got %d", http.StatusMethodNotAllowed, rr.Code)
+	}
+}
+
+func TestCategories(t *testing.T) {
+	fc := newFakeClient()
+	h := server.NewRouter(fc)
+
+	rr := doReq(t, h, http.MethodPost, "/api/categories", `{"name":" Еда ","icon":"🍽"}`)
+	if rr.Code != http.StatusCreated || !strings.Contains(rr.Body.String(), `"name":"еда"`) ||
+		strings.Contains(rr.Body.String(), "parent_id") {
+		t.Fatalf("unexpected create: %d %s", rr.Code, rr.Body.String())
+	}
+	rr = doReq(t, h, http.MethodPost, "/api/categories", `{"name":"кафе","parent_id":1}`)
+	if rr.Code != http.StatusCreated || !strings.Contains(rr.Body.String(), `"parent_id":1`) {
+		t.Fatalf("unexpected create: %d %s", rr.Code, rr.Body.String())
+	}
+	if rr = doReq(t, h, http.MethodPost, "/api/categories", `{"name":"x","parent_id":9}`); rr.Code != http.StatusNotFound {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusNotFound, rr.Code, rr.Body.String())
+	}
+	if rr = doReq(t, h, http.MethodPost, "/api/categories", `{"name":" "}`); rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodPatch, "/api/categories/2", `{"name":"Рестораны","archived":true}`)
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"name":"рестораны"`) ||
+		!strings.Contains(rr.Body.String(), `"archived":true`) {
+		t.Fatalf("unexpected patch: %d %s", rr.Code, rr.Body.String())
+	}
+	if rr = doReq(t, h, http.MethodPatch, "/api/categories/2", `{"parent_id":2}`); rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+	if rr = doReq(t, h, http.MethodDelete, "/api/categories/2", ""); rr.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected %d, got %d", http.StatusMethodNotAllowed, rr.Code)
+	}
+
+	rr = doReq(t, h, http.MethodPost, "/api/categories/2/merge", `{"into_id":1}`)
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"id":1`) {
+		t.Fatalf("unexpected merge: %d %s", rr.Code, rr.Body.String())
+	}
+	if rr = doReq(t, h, http.MethodPost, "/api/categories/1/merge", `{"into_id":1}`); rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+	rr = doReq(t, h, http.MethodGet, "/api/categories", "")
+	if rr.Code != http.StatusOK || strings.Count(rr.Body.String(), `"id"`) != 1 {
+		t.Fatalf("unexpected list: %d %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestCategoryAliases(t *testing.T) {
+	fc := newFakeClient()
+	h := server.NewRouter(fc)
+
+	rr := doReq(t, h, http.MethodPost, "/api/categories/aliases", `{"alias":"Taxi","category":"такси"}`)
+	if rr.Code != http.StatusCreated || rr.Body.String() != `{"alias":"taxi","category":"такси"}`+"\n" {
+		t.Fatalf("unexpected alias: %d %s", rr.Code, rr.Body.String())
+	}
+	if rr = doReq(t, h, http.MethodPost, "/api/categories/aliases", `{"alias":"такси","category":"такси"}`); rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+	rr = doReq(t, h, http.MethodGet, "/api/categories/aliases", "")
+	if rr.Code != http.StatusOK || rr.Body.String() != `[{"alias":"taxi","category":"такси"}]`+"\n" {
+		t.Fatalf("unexpected list: %d %s", rr.Code, rr.Body.String())
+	}
+	if rr = doReq(t, h, http.MethodDelete, "/api/categories/aliases?alias=taxi", ""); rr.Code != http.StatusNoContent {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusNoContent, rr.Code, rr.Body.String())
+	}
+	if rr = doReq(t, h, http.MethodDelete, "/api/categories/aliases?alias=taxi", ""); rr.Code != http.StatusNotFound {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusNotFound, rr.Code, rr.Body.String())
+	}
+	if rr = doReq(t, h, http.MethodDelete, "/api/categories/aliases", ""); rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodGet, "/api/categories/suggestions", "")
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"from":"taxi","into":"такси","reason":"alias","from_count":2`) {
+		t.Fatalf("unexpected suggestions: %d %s", rr.Code, rr.Body.String())
+	}
+	rr = doReq(t, h, http.MethodPost, "/api/categories/merge", `{"from":"taxi","into":"Такси"}`)
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"name":"такси"`) {
+		t.Fatalf("unexpected merge: %d %s", rr.Code, rr.Body.String())
+	}
+	if rr = doReq(t, h, http.MethodGet, "/api/categories/merge", ""); rr.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected %d, got %d", http.StatusMethodNotAllowed, rr.Code)
+	}
+}
+
+func TestRules(t *testing.T) {
+	fc := newFakeClient()
+	h := server.NewRouter(fc)
+
+	rr := doReq(t, h, http.MethodPost, "/api/rules",
+		`{"name":"такси","description_contains":"yandex taxi","min_amount":"100.50","category":"Такси","tags":["поездки"]}`)
+	if rr.Code != http.StatusCreated || !strings.Contains(rr.Body.String(), `"id":1`) ||
+		!strings.Contains(rr.Body.String(), `"min_amount":100.50`) || strings.Contains(rr.Body.String(), "max_amount") ||
+		!strings.Contains(rr.Body.String(), `"category":"такси","tags":["поездки"]`) {
+		t.Fatalf("unexpected create: %d %s", rr.Code, rr.Body.String())
+	}
+	if rr = doReq(t, h, http.MethodPost, "/api/rules", `{"category":"x"}`); rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+	if rr = doReq(t, h, http.MethodPost, "/api/rules", `{"description_contains":"x","min_amount":"1e3","category":"x"}`); rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusBadRequest, rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodPost, "/api/rules/test", `{"description":"YANDEX TAXI 42","amount":500}`)
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"fired":{"id":1`) {
+		t.Fatalf("unexpected dry run: %d %s", rr.Code, rr.Body.String())
+	}
+	rr = doReq(t, h, http.MethodPost, "/api/rules/test", `{"description":"cinema"}`)
+	if rr.Code != http.StatusOK || rr.Body.String() != `{"fired":null,"matching":[]}`+"\n" {
+		t.Fatalf("unexpected dry run: %d %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodPost, "/api/transactions",
+		`{"amount":500,"category":"транспорт","auto_category":true,"description":"yandex taxi","date":"2025-12-01T10:00:00Z"}`)
+	if rr.Code != http.StatusCreated || !strings.Contains(rr.Body.String(), `"category":"такси"`) {
+		t.Fatalf("unexpected auto category: %d %s", rr.Code, rr.Body.String())
+	}
+	rr = doReq(t, h, http.MethodPost, "/api/transactions/bulk",
+		`[{"amount":1,"description":"yandex taxi","date":"2025-12-01T10:00:00Z"},{"amount":1,"description":"cinema","date":"2025-12-01T10:00:00Z"}]`)
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"accepted":1`) {
+		t.Fatalf("unexpected bulk import: %d %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodPut, "/api/rules/1", `{"description_contains":"uber","category":"такси","disabled":true}`)
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"disabled":true`) || !strings.Contains(rr.Body.String(), `"tags":[]`) {
+		t.Fatalf("unexpected update: %d %s", rr.Code, rr.Body.String())
+	}
+	rr = doReq(t, h, http.MethodGet, "/api/rules", "")
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"description_contains":"uber"`) {
+		t.Fatalf("unexpected list: %d %s", rr.Code, rr.Body.String())
+	}
+	if rr = doReq(t, h, http.MethodDelete, "/api/rules/1", ""); rr.Code != http.StatusNoContent {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusNoContent, rr.Code, rr.Body.String())
+	}
+	if rr = doReq(t, h, http.MethodDelete, "/api/rules/1", ""); rr.Code != http.StatusNotFound {
+		t.Fatalf("expected %d, got %d, body=%s", http.StatusNotFound, rr.Code, rr.Body.String())
+	}
+	if rr = doReq(t, h, http.MethodGet, "/api/rules/test", ""); rr.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected %d, got %d", http.StatusMethodNotAllowed, rr.Code)
+	}
+}
+
+func TestTags(t *testing.T) {
+	fc := newFakeClient()
+	h := server.NewRouter(fc)
+
+	rr := doReq(t, h, http.MethodPost, "/api/transactions",
+		`{"amount":300,"category":"еда","tags":["отпуск","кафе"],"date":"2025-12-01T10:00:00Z"}`)
+	if rr.Code != http.StatusCreated || !strings.Contains(rr.Body.String(), `"tags":["отпуск","кафе"]`) {
+		t.Fatalf("unexpected create: %d %s", rr.Code, rr.Body.String())
+	}
+	rr = doReq(t, h, http.MethodPost, "/api/transactions", `{"amount":100,"category":"еда","date":"2025-12-02T10:00:00Z"}`)
+	if rr.Code != http.StatusCreated || strings.Contains(rr.Body.String(), `"tags"`) {
+		t.Fatalf("unexpected create: %d %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodPatch, "/api/transactions/2", `{"tags":["кафе"]}`)
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"tags":["кафе"]`) {
+		t.Fatalf("unexpected patch: %d %s", rr.Code, rr.Body.String())
+	}
+	rr = doReq(t, h, http.MethodPatch, "/api/transactions/2", `{"amount":150}`)
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"tags":["кафе"]`) {
+		t.Fatalf("patch without tags must keep them: %d %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodGet, "/api/reports/tags?from=2025-12-01&to=2025-12-31", "")
+	if rr.Code != http.StatusOK || rr.Body.String() != `{"кафе":450,"отпуск":300}`+"\n" {
+		t.Fatalf("unexpected tag report: %d %s", rr.Code, rr.Body.String())
+	}
+	if rr = doReq(t, h, http.MethodGet, "/api/reports/tags?from=2025-12-01", ""); rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+
+	if rr = doReq(t, h, http.MethodGet, "/api/transactions?tag=кафе,отпуск&tag=x", ""); rr.Code != http.StatusOK {
+		t.Fatalf("unexpected list: %d %s", rr.Code, rr.Body.String())
+	}
+	if got := fc.lastList.GetTags(); !slices.Equal(got, []string{"кафе", "отпуск", "x"}) {
+		t.Fatalf("unexpected tag filter: %v", got)
+	}
+
+	rr = doReq(t, h, http.MethodPatch, "/api/transactions/2", `{"tags":[]}`)
+	if rr.Code != http.StatusOK || strings.Contains(rr.Body.String(), `"tags"`) {
+		t.Fatalf("unexpected patch: %d %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestBudgetProgress(t *testing.T) {
+	fc := newFakeClient()
+	h := server.NewRouter(fc)
+
+	for _, body := range []string{`{"category":"food","limit":1000}`, `{"category":"cafe","limit":500}`} {
+		if rr := doReq(t, h, http.MethodPost, "/api/budgets", body); rr.Code != http.StatusCreated {
+			t.Fatalf("set budget: %d %s", rr.Code, rr.Body.String())
+		}
+	}
+	if rr := doReq(t, h, http.MethodPost, "/api/transactions", `{"amount":250,"category":"food","date":"2025-12-01T10:00:00Z"}`); rr.Code != http.StatusCreated {
+		t.Fatalf("add transaction: %d %s", rr.Code, rr.Body.String())
+	}
+
+	rr := doReq(t, h, http.MethodGet, "/api/reports/budgets?from=2025-12-01&to=2025-12-31", "")
+	want := `[{"category":"cafe","period":"fixed","currency":"RUB","limit":500,"spent":0,"remaining":500,"percent":0,"projected":0},` +
+		`{"category":"food","period":"fixed","currency":"RUB","limit":1000,"spent":250,"remaining":750,"percent":25,"projected":250}]` + "\n"
+	if rr.Code != http.StatusOK || rr.Body.String() != want {
+		t.Fatalf("unexpected progress: %d %s", rr.Code, rr.Body.String())
+	}
+
+	if rr = doReq(t, h, http.MethodGet, "/api/reports/budgets?to=2025-12-31", ""); rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+	if rr = doReq(t, h, http.MethodGet, "/api/reports/budgets?from=2025-12-31&to=2025-12-01", ""); rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+	if rr = doReq(t, h, http.MethodPost, "/api/reports/budgets?from=2025-12-01&to=2025-12-31", ""); rr.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected %d, got %d", http.StatusMethodNotAllowed, rr.Code)
+	}
+}
+
+func TestSpendingTimeSeries(t *testing.T) {
+	fc := newFakeClient()
+	h := server.NewRouter(fc)
+
+	rr := doReq(t, h, http.MethodGet, "/api/reports/timeseries?from=2025-12-03&to=2025-12-10&bucket=week&timezone=Europe/Moscow", "")
+	want := `{"bucket":"week","currency":"RUB","points":[` +
+		`{"from":"2025-12-03","to":"2025-12-07","label":"2025-W49","total":150},` +
+		`{"from":"2025-12-08","to":"2025-12-10","label":"2025-W50","total":0}]}` + "\n"
+	if rr.Code != http.StatusOK || rr.Body.String() != want {
+		t.Fatalf("unexpected series: %d %s", rr.Code, rr.Body.String())
+	}
+	if s := fc.lastSeries; s.GetFrom() != "2025-12-03" || s.GetTo() != "2025-12-10" || s.GetBucket() != "week" ||
+		s.GetTimezone() != "Europe/Moscow" || s.GetByCategory() {
+		t.Fatalf("unexpected request: %v", s)
+	}
+
+	rr = doReq(t, h, http.MethodGet, "/api/reports/timeseries?from=2025-12-03&by_category=true", "")
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"categories":["еда","кафе"]`) ||
+		!strings.Contains(rr.Body.String(), `"by_category":{"еда":0,"кафе":0}`) {
+		t.Fatalf("unexpected split series: %d %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodGet, "/api/reports/timeseries?from=2025-12-03&by_category=1&format=csv", "")
+	wantCSV := "label,from,to,total,еда,кафе\n2025-W49,2025-12-03,2025-12-07,150,100,50\n2025-W50,2025-12-08,2025-12-10,0,0,0\n"
+	if rr.Code != http.StatusOK || rr.Body.String() != wantCSV || rr.Header().Get("X-Report-Currency") != "RUB" {
+		t.Fatalf("unexpected csv: %d %q", rr.Code, rr.Body.String())
+	}
+
+	for _, url := range []string{
+		"/api/reports/timeseries?to=2025-12-10",
+		"/api/reports/timeseries?from=2025-12-03&format=xml",
+		"/api/reports/timeseries?from=2025-12-03&by_category=maybe",
+		"/api/reports/timeseries?from=2025-12-03&bucket=hour",
+	} {
+		if rr = doReq(t, h, http.MethodGet, url, ""); rr.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected %d, got %d", url, http.StatusBadRequest, rr.Code)
+		}
+	}
+}
+
+func TestComparePeriods(t *testing.T) {
+	fc := newFakeClient()
+	h := server.NewRouter(fc)
+
+	rr := doReq(t, h, http.MethodGet, "/api/reports/compare?from=2025-12-01&to=2025-12-31", "")
+	want := `{"from":"2025-12-01","to":"2025-12-31","compare_from":"2025-11-01","compare_to":"2025-11-30","currency":"RUB","items":[` +
+		`{"category":"кафе","current":300,"previous":0,"delta":300,"delta_percent":null,"status":"new"},` +
+		`{"category":"еда","current":1000,"previous":800,"delta":200,"delta_percent":25}],` +
+		`"total":{"current":1300,"previous":800,"delta":500,"delta_percent":62.5},"new_categories":["кафе"],"disappeared_categories":[]}` + "\n"
+	if rr.Code != http.StatusOK || rr.Body.String() != want {
+		t.Fatalf("unexpected comparison: %d %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doReq(t, h, http.MethodGet, "/api/reports/compare?from=2025-12-01&to=2025-12-31&compare_from=2024-12-01&compare_to=2024-12-31", "")
+	if rr.Code != http.StatusOK || fc.lastCompare.GetCompareFrom() != "2024-12-01" || fc.lastCompare.GetCompareTo() != "2024-12-31" {
+		t.Fatalf("unexpected comparison: %d %s", rr.Code, rr.Body.String())
+	}
+
+	for _, url := range []string{
+		"/api/reports/compare?from=2025-12-01",
+		"/api/reports/compare?from=2025-12-01&to=2025-12-31&compare_from=2024-12-01",
+		"/api/reports/compare?from=2025-12-31&to=2025-12-01",
+	} {
+		if rr = doReq(t, h, http.MethodGet, url, ""); rr.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected %d, got %d", url, http.StatusBadRequest, rr.Code)
+		}
+	}
+}
+
+func TestAnomalies(t *testing.T) {
+	h := server.NewRouter(newFakeClient())
+
+	rr := doReq(t, h, http.MethodGet, "/api/reports/anomalies?from=2025-12-01&to=2025-12-31", "")
+	want := `{"items":[` +
+		`{"kind":"transaction","category":"еда","transaction_id":7,"from":"2025-12-09","to":"2025-12-09","amount":5000,"typical":1000,"score":54,"currency":"RUB"},` +
+		`{"kind":"weekly","category":"такси","from":"2025-12-08","to":"2025-12-14","amount":0,"typical":300,"score":-40.5,"currency":"RUB"}]}` + "\n"
+	if rr.Code != http.StatusOK || rr.Body.String() != want {
+		t.Fatalf("unexpected anomalies: %d %s", rr.Code, rr.Body.String())
+	}
+	for _, url := range []string{"/api/reports/anomalies?from=2025-12-01", "/api/reports/anomalies?from=2025-12-31&to=2025-12-01"} {
+		if rr = doReq(t, h, http.MethodGet, url, ""); rr.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected %d, got %d", url, http.StatusBadRequest, rr.Code)
+		}
+	}
+	if rr = doReq(t, h, http.MethodPost, "/api/reports/anomalies?from=2025-12-01&to=2025-12-31", ""); rr.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected %d, got %d", http.StatusMethodNotAllowed, rr.Code)
+	}
+
+	t.Run("check_on_create", func(t *testing.T) {
+		rr := doReq(t, h, http.MethodPost, "/api/transactions", `{"amount":20000,"category":"еда","date":"2025-12-10T12:00:00Z","check_anomaly":true}`)
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusCreated, rr.Code, rr.Body.String())
+		}
+		var got struct {
+			ID      int                  `json:"id"`
+			Anomaly *api.AnomalyResponse `json:"anomaly"`
+		}
+		if err := json.NewDecoder(bytes.NewReader(rr.Body.Bytes())).Decode(&got); err != nil {
+			t.Fatalf("decode error: %v", err)
+		}
+		if got.Anomaly == nil || got.Anomaly.TransactionID != int64(got.ID) || got.Anomaly.Kind != "transaction" {
+			t.Fatalf("expected anomaly, got %s", rr.Body.String())
+		}
+
+		rr = doReq(t, h, http.MethodPost, "/api/transactions", `{"amount":20000,"category":"еда","date":"2025-12-10T12:00:00Z"}`)
+		if rr.Code != http.StatusCreated || strings.Contains(rr.Body.String(), "anomaly") {
+			t.Fatalf("anomaly must be checked only on request: %d %s", rr.Code, rr.Body.String())
+		}
+	})
+}
+
+type fakeKeys map[string]ed25519.PublicKey
+
+func (f fakeKeys) PublicKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	k, ok := f[kid]
+	if !ok {
+		return nil, errors.New("unknown kid")
+	}
+	return k, nil
+}
+
+type fakeRevocation map[string]bool
+
+func (f fakeRevocation) IsRevoked(ctx context.Context, jti string, exp time.Time) (bool, error) {
+	return f[jti], nil
+}
+
+func TestJWTProtectsAPI(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	_, otherPriv, _ := ed25519.GenerateKey(rand.Reader)
+	revoked := fakeRevocation{"revoked-jti": true}
+	h := middleware.JWT(fakeKeys{"k1": pub}, revoked)(server.NewRouter(newFakeClient()))
+
+	signWith := func(t *testing.T, key ed25519.PrivateKey, claims jwt.MapClaims) string {
+		t.Helper()
+		tok := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+		tok.Header["kid"] = "k1"
+		s, err := tok.SignedString(key)
+		if err != nil {
+			t.Fatalf("sign error: %v", err)
+		}
+		return s
+	}
+	sign := func(t *testing.T, key ed25519.PrivateKey, sub string) string {
+		return signWith(t, key, jwt.MapClaims{
+			"sub": sub,
+			"jti": "jti-" + sub,
+			"exp": time.Now().Add(time.Hour).Unix(),
+		})
+	}
+
+	doAuthReq := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/budgets", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("missing_token", func(t *testing.T) {
+		rr := doAuthReq("")
+		if rr.Code != http.StatusUnauthorized {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusUnauthorized, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("wrong_key", func(t *testing.T) {
+		rr := doAuthReq(sign(t, otherPriv, "u1"))
+		if rr.Code != http.StatusUnauthorized {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusUnauthorized, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		rr := doAuthReq(sign(t, priv, "u1"))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		rr := doAuthReq(signWith(t, priv, jwt.MapClaims{
+			"sub": "u1",
+			"jti": "revoked-jti",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}))
+		if rr.Code != http.StatusUnauthorized {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusUnauthorized, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("hs256_rejected", func(t *testing.T) {
+		tok := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"sub": "u1",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		})
+		tok.Header["kid"] = "k1"
+		raw, err := tok.SignedString([]byte(pub))
+		if err != nil {
+			t.Fatalf("sign error: %v", err)
+		}
+		rr := doAuthReq(raw)
+		if rr.Code != http.StatusUnauthorized {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusUnauthorized, rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("ping_is_public", func(t *testing.T) {
+		rr := doReq(t, h, http.MethodGet, "/ping", "")
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected %d, got %d, body=%s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 
 	"final/gateway/internal/handler"
 	"final/gateway/internal/httpx"
+	"final/gateway/internal/middleware"
 	ledgerv1 "final/gen/ledger/v1"
 )
 
@@ -47,16 +48,189 @@ func NewRouter(client ledgerv1.LedgerServiceClient) http.Handler {
 		httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
 	})
 
+	mux.HandleFunc("/api/transactions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			h.ReplaceTransaction(w, r)
+		case http.MethodPatch:
+			h.PatchTransaction(w, r)
+		case http.MethodDelete:
+			h.DeleteTransaction(w, r)
+		default:
+			httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	})
+
+	mux.HandleFunc("/api/accounts", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			h.CreateAccount(w, r)
+		case http.MethodGet:
+			h.ListAccounts(w, r)
+		default:
+			httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	})
+
+	mux.HandleFunc("/api/accounts/balances", func(w http.ResponseWriter, r *http.Request) {
+		h.GetBalances(w, r)
+	})
+
+	mux.HandleFunc("/api/transfers", func(w http.ResponseWriter, r *http.Request) {
+		h.Transfer(w, r)
+	})
+
+	mux.HandleFunc("/api/recurring", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			h.CreateRecurring(w, r)
+		case http.MethodGet:
+			h.ListRecurring(w, r)
+		default:
+			httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	})
+
+	mux.HandleFunc("/api/recurring/{id}/pause", h.SetRecurringPaused(true))
+	mux.HandleFunc("/api/recurring/{id}/resume", h.SetRecurringPaused(false))
+	mux.HandleFunc("/api/recurring/{id}/preview", func(w http.ResponseWriter, r *http.Request) {
+		h.PreviewRecurring(w, r)
+	})
+
+	mux.HandleFunc("/api/categories", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			h.CreateCategory(w, r)
+		case http.MethodGet:
+			h.ListCategories(w, r)
+		default:
+			httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	})
+
+	mux.HandleFunc("/api/categories/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		h.PatchCategory(w, r)
+	})
+	mux.HandleFunc("/api/categories/{id}/merge", func(w http.ResponseWriter, r *http.Request) {
+		h.MergeCategory(w, r)
+	})
+	mux.HandleFunc("/api/categories/merge", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		h.MergeCategoryNames(w, r)
+	})
+
+	mux.HandleFunc("/api/categories/aliases", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			h.SetCategoryAlias(w, r)
+		case http.MethodGet:
+			h.ListCategoryAliases(w, r)
+		case http.MethodDelete:
+			h.DeleteCategoryAlias(w, r)
+		default:
+			httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	})
+	mux.HandleFunc("/api/categories/suggestions", func(w http.ResponseWriter, r *http.Request) {
+		h.SuggestCategoryMerges(w, r)
+	})
+
+	mux.HandleFunc("/api/rules", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			h.CreateRule(w, r)
+		case http.MethodGet:
+			h.ListRules(w, r)
+		default:
+			httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	})
+
+	mux.HandleFunc("/api/rules/{id}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			h.UpdateRule(w, r)
+		case http.MethodDelete:
+			h.DeleteRule(w, r)
+		default:
+			httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	})
+	mux.HandleFunc("/api/rules/test", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		h.TestRules(w, r)
+	})
+
+	mux.HandleFunc("/api/ledger/trial-balance", func(w http.ResponseWriter, r *http.Request) {
+		h.TrialBalance(w, r)
+	})
+
+	mux.HandleFunc("/api/ledger/statement", func(w http.ResponseWriter, r *http.Request) {
+		h.AccountStatement(w, r)
+	})
+
+	mux.HandleFunc("/api/settings", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			h.GetSettings(w, r)
+		case http.MethodPut:
+			h.UpdateSettings(w, r)
+		default:
+			httpx.WriteError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	})
+
 	mux.HandleFunc("/api/reports/summary", func(w http.ResponseWriter, r *http.Request) {
 		h.ReportSummary(w, r)
 	})
 
+	mux.HandleFunc("/api/reports/tags", func(w http.ResponseWriter, r *http.Request) {
+		h.TagReport(w, r)
+	})
+
+	mux.HandleFunc("/api/reports/budgets", func(w http.ResponseWriter, r *http.Request) {
+		h.BudgetProgress(w, r)
+	})
+
+	mux.HandleFunc("/api/reports/timeseries", func(w http.ResponseWriter, r *http.Request) {
+		h.SpendingTimeSeries(w, r)
+	})
+
+	mux.HandleFunc("/api/reports/compare", func(w http.ResponseWriter, r *http.Request) {
+		h.ComparePeriods(w, r)
+	})
+
+	mux.HandleFunc("/api/reports/anomalies", func(w http.ResponseWriter, r *http.Request) {
+		h.ListAnomalies(w, r)
+	})
+
+	mux.HandleFunc("/api/reports/cashflow", func(w http.ResponseWriter, r *http.Request) {
+		h.CashFlow(w, r)
+	})
+
 	mux.HandleFunc("/api/transactions/bulk", func(w http.ResponseWriter, r *http.Request) {
 		h.BulkImportTransactions(w, r)
 	})
 
 	mux.HandleFunc("POST /auth/register", h.AuthRegister)
 	mux.HandleFunc("POST /auth/login", h.AuthLogin)
+	mux.HandleFunc("POST /auth/refresh", h.AuthRefresh)
+	mux.HandleFunc("POST /auth/logout", h.AuthLogout)
 
-	return mux
+	// Выгрузка стримится дольше обычного запроса, поэтому регистрируется
+	// мимо Timeout; её прерывает отключение клиента.
+	root := http.NewServeMux()
+	root.Handle("/", middleware.Timeout(mux))
+	root.HandleFunc("/api/transactions/export", h.ExportTransactions)
+	return root
 }
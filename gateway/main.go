@@ -7,6 +7,8 @@ import (
 	"os"
 	"time"
 
+	"final/gateway/internal/authclient"
+	"final/gateway/internal/grpcx"
 	"final/gateway/internal/middleware"
 	"final/gateway/internal/server"
 	ledgerv1 "final/gen/ledger/v1"
@@ -18,7 +20,11 @@ import (
 func main() {
 	addr := getenv("LEDGER_ADDR", "localhost:50051")
 
-	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.Dial(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(grpcx.UserIDUnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(grpcx.UserIDStreamClientInterceptor()),
+	)
 	if err != nil {
 		fmt.Println("grpc dial error:", err)
 		os.Exit(1)
@@ -29,7 +35,11 @@ func main() {
 
 	h := server.NewRouter(client)
 
-	handler := middleware.Timeout(h)
+	authAddr := getenv("AUTH_HTTP_ADDR", "http://localhost:8081")
+	jwks := authclient.NewJWKSClient(authAddr, 5*time.Minute)
+	revocation := authclient.NewRevocationClient(authAddr, 30*time.Second)
+
+	handler := middleware.JWT(jwks, revocation)(h)
 	handler = middleware.Logging(handler)
 
 	fmt.Println("Gateway started on :8080, ledger:", addr)
@@ -22,20 +22,125 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type BudgetWarning struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Category  string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Threshold int32                  `protobuf:"varint,2,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Limit     string                 `protobuf:"bytes,8,opt,name=limit,proto3" json:"limit,omitempty"`
+	Spent     string                 `protobuf:"bytes,9,opt,name=spent,proto3" json:"spent,omitempty"`
+	Percent   float64                `protobuf:"fixed64,5,opt,name=percent,proto3" json:"percent,omitempty"`
+	Exceeded  bool                   `protobuf:"varint,6,opt,name=exceeded,proto3" json:"exceeded,omitempty"`
+	// Валюта бюджета: limit и spent указаны в ней.
+	Currency      string `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BudgetWarning) Reset() {
+	*x = BudgetWarning{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BudgetWarning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BudgetWarning) ProtoMessage() {}
+
+func (x *BudgetWarning) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BudgetWarning.ProtoReflect.Descriptor instead.
+func (*BudgetWarning) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BudgetWarning) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *BudgetWarning) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *BudgetWarning) GetLimit() string {
+	if x != nil {
+		return x.Limit
+	}
+	return ""
+}
+
+func (x *BudgetWarning) GetSpent() string {
+	if x != nil {
+		return x.Spent
+	}
+	return ""
+}
+
+func (x *BudgetWarning) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *BudgetWarning) GetExceeded() bool {
+	if x != nil {
+		return x.Exceeded
+	}
+	return false
+}
+
+func (x *BudgetWarning) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
 type Transaction struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
-	Category      string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Date          string                 `protobuf:"bytes,5,opt,name=date,proto3" json:"date,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Amount      string                 `protobuf:"bytes,13,opt,name=amount,proto3" json:"amount,omitempty"`
+	Category    string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Date        string                 `protobuf:"bytes,5,opt,name=date,proto3" json:"date,omitempty"`
+	Warnings    []*BudgetWarning       `protobuf:"bytes,6,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	// Код ISO 4217.
+	Currency string `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	// expense, income, refund или transfer.
+	Kind      string `protobuf:"bytes,8,opt,name=kind,proto3" json:"kind,omitempty"`
+	AccountId int64  `protobuf:"varint,9,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// id второй половины перевода между счетами; 0 — не перевод между счетами.
+	TransferId int64 `protobuf:"varint,10,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	// Метки по алфавиту.
+	Tags []string `protobuf:"bytes,11,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Только в ответе AddTransaction с check_anomaly, если расход необычен.
+	Anomaly       *Anomaly `protobuf:"bytes,12,opt,name=anomaly,proto3" json:"anomaly,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Transaction) Reset() {
 	*x = Transaction{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[0]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -47,7 +152,7 @@ func (x *Transaction) String() string {
 func (*Transaction) ProtoMessage() {}
 
 func (x *Transaction) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[0]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -60,7 +165,7 @@ func (x *Transaction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
 func (*Transaction) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{0}
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *Transaction) GetId() int64 {
@@ -70,11 +175,11 @@ func (x *Transaction) GetId() int64 {
 	return 0
 }
 
-func (x *Transaction) GetAmount() float64 {
+func (x *Transaction) GetAmount() string {
 	if x != nil {
 		return x.Amount
 	}
-	return 0
+	return ""
 }
 
 func (x *Transaction) GetCategory() string {
@@ -98,30 +203,3906 @@ func (x *Transaction) GetDate() string {
 	return ""
 }
 
+func (x *Transaction) GetWarnings() []*BudgetWarning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *Transaction) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Transaction) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Transaction) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *Transaction) GetTransferId() int64 {
+	if x != nil {
+		return x.TransferId
+	}
+	return 0
+}
+
+func (x *Transaction) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Transaction) GetAnomaly() *Anomaly {
+	if x != nil {
+		return x.Anomaly
+	}
+	return nil
+}
+
 type Budget struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
-	Limit         float64                `protobuf:"fixed64,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Period        string                 `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Category       string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Limit          string                 `protobuf:"bytes,9,opt,name=limit,proto3" json:"limit,omitempty"`
+	Period         string                 `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	StartDay       int32                  `protobuf:"varint,4,opt,name=start_day,json=startDay,proto3" json:"start_day,omitempty"`
+	Timezone       string                 `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Enforcement    string                 `protobuf:"bytes,6,opt,name=enforcement,proto3" json:"enforcement,omitempty"`
+	WarnThresholds []int32                `protobuf:"varint,7,rep,packed,name=warn_thresholds,json=warnThresholds,proto3" json:"warn_thresholds,omitempty"`
+	Currency       string                 `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Budget) Reset() {
 	*x = Budget{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[1]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Budget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Budget) ProtoMessage() {}
+
+func (x *Budget) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Budget.ProtoReflect.Descriptor instead.
+func (*Budget) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Budget) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Budget) GetLimit() string {
+	if x != nil {
+		return x.Limit
+	}
+	return ""
+}
+
+func (x *Budget) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *Budget) GetStartDay() int32 {
+	if x != nil {
+		return x.StartDay
+	}
+	return 0
+}
+
+func (x *Budget) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *Budget) GetEnforcement() string {
+	if x != nil {
+		return x.Enforcement
+	}
+	return ""
+}
+
+func (x *Budget) GetWarnThresholds() []int32 {
+	if x != nil {
+		return x.WarnThresholds
+	}
+	return nil
+}
+
+func (x *Budget) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type CreateTransactionRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Amount      string                 `protobuf:"bytes,11,opt,name=amount,proto3" json:"amount,omitempty"`
+	Category    string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Date        string                 `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"`
+	// Пустая — базовая валюта пользователя.
+	Currency string `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Пустой — expense; transfer не принимается, переводы создаёт Transfer.
+	// Сумма положительна.
+	Kind string `protobuf:"bytes,6,opt,name=kind,proto3" json:"kind,omitempty"`
+	// 0 — счёт по умолчанию.
+	AccountId int64 `protobuf:"varint,7,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Категорию назначают правила; category — запасная, если ни одно не подошло.
+	// Правила применяются и при пустой category.
+	AutoCategory bool `protobuf:"varint,8,opt,name=auto_category,json=autoCategory,proto3" json:"auto_category,omitempty"`
+	// К меткам добавляются метки сработавшего правила.
+	Tags []string `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Сравнить расход с историей категории и вернуть Transaction.anomaly.
+	CheckAnomaly  bool `protobuf:"varint,10,opt,name=check_anomaly,json=checkAnomaly,proto3" json:"check_anomaly,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTransactionRequest) Reset() {
+	*x = CreateTransactionRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTransactionRequest) ProtoMessage() {}
+
+func (x *CreateTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTransactionRequest.ProtoReflect.Descriptor instead.
+func (*CreateTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateTransactionRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *CreateTransactionRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CreateTransactionRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateTransactionRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *CreateTransactionRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateTransactionRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *CreateTransactionRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *CreateTransactionRequest) GetAutoCategory() bool {
+	if x != nil {
+		return x.AutoCategory
+	}
+	return false
+}
+
+func (x *CreateTransactionRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateTransactionRequest) GetCheckAnomaly() bool {
+	if x != nil {
+		return x.CheckAnomaly
+	}
+	return false
+}
+
+type UpdateTransactionRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Amount      *string                `protobuf:"bytes,10,opt,name=amount,proto3,oneof" json:"amount,omitempty"`
+	Category    *string                `protobuf:"bytes,3,opt,name=category,proto3,oneof" json:"category,omitempty"`
+	Description *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Date        *string                `protobuf:"bytes,5,opt,name=date,proto3,oneof" json:"date,omitempty"`
+	Currency    *string                `protobuf:"bytes,6,opt,name=currency,proto3,oneof" json:"currency,omitempty"`
+	Kind        *string                `protobuf:"bytes,7,opt,name=kind,proto3,oneof" json:"kind,omitempty"`
+	AccountId   *int64                 `protobuf:"varint,8,opt,name=account_id,json=accountId,proto3,oneof" json:"account_id,omitempty"`
+	// Задан — метки заменяются целиком; пустой values снимает все метки.
+	Tags          *TagList `protobuf:"bytes,9,opt,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTransactionRequest) Reset() {
+	*x = UpdateTransactionRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTransactionRequest) ProtoMessage() {}
+
+func (x *UpdateTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTransactionRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateTransactionRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateTransactionRequest) GetAmount() string {
+	if x != nil && x.Amount != nil {
+		return *x.Amount
+	}
+	return ""
+}
+
+func (x *UpdateTransactionRequest) GetCategory() string {
+	if x != nil && x.Category != nil {
+		return *x.Category
+	}
+	return ""
+}
+
+func (x *UpdateTransactionRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateTransactionRequest) GetDate() string {
+	if x != nil && x.Date != nil {
+		return *x.Date
+	}
+	return ""
+}
+
+func (x *UpdateTransactionRequest) GetCurrency() string {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return ""
+}
+
+func (x *UpdateTransactionRequest) GetKind() string {
+	if x != nil && x.Kind != nil {
+		return *x.Kind
+	}
+	return ""
+}
+
+func (x *UpdateTransactionRequest) GetAccountId() int64 {
+	if x != nil && x.AccountId != nil {
+		return *x.AccountId
+	}
+	return 0
+}
+
+func (x *UpdateTransactionRequest) GetTags() *TagList {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type TagList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagList) Reset() {
+	*x = TagList{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagList) ProtoMessage() {}
+
+func (x *TagList) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagList.ProtoReflect.Descriptor instead.
+func (*TagList) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TagList) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type DeleteTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTransactionRequest) Reset() {
+	*x = DeleteTransactionRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTransactionRequest) ProtoMessage() {}
+
+func (x *DeleteTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTransactionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteTransactionRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CreateBudgetRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Category       string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Limit          string                 `protobuf:"bytes,9,opt,name=limit,proto3" json:"limit,omitempty"`
+	Period         string                 `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	StartDay       int32                  `protobuf:"varint,4,opt,name=start_day,json=startDay,proto3" json:"start_day,omitempty"`
+	Timezone       string                 `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Enforcement    string                 `protobuf:"bytes,6,opt,name=enforcement,proto3" json:"enforcement,omitempty"`
+	WarnThresholds []int32                `protobuf:"varint,7,rep,packed,name=warn_thresholds,json=warnThresholds,proto3" json:"warn_thresholds,omitempty"`
+	// Пустая — базовая валюта пользователя.
+	Currency      string `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBudgetRequest) Reset() {
+	*x = CreateBudgetRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBudgetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBudgetRequest) ProtoMessage() {}
+
+func (x *CreateBudgetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBudgetRequest.ProtoReflect.Descriptor instead.
+func (*CreateBudgetRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateBudgetRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CreateBudgetRequest) GetLimit() string {
+	if x != nil {
+		return x.Limit
+	}
+	return ""
+}
+
+func (x *CreateBudgetRequest) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *CreateBudgetRequest) GetStartDay() int32 {
+	if x != nil {
+		return x.StartDay
+	}
+	return 0
+}
+
+func (x *CreateBudgetRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *CreateBudgetRequest) GetEnforcement() string {
+	if x != nil {
+		return x.Enforcement
+	}
+	return ""
+}
+
+func (x *CreateBudgetRequest) GetWarnThresholds() []int32 {
+	if x != nil {
+		return x.WarnThresholds
+	}
+	return nil
+}
+
+func (x *CreateBudgetRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ListTransactionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Даты в формате YYYY-MM-DD, включительно; пустые — без ограничения.
+	From       string   `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To         string   `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Categories []string `protobuf:"bytes,3,rep,name=categories,proto3" json:"categories,omitempty"`
+	MinAmount  *string  `protobuf:"bytes,13,opt,name=min_amount,json=minAmount,proto3,oneof" json:"min_amount,omitempty"`
+	MaxAmount  *string  `protobuf:"bytes,14,opt,name=max_amount,json=maxAmount,proto3,oneof" json:"max_amount,omitempty"`
+	// Подстрока описания, без учёта регистра.
+	Query string `protobuf:"bytes,6,opt,name=query,proto3" json:"query,omitempty"`
+	// date_desc (по умолчанию), date_asc, amount_desc, amount_asc.
+	Sort string `protobuf:"bytes,7,opt,name=sort,proto3" json:"sort,omitempty"`
+	// Без page_size и page_token — все транзакции одним ответом.
+	PageSize  int32  `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,9,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Пустой — транзакции всех видов.
+	Kinds      []string `protobuf:"bytes,10,rep,name=kinds,proto3" json:"kinds,omitempty"`
+	AccountIds []int64  `protobuf:"varint,11,rep,packed,name=account_ids,json=accountIds,proto3" json:"account_ids,omitempty"`
+	// Транзакции хотя бы с одной из меток.
+	Tags          []string `protobuf:"bytes,12,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsRequest) Reset() {
+	*x = ListTransactionsRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsRequest) ProtoMessage() {}
+
+func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListTransactionsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *ListTransactionsRequest) GetMinAmount() string {
+	if x != nil && x.MinAmount != nil {
+		return *x.MinAmount
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetMaxAmount() string {
+	if x != nil && x.MaxAmount != nil {
+		return *x.MaxAmount
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetKinds() []string {
+	if x != nil {
+		return x.Kinds
+	}
+	return nil
+}
+
+func (x *ListTransactionsRequest) GetAccountIds() []int64 {
+	if x != nil {
+		return x.AccountIds
+	}
+	return nil
+}
+
+func (x *ListTransactionsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type ListTransactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Transaction         `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsResponse) Reset() {
+	*x = ListTransactionsResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsResponse) ProtoMessage() {}
+
+func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListTransactionsResponse) GetItems() []*Transaction {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListTransactionsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ListBudgetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Budget              `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBudgetsResponse) Reset() {
+	*x = ListBudgetsResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBudgetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBudgetsResponse) ProtoMessage() {}
+
+func (x *ListBudgetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBudgetsResponse.ProtoReflect.Descriptor instead.
+func (*ListBudgetsResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListBudgetsResponse) GetItems() []*Budget {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ReportSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportSummaryRequest) Reset() {
+	*x = ReportSummaryRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportSummaryRequest) ProtoMessage() {}
+
+func (x *ReportSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportSummaryRequest.ProtoReflect.Descriptor instead.
+func (*ReportSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReportSummaryRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ReportSummaryRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type ReportSummaryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Суммы в базовой валюте пользователя по курсу на дату каждой траты.
+	Totals        map[string]string `protobuf:"bytes,3,rep,name=totals,proto3" json:"totals,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Currency      string            `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportSummaryResponse) Reset() {
+	*x = ReportSummaryResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportSummaryResponse) ProtoMessage() {}
+
+func (x *ReportSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportSummaryResponse.ProtoReflect.Descriptor instead.
+func (*ReportSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ReportSummaryResponse) GetTotals() map[string]string {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+func (x *ReportSummaryResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+// Исполнение бюджета за диапазон; суммы в валюте бюджета.
+type BudgetProgress struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Category string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Period   string                 `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`
+	Currency string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Часть лимита, приходящаяся на диапазон.
+	Limit string `protobuf:"bytes,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Spent string `protobuf:"bytes,5,opt,name=spent,proto3" json:"spent,omitempty"`
+	// Отрицательный, если лимит превышен.
+	Remaining string  `protobuf:"bytes,6,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	Percent   float64 `protobuf:"fixed64,7,opt,name=percent,proto3" json:"percent,omitempty"`
+	// Ожидаемые траты к концу диапазона при нынешнем темпе.
+	Projected     string `protobuf:"bytes,8,opt,name=projected,proto3" json:"projected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BudgetProgress) Reset() {
+	*x = BudgetProgress{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BudgetProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BudgetProgress) ProtoMessage() {}
+
+func (x *BudgetProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BudgetProgress.ProtoReflect.Descriptor instead.
+func (*BudgetProgress) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BudgetProgress) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *BudgetProgress) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *BudgetProgress) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *BudgetProgress) GetLimit() string {
+	if x != nil {
+		return x.Limit
+	}
+	return ""
+}
+
+func (x *BudgetProgress) GetSpent() string {
+	if x != nil {
+		return x.Spent
+	}
+	return ""
+}
+
+func (x *BudgetProgress) GetRemaining() string {
+	if x != nil {
+		return x.Remaining
+	}
+	return ""
+}
+
+func (x *BudgetProgress) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *BudgetProgress) GetProjected() string {
+	if x != nil {
+		return x.Projected
+	}
+	return ""
+}
+
+type BudgetProgressResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*BudgetProgress      `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BudgetProgressResponse) Reset() {
+	*x = BudgetProgressResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BudgetProgressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BudgetProgressResponse) ProtoMessage() {}
+
+func (x *BudgetProgressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BudgetProgressResponse.ProtoReflect.Descriptor instead.
+func (*BudgetProgressResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BudgetProgressResponse) GetItems() []*BudgetProgress {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type SpendingTimeSeriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// YYYY-MM-DD или RFC3339; момент переводится в дату часового пояса timezone.
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	// Пустой — сегодня в часовом поясе timezone.
+	To string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// day, week (ISO, с понедельника), month (по умолчанию) или year.
+	Bucket string `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// IANA, например Europe/Moscow; пустой — UTC.
+	Timezone      string `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	ByCategory    bool   `protobuf:"varint,5,opt,name=by_category,json=byCategory,proto3" json:"by_category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpendingTimeSeriesRequest) Reset() {
+	*x = SpendingTimeSeriesRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpendingTimeSeriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpendingTimeSeriesRequest) ProtoMessage() {}
+
+func (x *SpendingTimeSeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpendingTimeSeriesRequest.ProtoReflect.Descriptor instead.
+func (*SpendingTimeSeriesRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SpendingTimeSeriesRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *SpendingTimeSeriesRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *SpendingTimeSeriesRequest) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *SpendingTimeSeriesRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *SpendingTimeSeriesRequest) GetByCategory() bool {
+	if x != nil {
+		return x.ByCategory
+	}
+	return false
+}
+
+type SeriesPoint struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Границы корзины, обрезанные по диапазону ряда.
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// 2025-12-01, 2025-W49, 2025-12 или 2025.
+	Label string `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`
+	Total string `protobuf:"bytes,4,opt,name=total,proto3" json:"total,omitempty"`
+	// Только при by_category; есть все категории ряда, пропуски — "0".
+	ByCategory    map[string]string `protobuf:"bytes,5,rep,name=by_category,json=byCategory,proto3" json:"by_category,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeriesPoint) Reset() {
+	*x = SeriesPoint{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeriesPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeriesPoint) ProtoMessage() {}
+
+func (x *SeriesPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeriesPoint.ProtoReflect.Descriptor instead.
+func (*SeriesPoint) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SeriesPoint) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *SeriesPoint) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *SeriesPoint) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *SeriesPoint) GetTotal() string {
+	if x != nil {
+		return x.Total
+	}
+	return ""
+}
+
+func (x *SeriesPoint) GetByCategory() map[string]string {
+	if x != nil {
+		return x.ByCategory
+	}
+	return nil
+}
+
+type SpendingTimeSeriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bucket        string                 `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Categories    []string               `protobuf:"bytes,3,rep,name=categories,proto3" json:"categories,omitempty"`
+	Points        []*SeriesPoint         `protobuf:"bytes,4,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpendingTimeSeriesResponse) Reset() {
+	*x = SpendingTimeSeriesResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpendingTimeSeriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpendingTimeSeriesResponse) ProtoMessage() {}
+
+func (x *SpendingTimeSeriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpendingTimeSeriesResponse.ProtoReflect.Descriptor instead.
+func (*SpendingTimeSeriesResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SpendingTimeSeriesResponse) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *SpendingTimeSeriesResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *SpendingTimeSeriesResponse) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *SpendingTimeSeriesResponse) GetPoints() []*SeriesPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+type ComparePeriodsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	From  string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To    string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// Пустые — предыдущий период той же длины; для целых календарных
+	// месяцев — столько же предыдущих месяцев.
+	CompareFrom   string `protobuf:"bytes,3,opt,name=compare_from,json=compareFrom,proto3" json:"compare_from,omitempty"`
+	CompareTo     string `protobuf:"bytes,4,opt,name=compare_to,json=compareTo,proto3" json:"compare_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComparePeriodsRequest) Reset() {
+	*x = ComparePeriodsRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComparePeriodsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComparePeriodsRequest) ProtoMessage() {}
+
+func (x *ComparePeriodsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComparePeriodsRequest.ProtoReflect.Descriptor instead.
+func (*ComparePeriodsRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ComparePeriodsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ComparePeriodsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ComparePeriodsRequest) GetCompareFrom() string {
+	if x != nil {
+		return x.CompareFrom
+	}
+	return ""
+}
+
+func (x *ComparePeriodsRequest) GetCompareTo() string {
+	if x != nil {
+		return x.CompareTo
+	}
+	return ""
+}
+
+type CategoryDelta struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Category string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Current  string                 `protobuf:"bytes,2,opt,name=current,proto3" json:"current,omitempty"`
+	Previous string                 `protobuf:"bytes,3,opt,name=previous,proto3" json:"previous,omitempty"`
+	Delta    string                 `protobuf:"bytes,4,opt,name=delta,proto3" json:"delta,omitempty"`
+	// Не задан, если в предыдущем периоде трат не было.
+	DeltaPercent *float64 `protobuf:"fixed64,5,opt,name=delta_percent,json=deltaPercent,proto3,oneof" json:"delta_percent,omitempty"`
+	// new, disappeared или пустой.
+	Status        string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryDelta) Reset() {
+	*x = CategoryDelta{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryDelta) ProtoMessage() {}
+
+func (x *CategoryDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryDelta.ProtoReflect.Descriptor instead.
+func (*CategoryDelta) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CategoryDelta) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CategoryDelta) GetCurrent() string {
+	if x != nil {
+		return x.Current
+	}
+	return ""
+}
+
+func (x *CategoryDelta) GetPrevious() string {
+	if x != nil {
+		return x.Previous
+	}
+	return ""
+}
+
+func (x *CategoryDelta) GetDelta() string {
+	if x != nil {
+		return x.Delta
+	}
+	return ""
+}
+
+func (x *CategoryDelta) GetDeltaPercent() float64 {
+	if x != nil && x.DeltaPercent != nil {
+		return *x.DeltaPercent
+	}
+	return 0
+}
+
+func (x *CategoryDelta) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ComparePeriodsResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	From        string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To          string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	CompareFrom string                 `protobuf:"bytes,3,opt,name=compare_from,json=compareFrom,proto3" json:"compare_from,omitempty"`
+	CompareTo   string                 `protobuf:"bytes,4,opt,name=compare_to,json=compareTo,proto3" json:"compare_to,omitempty"`
+	Currency    string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	// По убыванию модуля изменения.
+	Items                 []*CategoryDelta `protobuf:"bytes,6,rep,name=items,proto3" json:"items,omitempty"`
+	Total                 *CategoryDelta   `protobuf:"bytes,7,opt,name=total,proto3" json:"total,omitempty"`
+	NewCategories         []string         `protobuf:"bytes,8,rep,name=new_categories,json=newCategories,proto3" json:"new_categories,omitempty"`
+	DisappearedCategories []string         `protobuf:"bytes,9,rep,name=disappeared_categories,json=disappearedCategories,proto3" json:"disappeared_categories,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ComparePeriodsResponse) Reset() {
+	*x = ComparePeriodsResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComparePeriodsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComparePeriodsResponse) ProtoMessage() {}
+
+func (x *ComparePeriodsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComparePeriodsResponse.ProtoReflect.Descriptor instead.
+func (*ComparePeriodsResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ComparePeriodsResponse) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ComparePeriodsResponse) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ComparePeriodsResponse) GetCompareFrom() string {
+	if x != nil {
+		return x.CompareFrom
+	}
+	return ""
+}
+
+func (x *ComparePeriodsResponse) GetCompareTo() string {
+	if x != nil {
+		return x.CompareTo
+	}
+	return ""
+}
+
+func (x *ComparePeriodsResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ComparePeriodsResponse) GetItems() []*CategoryDelta {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ComparePeriodsResponse) GetTotal() *CategoryDelta {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *ComparePeriodsResponse) GetNewCategories() []string {
+	if x != nil {
+		return x.NewCategories
+	}
+	return nil
+}
+
+func (x *ComparePeriodsResponse) GetDisappearedCategories() []string {
+	if x != nil {
+		return x.DisappearedCategories
+	}
+	return nil
+}
+
+// Необычная трата по медиане и MAD истории категории.
+type Anomaly struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// transaction — расход намного больше обычного для категории;
+	// weekly — траты категории за неделю сильно отличаются от предыдущих недель.
+	Kind     string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Category string `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	// 0 для weekly.
+	TransactionId int64 `protobuf:"varint,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// Дата транзакции или понедельник и воскресенье недели.
+	From   string `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To     string `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	Amount string `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Медиана истории.
+	Typical string `protobuf:"bytes,7,opt,name=typical,proto3" json:"typical,omitempty"`
+	// Модифицированная z-оценка: больше нуля — потрачено больше обычного.
+	Score         float64 `protobuf:"fixed64,8,opt,name=score,proto3" json:"score,omitempty"`
+	Currency      string  `protobuf:"bytes,9,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Anomaly) Reset() {
+	*x = Anomaly{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Anomaly) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Anomaly) ProtoMessage() {}
+
+func (x *Anomaly) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Anomaly.ProtoReflect.Descriptor instead.
+func (*Anomaly) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Anomaly) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Anomaly) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Anomaly) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+func (x *Anomaly) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Anomaly) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *Anomaly) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Anomaly) GetTypical() string {
+	if x != nil {
+		return x.Typical
+	}
+	return ""
+}
+
+func (x *Anomaly) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Anomaly) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ListAnomaliesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// По убыванию даты.
+	Items         []*Anomaly `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAnomaliesResponse) Reset() {
+	*x = ListAnomaliesResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAnomaliesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAnomaliesResponse) ProtoMessage() {}
+
+func (x *ListAnomaliesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAnomaliesResponse.ProtoReflect.Descriptor instead.
+func (*ListAnomaliesResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListAnomaliesResponse) GetItems() []*Anomaly {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type CashFlowRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	From  string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To    string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// weekly, monthly (по умолчанию), quarterly, yearly или fixed — весь диапазон.
+	Granularity   string `protobuf:"bytes,3,opt,name=granularity,proto3" json:"granularity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CashFlowRequest) Reset() {
+	*x = CashFlowRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CashFlowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CashFlowRequest) ProtoMessage() {}
+
+func (x *CashFlowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CashFlowRequest.ProtoReflect.Descriptor instead.
+func (*CashFlowRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CashFlowRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *CashFlowRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *CashFlowRequest) GetGranularity() string {
+	if x != nil {
+		return x.Granularity
+	}
+	return ""
+}
+
+type CashFlowPeriod struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	From   string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To     string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Income string                 `protobuf:"bytes,3,opt,name=income,proto3" json:"income,omitempty"`
+	// Расходы за вычетом возвратов.
+	Expense string `protobuf:"bytes,4,opt,name=expense,proto3" json:"expense,omitempty"`
+	Net     string `protobuf:"bytes,5,opt,name=net,proto3" json:"net,omitempty"`
+	// net / income в процентах; 0, если дохода не было.
+	SavingsRate   float64 `protobuf:"fixed64,6,opt,name=savings_rate,json=savingsRate,proto3" json:"savings_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CashFlowPeriod) Reset() {
+	*x = CashFlowPeriod{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CashFlowPeriod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CashFlowPeriod) ProtoMessage() {}
+
+func (x *CashFlowPeriod) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CashFlowPeriod.ProtoReflect.Descriptor instead.
+func (*CashFlowPeriod) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CashFlowPeriod) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *CashFlowPeriod) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *CashFlowPeriod) GetIncome() string {
+	if x != nil {
+		return x.Income
+	}
+	return ""
+}
+
+func (x *CashFlowPeriod) GetExpense() string {
+	if x != nil {
+		return x.Expense
+	}
+	return ""
+}
+
+func (x *CashFlowPeriod) GetNet() string {
+	if x != nil {
+		return x.Net
+	}
+	return ""
+}
+
+func (x *CashFlowPeriod) GetSavingsRate() float64 {
+	if x != nil {
+		return x.SavingsRate
+	}
+	return 0
+}
+
+type CashFlowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Periods       []*CashFlowPeriod      `protobuf:"bytes,1,rep,name=periods,proto3" json:"periods,omitempty"`
+	Total         *CashFlowPeriod        `protobuf:"bytes,2,opt,name=total,proto3" json:"total,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CashFlowResponse) Reset() {
+	*x = CashFlowResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CashFlowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CashFlowResponse) ProtoMessage() {}
+
+func (x *CashFlowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CashFlowResponse.ProtoReflect.Descriptor instead.
+func (*CashFlowResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CashFlowResponse) GetPeriods() []*CashFlowPeriod {
+	if x != nil {
+		return x.Periods
+	}
+	return nil
+}
+
+func (x *CashFlowResponse) GetTotal() *CashFlowPeriod {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *CashFlowResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type Account struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Currency       string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	OpeningBalance string                 `protobuf:"bytes,4,opt,name=opening_balance,json=openingBalance,proto3" json:"opening_balance,omitempty"`
+	IsDefault      bool                   `protobuf:"varint,5,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Account) Reset() {
+	*x = Account{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Account) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Account) ProtoMessage() {}
+
+func (x *Account) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Account.ProtoReflect.Descriptor instead.
+func (*Account) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Account) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Account) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Account) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Account) GetOpeningBalance() string {
+	if x != nil {
+		return x.OpeningBalance
+	}
+	return ""
+}
+
+func (x *Account) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+type CreateAccountRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Пустая — базовая валюта пользователя.
+	Currency       string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	OpeningBalance string `protobuf:"bytes,3,opt,name=opening_balance,json=openingBalance,proto3" json:"opening_balance,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateAccountRequest) Reset() {
+	*x = CreateAccountRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccountRequest) ProtoMessage() {}
+
+func (x *CreateAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccountRequest.ProtoReflect.Descriptor instead.
+func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreateAccountRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAccountRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateAccountRequest) GetOpeningBalance() string {
+	if x != nil {
+		return x.OpeningBalance
+	}
+	return ""
+}
+
+type ListAccountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Account             `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountsResponse) Reset() {
+	*x = ListAccountsResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountsResponse) ProtoMessage() {}
+
+func (x *ListAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountsResponse.ProtoReflect.Descriptor instead.
+func (*ListAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListAccountsResponse) GetItems() []*Account {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type TransferRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 0 — счёт по умолчанию.
+	FromAccountId int64 `protobuf:"varint,1,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
+	ToAccountId   int64 `protobuf:"varint,2,opt,name=to_account_id,json=toAccountId,proto3" json:"to_account_id,omitempty"`
+	// Списывается в валюте from_account_id.
+	Amount string `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Зачисляется в валюте to_account_id; если не задано — по курсу на дату.
+	ToAmount      *string `protobuf:"bytes,4,opt,name=to_amount,json=toAmount,proto3,oneof" json:"to_amount,omitempty"`
+	Date          string  `protobuf:"bytes,5,opt,name=date,proto3" json:"date,omitempty"`
+	Description   string  `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferRequest) Reset() {
+	*x = TransferRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferRequest) ProtoMessage() {}
+
+func (x *TransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferRequest.ProtoReflect.Descriptor instead.
+func (*TransferRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *TransferRequest) GetFromAccountId() int64 {
+	if x != nil {
+		return x.FromAccountId
+	}
+	return 0
+}
+
+func (x *TransferRequest) GetToAccountId() int64 {
+	if x != nil {
+		return x.ToAccountId
+	}
+	return 0
+}
+
+func (x *TransferRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *TransferRequest) GetToAmount() string {
+	if x != nil && x.ToAmount != nil {
+		return *x.ToAmount
+	}
+	return ""
+}
+
+func (x *TransferRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *TransferRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type TransferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Withdrawal    *Transaction           `protobuf:"bytes,1,opt,name=withdrawal,proto3" json:"withdrawal,omitempty"`
+	Deposit       *Transaction           `protobuf:"bytes,2,opt,name=deposit,proto3" json:"deposit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferResponse) Reset() {
+	*x = TransferResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferResponse) ProtoMessage() {}
+
+func (x *TransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferResponse.ProtoReflect.Descriptor instead.
+func (*TransferResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TransferResponse) GetWithdrawal() *Transaction {
+	if x != nil {
+		return x.Withdrawal
+	}
+	return nil
+}
+
+func (x *TransferResponse) GetDeposit() *Transaction {
+	if x != nil {
+		return x.Deposit
+	}
+	return nil
+}
+
+type GetBalancesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// YYYY-MM-DD, включительно; пустая — сегодня.
+	On            string `protobuf:"bytes,1,opt,name=on,proto3" json:"on,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalancesRequest) Reset() {
+	*x = GetBalancesRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalancesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalancesRequest) ProtoMessage() {}
+
+func (x *GetBalancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalancesRequest.ProtoReflect.Descriptor instead.
+func (*GetBalancesRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetBalancesRequest) GetOn() string {
+	if x != nil {
+		return x.On
+	}
+	return ""
+}
+
+type AccountBalance struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Account *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// В валюте счёта.
+	Balance       string `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountBalance) Reset() {
+	*x = AccountBalance{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountBalance) ProtoMessage() {}
+
+func (x *AccountBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountBalance.ProtoReflect.Descriptor instead.
+func (*AccountBalance) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *AccountBalance) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *AccountBalance) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+type GetBalancesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Items []*AccountBalance      `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// Сумма балансов в базовой валюте.
+	Total         string `protobuf:"bytes,2,opt,name=total,proto3" json:"total,omitempty"`
+	Currency      string `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalancesResponse) Reset() {
+	*x = GetBalancesResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalancesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalancesResponse) ProtoMessage() {}
+
+func (x *GetBalancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalancesResponse.ProtoReflect.Descriptor instead.
+func (*GetBalancesResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetBalancesResponse) GetItems() []*AccountBalance {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *GetBalancesResponse) GetTotal() string {
+	if x != nil {
+		return x.Total
+	}
+	return ""
+}
+
+func (x *GetBalancesResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type TrialBalanceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// YYYY-MM-DD, включительно; пустая — сегодня.
+	On            string `protobuf:"bytes,1,opt,name=on,proto3" json:"on,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrialBalanceRequest) Reset() {
+	*x = TrialBalanceRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrialBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrialBalanceRequest) ProtoMessage() {}
+
+func (x *TrialBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrialBalanceRequest.ProtoReflect.Descriptor instead.
+func (*TrialBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *TrialBalanceRequest) GetOn() string {
+	if x != nil {
+		return x.On
+	}
+	return ""
+}
+
+type TrialBalanceLine struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Счёт главной книги: assets:<id>, expenses:<категория>, income:<категория>, equity:*.
+	Account  string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Currency string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Debit    string `protobuf:"bytes,3,opt,name=debit,proto3" json:"debit,omitempty"`
+	Credit   string `protobuf:"bytes,4,opt,name=credit,proto3" json:"credit,omitempty"`
+	// debit - credit: положительное — дебетовое сальдо.
+	Balance       string `protobuf:"bytes,5,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrialBalanceLine) Reset() {
+	*x = TrialBalanceLine{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrialBalanceLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrialBalanceLine) ProtoMessage() {}
+
+func (x *TrialBalanceLine) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrialBalanceLine.ProtoReflect.Descriptor instead.
+func (*TrialBalanceLine) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TrialBalanceLine) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *TrialBalanceLine) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *TrialBalanceLine) GetDebit() string {
+	if x != nil {
+		return x.Debit
+	}
+	return ""
+}
+
+func (x *TrialBalanceLine) GetCredit() string {
+	if x != nil {
+		return x.Credit
+	}
+	return ""
+}
+
+func (x *TrialBalanceLine) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+type TrialBalanceResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	On    string                 `protobuf:"bytes,1,opt,name=on,proto3" json:"on,omitempty"`
+	Lines []*TrialBalanceLine    `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+	// Дебет равен кредиту в каждой валюте.
+	Balanced      bool `protobuf:"varint,3,opt,name=balanced,proto3" json:"balanced,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrialBalanceResponse) Reset() {
+	*x = TrialBalanceResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrialBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrialBalanceResponse) ProtoMessage() {}
+
+func (x *TrialBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrialBalanceResponse.ProtoReflect.Descriptor instead.
+func (*TrialBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *TrialBalanceResponse) GetOn() string {
+	if x != nil {
+		return x.On
+	}
+	return ""
+}
+
+func (x *TrialBalanceResponse) GetLines() []*TrialBalanceLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *TrialBalanceResponse) GetBalanced() bool {
+	if x != nil {
+		return x.Balanced
+	}
+	return false
+}
+
+type AccountStatementRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Account string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// Пустая — валюта счёта для assets:<id>, иначе базовая валюта.
+	Currency      string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	From          string `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            string `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountStatementRequest) Reset() {
+	*x = AccountStatementRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountStatementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountStatementRequest) ProtoMessage() {}
+
+func (x *AccountStatementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountStatementRequest.ProtoReflect.Descriptor instead.
+func (*AccountStatementRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *AccountStatementRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *AccountStatementRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *AccountStatementRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *AccountStatementRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type StatementLine struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	EntryId int64                  `protobuf:"varint,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	// 0 — остаток на открытии счёта.
+	TransactionId int64  `protobuf:"varint,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Date          string `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	Description   string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Amount        string `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Balance       string `protobuf:"bytes,6,opt,name=balance,proto3" json:"balance,omitempty"`
+	// RFC 3339, момент записи в журнал.
+	RecordedAt    string `protobuf:"bytes,7,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatementLine) Reset() {
+	*x = StatementLine{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatementLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatementLine) ProtoMessage() {}
+
+func (x *StatementLine) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatementLine.ProtoReflect.Descriptor instead.
+func (*StatementLine) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *StatementLine) GetEntryId() int64 {
+	if x != nil {
+		return x.EntryId
+	}
+	return 0
+}
+
+func (x *StatementLine) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+func (x *StatementLine) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *StatementLine) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *StatementLine) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *StatementLine) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *StatementLine) GetRecordedAt() string {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return ""
+}
+
+type AccountStatementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Opening       string                 `protobuf:"bytes,3,opt,name=opening,proto3" json:"opening,omitempty"`
+	Closing       string                 `protobuf:"bytes,4,opt,name=closing,proto3" json:"closing,omitempty"`
+	Lines         []*StatementLine       `protobuf:"bytes,5,rep,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountStatementResponse) Reset() {
+	*x = AccountStatementResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountStatementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountStatementResponse) ProtoMessage() {}
+
+func (x *AccountStatementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountStatementResponse.ProtoReflect.Descriptor instead.
+func (*AccountStatementResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *AccountStatementResponse) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *AccountStatementResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *AccountStatementResponse) GetOpening() string {
+	if x != nil {
+		return x.Opening
+	}
+	return ""
+}
+
+func (x *AccountStatementResponse) GetClosing() string {
+	if x != nil {
+		return x.Closing
+	}
+	return ""
+}
+
+func (x *AccountStatementResponse) GetLines() []*StatementLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+type RecurringTransaction struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId   int64                  `protobuf:"varint,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Kind        string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Amount      string                 `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency    string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Category    string                 `protobuf:"bytes,6,opt,name=category,proto3" json:"category,omitempty"`
+	Description string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	// weekly, monthly, yearly — как FREQ в RRULE.
+	Frequency string `protobuf:"bytes,8,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	// Каждые сколько недель, месяцев или лет, как INTERVAL в RRULE.
+	Interval int32 `protobuf:"varint,9,opt,name=interval,proto3" json:"interval,omitempty"`
+	// YYYY-MM-DD; от неё считаются день недели, число месяца и дата года.
+	StartDate string `protobuf:"bytes,10,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	// Пустая — бессрочно.
+	EndDate string `protobuf:"bytes,11,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	// Ближайшее несозданное повторение; пустая — повторения закончились.
+	NextDate      string `protobuf:"bytes,12,opt,name=next_date,json=nextDate,proto3" json:"next_date,omitempty"`
+	Paused        bool   `protobuf:"varint,13,opt,name=paused,proto3" json:"paused,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecurringTransaction) Reset() {
+	*x = RecurringTransaction{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecurringTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecurringTransaction) ProtoMessage() {}
+
+func (x *RecurringTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecurringTransaction.ProtoReflect.Descriptor instead.
+func (*RecurringTransaction) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RecurringTransaction) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RecurringTransaction) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *RecurringTransaction) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *RecurringTransaction) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *RecurringTransaction) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *RecurringTransaction) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *RecurringTransaction) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *RecurringTransaction) GetFrequency() string {
+	if x != nil {
+		return x.Frequency
+	}
+	return ""
+}
+
+func (x *RecurringTransaction) GetInterval() int32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *RecurringTransaction) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *RecurringTransaction) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *RecurringTransaction) GetNextDate() string {
+	if x != nil {
+		return x.NextDate
+	}
+	return ""
+}
+
+func (x *RecurringTransaction) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+type CreateRecurringRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 0 — счёт по умолчанию.
+	AccountId int64  `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Kind      string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Amount    string `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Пустая — валюта счёта.
+	Currency    string `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Category    string `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	Description string `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Frequency   string `protobuf:"bytes,7,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	// 0 — 1.
+	Interval      int32  `protobuf:"varint,8,opt,name=interval,proto3" json:"interval,omitempty"`
+	StartDate     string `protobuf:"bytes,9,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       string `protobuf:"bytes,10,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRecurringRequest) Reset() {
+	*x = CreateRecurringRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRecurringRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRecurringRequest) ProtoMessage() {}
+
+func (x *CreateRecurringRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRecurringRequest.ProtoReflect.Descriptor instead.
+func (*CreateRecurringRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CreateRecurringRequest) GetAccountId() int64 {
+	if x != nil {
+		return x.AccountId
+	}
+	return 0
+}
+
+func (x *CreateRecurringRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *CreateRecurringRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *CreateRecurringRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateRecurringRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CreateRecurringRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateRecurringRequest) GetFrequency() string {
+	if x != nil {
+		return x.Frequency
+	}
+	return ""
+}
+
+func (x *CreateRecurringRequest) GetInterval() int32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *CreateRecurringRequest) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *CreateRecurringRequest) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+type ListRecurringResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Items         []*RecurringTransaction `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecurringResponse) Reset() {
+	*x = ListRecurringResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecurringResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecurringResponse) ProtoMessage() {}
+
+func (x *ListRecurringResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecurringResponse.ProtoReflect.Descriptor instead.
+func (*ListRecurringResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListRecurringResponse) GetItems() []*RecurringTransaction {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type SetRecurringPausedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Paused        bool                   `protobuf:"varint,2,opt,name=paused,proto3" json:"paused,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRecurringPausedRequest) Reset() {
+	*x = SetRecurringPausedRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRecurringPausedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRecurringPausedRequest) ProtoMessage() {}
+
+func (x *SetRecurringPausedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRecurringPausedRequest.ProtoReflect.Descriptor instead.
+func (*SetRecurringPausedRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SetRecurringPausedRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SetRecurringPausedRequest) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+type PreviewRecurringRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// 0 — 5.
+	Count         int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewRecurringRequest) Reset() {
+	*x = PreviewRecurringRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewRecurringRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewRecurringRequest) ProtoMessage() {}
+
+func (x *PreviewRecurringRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewRecurringRequest.ProtoReflect.Descriptor instead.
+func (*PreviewRecurringRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *PreviewRecurringRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PreviewRecurringRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type PreviewRecurringResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// YYYY-MM-DD.
+	Dates         []string `protobuf:"bytes,1,rep,name=dates,proto3" json:"dates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewRecurringResponse) Reset() {
+	*x = PreviewRecurringResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewRecurringResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewRecurringResponse) ProtoMessage() {}
+
+func (x *PreviewRecurringResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewRecurringResponse.ProtoReflect.Descriptor instead.
+func (*PreviewRecurringResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *PreviewRecurringResponse) GetDates() []string {
+	if x != nil {
+		return x.Dates
+	}
+	return nil
+}
+
+type Category struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// 0 — корневая категория.
+	ParentId      int64  `protobuf:"varint,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Icon          string `protobuf:"bytes,4,opt,name=icon,proto3" json:"icon,omitempty"`
+	Archived      bool   `protobuf:"varint,5,opt,name=archived,proto3" json:"archived,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *Category) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *Category) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *Category) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+type CreateCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId      int64                  `protobuf:"varint,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Icon          string                 `protobuf:"bytes,3,opt,name=icon,proto3" json:"icon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCategoryRequest) Reset() {
+	*x = CreateCategoryRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCategoryRequest) ProtoMessage() {}
+
+func (x *CreateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*CreateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CreateCategoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCategoryRequest) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *CreateCategoryRequest) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+type ListCategoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Category            `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesResponse) Reset() {
+	*x = ListCategoriesResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesResponse) ProtoMessage() {}
+
+func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ListCategoriesResponse) GetItems() []*Category {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type UpdateCategoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// 0 — сделать корневой.
+	ParentId      *int64  `protobuf:"varint,3,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	Icon          *string `protobuf:"bytes,4,opt,name=icon,proto3,oneof" json:"icon,omitempty"`
+	Archived      *bool   `protobuf:"varint,5,opt,name=archived,proto3,oneof" json:"archived,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCategoryRequest) Reset() {
+	*x = UpdateCategoryRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCategoryRequest) ProtoMessage() {}
+
+func (x *UpdateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *UpdateCategoryRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateCategoryRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateCategoryRequest) GetParentId() int64 {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return 0
+}
+
+func (x *UpdateCategoryRequest) GetIcon() string {
+	if x != nil && x.Icon != nil {
+		return *x.Icon
+	}
+	return ""
+}
+
+func (x *UpdateCategoryRequest) GetArchived() bool {
+	if x != nil && x.Archived != nil {
+		return *x.Archived
+	}
+	return false
+}
+
+type MergeCategoriesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	FromId int64                  `protobuf:"varint,1,opt,name=from_id,json=fromId,proto3" json:"from_id,omitempty"`
+	IntoId int64                  `protobuf:"varint,2,opt,name=into_id,json=intoId,proto3" json:"into_id,omitempty"`
+	// Слияние по имени, если from_id = 0: категории могут отсутствовать в дереве.
+	FromName      string `protobuf:"bytes,3,opt,name=from_name,json=fromName,proto3" json:"from_name,omitempty"`
+	IntoName      string `protobuf:"bytes,4,opt,name=into_name,json=intoName,proto3" json:"into_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeCategoriesRequest) Reset() {
+	*x = MergeCategoriesRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeCategoriesRequest) ProtoMessage() {}
+
+func (x *MergeCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*MergeCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *MergeCategoriesRequest) GetFromId() int64 {
+	if x != nil {
+		return x.FromId
+	}
+	return 0
+}
+
+func (x *MergeCategoriesRequest) GetIntoId() int64 {
+	if x != nil {
+		return x.IntoId
+	}
+	return 0
+}
+
+func (x *MergeCategoriesRequest) GetFromName() string {
+	if x != nil {
+		return x.FromName
+	}
+	return ""
+}
+
+func (x *MergeCategoriesRequest) GetIntoName() string {
+	if x != nil {
+		return x.IntoName
+	}
+	return ""
+}
+
+type CategoryAlias struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alias         string                 `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryAlias) Reset() {
+	*x = CategoryAlias{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryAlias) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryAlias) ProtoMessage() {}
+
+func (x *CategoryAlias) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryAlias.ProtoReflect.Descriptor instead.
+func (*CategoryAlias) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *CategoryAlias) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *CategoryAlias) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type ListCategoryAliasesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*CategoryAlias       `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoryAliasesResponse) Reset() {
+	*x = ListCategoryAliasesResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoryAliasesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoryAliasesResponse) ProtoMessage() {}
+
+func (x *ListCategoryAliasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoryAliasesResponse.ProtoReflect.Descriptor instead.
+func (*ListCategoryAliasesResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListCategoryAliasesResponse) GetItems() []*CategoryAlias {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type DeleteCategoryAliasRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alias         string                 `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCategoryAliasRequest) Reset() {
+	*x = DeleteCategoryAliasRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCategoryAliasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCategoryAliasRequest) ProtoMessage() {}
+
+func (x *DeleteCategoryAliasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCategoryAliasRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCategoryAliasRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *DeleteCategoryAliasRequest) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+type CategoryMergeSuggestion struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	From  string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	Into  string                 `protobuf:"bytes,2,opt,name=into,proto3" json:"into,omitempty"`
+	// normalized, alias или similar.
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	FromCount     int64  `protobuf:"varint,4,opt,name=from_count,json=fromCount,proto3" json:"from_count,omitempty"`
+	IntoCount     int64  `protobuf:"varint,5,opt,name=into_count,json=intoCount,proto3" json:"into_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryMergeSuggestion) Reset() {
+	*x = CategoryMergeSuggestion{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryMergeSuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryMergeSuggestion) ProtoMessage() {}
+
+func (x *CategoryMergeSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryMergeSuggestion.ProtoReflect.Descriptor instead.
+func (*CategoryMergeSuggestion) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *CategoryMergeSuggestion) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *CategoryMergeSuggestion) GetInto() string {
+	if x != nil {
+		return x.Into
+	}
+	return ""
+}
+
+func (x *CategoryMergeSuggestion) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CategoryMergeSuggestion) GetFromCount() int64 {
+	if x != nil {
+		return x.FromCount
+	}
+	return 0
+}
+
+func (x *CategoryMergeSuggestion) GetIntoCount() int64 {
+	if x != nil {
+		return x.IntoCount
+	}
+	return 0
+}
+
+type SuggestCategoryMergesResponse struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Items         []*CategoryMergeSuggestion `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestCategoryMergesResponse) Reset() {
+	*x = SuggestCategoryMergesResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestCategoryMergesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestCategoryMergesResponse) ProtoMessage() {}
+
+func (x *SuggestCategoryMergesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestCategoryMergesResponse.ProtoReflect.Descriptor instead.
+func (*SuggestCategoryMergesResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *SuggestCategoryMergesResponse) GetItems() []*CategoryMergeSuggestion {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// Правило автокатегоризации: срабатывает, если выполнены все заданные
+// условия; пустое условие и нулевая граница суммы не проверяются.
+type Rule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Меньше — раньше.
+	Priority            int32    `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`
+	DescriptionContains string   `protobuf:"bytes,4,opt,name=description_contains,json=descriptionContains,proto3" json:"description_contains,omitempty"`
+	DescriptionRegex    string   `protobuf:"bytes,5,opt,name=description_regex,json=descriptionRegex,proto3" json:"description_regex,omitempty"`
+	Merchant            string   `protobuf:"bytes,6,opt,name=merchant,proto3" json:"merchant,omitempty"`
+	MinAmount           string   `protobuf:"bytes,7,opt,name=min_amount,json=minAmount,proto3" json:"min_amount,omitempty"`
+	MaxAmount           string   `protobuf:"bytes,8,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
+	Category            string   `protobuf:"bytes,9,opt,name=category,proto3" json:"category,omitempty"`
+	Tags                []string `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	Disabled            bool     `protobuf:"varint,11,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Rule) Reset() {
+	*x = Rule{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Budget) String() string {
+func (x *Rule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Budget) ProtoMessage() {}
+func (*Rule) ProtoMessage() {}
 
-func (x *Budget) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[1]
+func (x *Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -132,124 +4113,110 @@ func (x *Budget) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Budget.ProtoReflect.Descriptor instead.
-func (*Budget) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use Rule.ProtoReflect.Descriptor instead.
+func (*Rule) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *Budget) GetCategory() string {
+func (x *Rule) GetId() int64 {
 	if x != nil {
-		return x.Category
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Rule) GetName() string {
+	if x != nil {
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Budget) GetLimit() float64 {
+func (x *Rule) GetPriority() int32 {
 	if x != nil {
-		return x.Limit
+		return x.Priority
 	}
 	return 0
 }
 
-func (x *Budget) GetPeriod() string {
+func (x *Rule) GetDescriptionContains() string {
 	if x != nil {
-		return x.Period
+		return x.DescriptionContains
 	}
 	return ""
 }
 
-type CreateTransactionRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Amount        float64                `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`
-	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Date          string                 `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *CreateTransactionRequest) Reset() {
-	*x = CreateTransactionRequest{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[2]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *CreateTransactionRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Rule) GetDescriptionRegex() string {
+	if x != nil {
+		return x.DescriptionRegex
+	}
+	return ""
 }
 
-func (*CreateTransactionRequest) ProtoMessage() {}
-
-func (x *CreateTransactionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[2]
+func (x *Rule) GetMerchant() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Merchant
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use CreateTransactionRequest.ProtoReflect.Descriptor instead.
-func (*CreateTransactionRequest) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{2}
+func (x *Rule) GetMinAmount() string {
+	if x != nil {
+		return x.MinAmount
+	}
+	return ""
 }
 
-func (x *CreateTransactionRequest) GetAmount() float64 {
+func (x *Rule) GetMaxAmount() string {
 	if x != nil {
-		return x.Amount
+		return x.MaxAmount
 	}
-	return 0
+	return ""
 }
 
-func (x *CreateTransactionRequest) GetCategory() string {
+func (x *Rule) GetCategory() string {
 	if x != nil {
 		return x.Category
 	}
 	return ""
 }
 
-func (x *CreateTransactionRequest) GetDescription() string {
+func (x *Rule) GetTags() []string {
 	if x != nil {
-		return x.Description
+		return x.Tags
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateTransactionRequest) GetDate() string {
+func (x *Rule) GetDisabled() bool {
 	if x != nil {
-		return x.Date
+		return x.Disabled
 	}
-	return ""
+	return false
 }
 
-type CreateBudgetRequest struct {
+type ListRulesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
-	Limit         float64                `protobuf:"fixed64,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Period        string                 `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	Items         []*Rule                `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateBudgetRequest) Reset() {
-	*x = CreateBudgetRequest{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[3]
+func (x *ListRulesResponse) Reset() {
+	*x = ListRulesResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateBudgetRequest) String() string {
+func (x *ListRulesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateBudgetRequest) ProtoMessage() {}
+func (*ListRulesResponse) ProtoMessage() {}
 
-func (x *CreateBudgetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[3]
+func (x *ListRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -260,54 +4227,40 @@ func (x *CreateBudgetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateBudgetRequest.ProtoReflect.Descriptor instead.
-func (*CreateBudgetRequest) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{3}
-}
-
-func (x *CreateBudgetRequest) GetCategory() string {
-	if x != nil {
-		return x.Category
-	}
-	return ""
-}
-
-func (x *CreateBudgetRequest) GetLimit() float64 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
+// Deprecated: Use ListRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListRulesResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *CreateBudgetRequest) GetPeriod() string {
+func (x *ListRulesResponse) GetItems() []*Rule {
 	if x != nil {
-		return x.Period
+		return x.Items
 	}
-	return ""
+	return nil
 }
 
-type ListTransactionsResponse struct {
+type DeleteRuleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Items         []*Transaction         `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTransactionsResponse) Reset() {
-	*x = ListTransactionsResponse{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[4]
+func (x *DeleteRuleRequest) Reset() {
+	*x = DeleteRuleRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTransactionsResponse) String() string {
+func (x *DeleteRuleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTransactionsResponse) ProtoMessage() {}
+func (*DeleteRuleRequest) ProtoMessage() {}
 
-func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[4]
+func (x *DeleteRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -318,40 +4271,41 @@ func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTransactionsResponse.ProtoReflect.Descriptor instead.
-func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use DeleteRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRuleRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *ListTransactionsResponse) GetItems() []*Transaction {
+func (x *DeleteRuleRequest) GetId() int64 {
 	if x != nil {
-		return x.Items
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-type ListBudgetsResponse struct {
+type TestRulesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Items         []*Budget              `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Description   string                 `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	Amount        string                 `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListBudgetsResponse) Reset() {
-	*x = ListBudgetsResponse{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[5]
+func (x *TestRulesRequest) Reset() {
+	*x = TestRulesRequest{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListBudgetsResponse) String() string {
+func (x *TestRulesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListBudgetsResponse) ProtoMessage() {}
+func (*TestRulesRequest) ProtoMessage() {}
 
-func (x *ListBudgetsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[5]
+func (x *TestRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -362,41 +4316,50 @@ func (x *ListBudgetsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListBudgetsResponse.ProtoReflect.Descriptor instead.
-func (*ListBudgetsResponse) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use TestRulesRequest.ProtoReflect.Descriptor instead.
+func (*TestRulesRequest) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *ListBudgetsResponse) GetItems() []*Budget {
+func (x *TestRulesRequest) GetDescription() string {
 	if x != nil {
-		return x.Items
+		return x.Description
 	}
-	return nil
+	return ""
 }
 
-type ReportSummaryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
-	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+func (x *TestRulesRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+type TestRulesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Пусто, если ни одно правило не подошло.
+	Fired *Rule `protobuf:"bytes,1,opt,name=fired,proto3" json:"fired,omitempty"`
+	// Все подходящие правила в порядке проверки; первое — fired.
+	Matching      []*Rule `protobuf:"bytes,2,rep,name=matching,proto3" json:"matching,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReportSummaryRequest) Reset() {
-	*x = ReportSummaryRequest{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[6]
+func (x *TestRulesResponse) Reset() {
+	*x = TestRulesResponse{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReportSummaryRequest) String() string {
+func (x *TestRulesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReportSummaryRequest) ProtoMessage() {}
+func (*TestRulesResponse) ProtoMessage() {}
 
-func (x *ReportSummaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[6]
+func (x *TestRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -407,47 +4370,47 @@ func (x *ReportSummaryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReportSummaryRequest.ProtoReflect.Descriptor instead.
-func (*ReportSummaryRequest) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use TestRulesResponse.ProtoReflect.Descriptor instead.
+func (*TestRulesResponse) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *ReportSummaryRequest) GetFrom() string {
+func (x *TestRulesResponse) GetFired() *Rule {
 	if x != nil {
-		return x.From
+		return x.Fired
 	}
-	return ""
+	return nil
 }
 
-func (x *ReportSummaryRequest) GetTo() string {
+func (x *TestRulesResponse) GetMatching() []*Rule {
 	if x != nil {
-		return x.To
+		return x.Matching
 	}
-	return ""
+	return nil
 }
 
-type ReportSummaryResponse struct {
+type Settings struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Totals        map[string]float64     `protobuf:"bytes,1,rep,name=totals,proto3" json:"totals,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	BaseCurrency  string                 `protobuf:"bytes,1,opt,name=base_currency,json=baseCurrency,proto3" json:"base_currency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReportSummaryResponse) Reset() {
-	*x = ReportSummaryResponse{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[7]
+func (x *Settings) Reset() {
+	*x = Settings{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReportSummaryResponse) String() string {
+func (x *Settings) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReportSummaryResponse) ProtoMessage() {}
+func (*Settings) ProtoMessage() {}
 
-func (x *ReportSummaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[7]
+func (x *Settings) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -458,16 +4421,16 @@ func (x *ReportSummaryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReportSummaryResponse.ProtoReflect.Descriptor instead.
-func (*ReportSummaryResponse) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use Settings.ProtoReflect.Descriptor instead.
+func (*Settings) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *ReportSummaryResponse) GetTotals() map[string]float64 {
+func (x *Settings) GetBaseCurrency() string {
 	if x != nil {
-		return x.Totals
+		return x.BaseCurrency
 	}
-	return nil
+	return ""
 }
 
 type BulkImportTransactionsRequest struct {
@@ -480,7 +4443,7 @@ type BulkImportTransactionsRequest struct {
 
 func (x *BulkImportTransactionsRequest) Reset() {
 	*x = BulkImportTransactionsRequest{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[8]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -492,7 +4455,7 @@ func (x *BulkImportTransactionsRequest) String() string {
 func (*BulkImportTransactionsRequest) ProtoMessage() {}
 
 func (x *BulkImportTransactionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[8]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -505,7 +4468,7 @@ func (x *BulkImportTransactionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BulkImportTransactionsRequest.ProtoReflect.Descriptor instead.
 func (*BulkImportTransactionsRequest) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{8}
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{62}
 }
 
 func (x *BulkImportTransactionsRequest) GetItems() []*CreateTransactionRequest {
@@ -532,7 +4495,7 @@ type BulkImportError struct {
 
 func (x *BulkImportError) Reset() {
 	*x = BulkImportError{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[9]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -544,7 +4507,7 @@ func (x *BulkImportError) String() string {
 func (*BulkImportError) ProtoMessage() {}
 
 func (x *BulkImportError) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[9]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -557,7 +4520,7 @@ func (x *BulkImportError) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BulkImportError.ProtoReflect.Descriptor instead.
 func (*BulkImportError) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{9}
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{63}
 }
 
 func (x *BulkImportError) GetIndex() int32 {
@@ -574,18 +4537,71 @@ func (x *BulkImportError) GetError() string {
 	return ""
 }
 
+type BulkImportWarning struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Warning       *BudgetWarning         `protobuf:"bytes,2,opt,name=warning,proto3" json:"warning,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkImportWarning) Reset() {
+	*x = BulkImportWarning{}
+	mi := &file_ledger_v1_ledger_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkImportWarning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkImportWarning) ProtoMessage() {}
+
+func (x *BulkImportWarning) ProtoReflect() protoreflect.Message {
+	mi := &file_ledger_v1_ledger_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkImportWarning.ProtoReflect.Descriptor instead.
+func (*BulkImportWarning) Descriptor() ([]byte, []int) {
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *BulkImportWarning) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BulkImportWarning) GetWarning() *BudgetWarning {
+	if x != nil {
+		return x.Warning
+	}
+	return nil
+}
+
 type BulkImportTransactionsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Accepted      int64                  `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
 	Rejected      int64                  `protobuf:"varint,2,opt,name=rejected,proto3" json:"rejected,omitempty"`
 	Errors        []*BulkImportError     `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	Warnings      []*BulkImportWarning   `protobuf:"bytes,4,rep,name=warnings,proto3" json:"warnings,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *BulkImportTransactionsResponse) Reset() {
 	*x = BulkImportTransactionsResponse{}
-	mi := &file_ledger_v1_ledger_proto_msgTypes[10]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -597,7 +4613,7 @@ func (x *BulkImportTransactionsResponse) String() string {
 func (*BulkImportTransactionsResponse) ProtoMessage() {}
 
 func (x *BulkImportTransactionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ledger_v1_ledger_proto_msgTypes[10]
+	mi := &file_ledger_v1_ledger_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -610,7 +4626,7 @@ func (x *BulkImportTransactionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BulkImportTransactionsResponse.ProtoReflect.Descriptor instead.
 func (*BulkImportTransactionsResponse) Descriptor() ([]byte, []int) {
-	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{10}
+	return file_ledger_v1_ledger_proto_rawDescGZIP(), []int{65}
 }
 
 func (x *BulkImportTransactionsResponse) GetAccepted() int64 {
@@ -634,59 +4650,458 @@ func (x *BulkImportTransactionsResponse) GetErrors() []*BulkImportError {
 	return nil
 }
 
+func (x *BulkImportTransactionsResponse) GetWarnings() []*BulkImportWarning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
 var File_ledger_v1_ledger_proto protoreflect.FileDescriptor
 
 const file_ledger_v1_ledger_proto_rawDesc = "" +
 	"\n" +
-	"\x16ledger/v1/ledger.proto\x12\tledger.v1\x1a\x1bgoogle/protobuf/empty.proto\"\x87\x01\n" +
+	"\x16ledger/v1/ledger.proto\x12\tledger.v1\x1a\x1bgoogle/protobuf/empty.proto\"\xd3\x01\n" +
+	"\rBudgetWarning\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x1c\n" +
+	"\tthreshold\x18\x02 \x01(\x05R\tthreshold\x12\x14\n" +
+	"\x05limit\x18\b \x01(\tR\x05limit\x12\x14\n" +
+	"\x05spent\x18\t \x01(\tR\x05spent\x12\x18\n" +
+	"\apercent\x18\x05 \x01(\x01R\apercent\x12\x1a\n" +
+	"\bexceeded\x18\x06 \x01(\bR\bexceeded\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrencyJ\x04\b\x03\x10\x04J\x04\b\x04\x10\x05\"\xf5\x02\n" +
 	"\vTransaction\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
-	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\x06amount\x18\r \x01(\tR\x06amount\x12\x1a\n" +
 	"\bcategory\x18\x03 \x01(\tR\bcategory\x12 \n" +
 	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x12\n" +
-	"\x04date\x18\x05 \x01(\tR\x04date\"R\n" +
+	"\x04date\x18\x05 \x01(\tR\x04date\x124\n" +
+	"\bwarnings\x18\x06 \x03(\v2\x18.ledger.v1.BudgetWarningR\bwarnings\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12\x12\n" +
+	"\x04kind\x18\b \x01(\tR\x04kind\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\t \x01(\x03R\taccountId\x12\x1f\n" +
+	"\vtransfer_id\x18\n" +
+	" \x01(\x03R\n" +
+	"transferId\x12\x12\n" +
+	"\x04tags\x18\v \x03(\tR\x04tags\x12,\n" +
+	"\aanomaly\x18\f \x01(\v2\x12.ledger.v1.AnomalyR\aanomalyJ\x04\b\x02\x10\x03\"\xf8\x01\n" +
 	"\x06Budget\x12\x1a\n" +
 	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x01R\x05limit\x12\x16\n" +
-	"\x06period\x18\x03 \x01(\tR\x06period\"\x84\x01\n" +
+	"\x05limit\x18\t \x01(\tR\x05limit\x12\x16\n" +
+	"\x06period\x18\x03 \x01(\tR\x06period\x12\x1b\n" +
+	"\tstart_day\x18\x04 \x01(\x05R\bstartDay\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\x12 \n" +
+	"\venforcement\x18\x06 \x01(\tR\venforcement\x12'\n" +
+	"\x0fwarn_thresholds\x18\a \x03(\x05R\x0ewarnThresholds\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrencyJ\x04\b\x02\x10\x03\"\xb7\x02\n" +
 	"\x18CreateTransactionRequest\x12\x16\n" +
-	"\x06amount\x18\x01 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\x06amount\x18\v \x01(\tR\x06amount\x12\x1a\n" +
 	"\bcategory\x18\x02 \x01(\tR\bcategory\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x12\n" +
-	"\x04date\x18\x04 \x01(\tR\x04date\"_\n" +
+	"\x04date\x18\x04 \x01(\tR\x04date\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x12\n" +
+	"\x04kind\x18\x06 \x01(\tR\x04kind\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\a \x01(\x03R\taccountId\x12#\n" +
+	"\rauto_category\x18\b \x01(\bR\fautoCategory\x12\x12\n" +
+	"\x04tags\x18\t \x03(\tR\x04tags\x12#\n" +
+	"\rcheck_anomaly\x18\n" +
+	" \x01(\bR\fcheckAnomalyJ\x04\b\x01\x10\x02\"\x8a\x03\n" +
+	"\x18UpdateTransactionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
+	"\x06amount\x18\n" +
+	" \x01(\tH\x00R\x06amount\x88\x01\x01\x12\x1f\n" +
+	"\bcategory\x18\x03 \x01(\tH\x01R\bcategory\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x02R\vdescription\x88\x01\x01\x12\x17\n" +
+	"\x04date\x18\x05 \x01(\tH\x03R\x04date\x88\x01\x01\x12\x1f\n" +
+	"\bcurrency\x18\x06 \x01(\tH\x04R\bcurrency\x88\x01\x01\x12\x17\n" +
+	"\x04kind\x18\a \x01(\tH\x05R\x04kind\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"account_id\x18\b \x01(\x03H\x06R\taccountId\x88\x01\x01\x12&\n" +
+	"\x04tags\x18\t \x01(\v2\x12.ledger.v1.TagListR\x04tagsB\t\n" +
+	"\a_amountB\v\n" +
+	"\t_categoryB\x0e\n" +
+	"\f_descriptionB\a\n" +
+	"\x05_dateB\v\n" +
+	"\t_currencyB\a\n" +
+	"\x05_kindB\r\n" +
+	"\v_account_idJ\x04\b\x02\x10\x03\"!\n" +
+	"\aTagList\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"*\n" +
+	"\x18DeleteTransactionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x85\x02\n" +
 	"\x13CreateBudgetRequest\x12\x1a\n" +
 	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x01R\x05limit\x12\x16\n" +
-	"\x06period\x18\x03 \x01(\tR\x06period\"H\n" +
+	"\x05limit\x18\t \x01(\tR\x05limit\x12\x16\n" +
+	"\x06period\x18\x03 \x01(\tR\x06period\x12\x1b\n" +
+	"\tstart_day\x18\x04 \x01(\x05R\bstartDay\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\x12 \n" +
+	"\venforcement\x18\x06 \x01(\tR\venforcement\x12'\n" +
+	"\x0fwarn_thresholds\x18\a \x03(\x05R\x0ewarnThresholds\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrencyJ\x04\b\x02\x10\x03\"\x80\x03\n" +
+	"\x17ListTransactionsRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x03 \x03(\tR\n" +
+	"categories\x12\"\n" +
+	"\n" +
+	"min_amount\x18\r \x01(\tH\x00R\tminAmount\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"max_amount\x18\x0e \x01(\tH\x01R\tmaxAmount\x88\x01\x01\x12\x14\n" +
+	"\x05query\x18\x06 \x01(\tR\x05query\x12\x12\n" +
+	"\x04sort\x18\a \x01(\tR\x04sort\x12\x1b\n" +
+	"\tpage_size\x18\b \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\t \x01(\tR\tpageToken\x12\x14\n" +
+	"\x05kinds\x18\n" +
+	" \x03(\tR\x05kinds\x12\x1f\n" +
+	"\vaccount_ids\x18\v \x03(\x03R\n" +
+	"accountIds\x12\x12\n" +
+	"\x04tags\x18\f \x03(\tR\x04tagsB\r\n" +
+	"\v_min_amountB\r\n" +
+	"\v_max_amountJ\x04\b\x04\x10\x05J\x04\b\x05\x10\x06\"p\n" +
 	"\x18ListTransactionsResponse\x12,\n" +
-	"\x05items\x18\x01 \x03(\v2\x16.ledger.v1.TransactionR\x05items\">\n" +
+	"\x05items\x18\x01 \x03(\v2\x16.ledger.v1.TransactionR\x05items\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\">\n" +
 	"\x13ListBudgetsResponse\x12'\n" +
 	"\x05items\x18\x01 \x03(\v2\x11.ledger.v1.BudgetR\x05items\":\n" +
 	"\x14ReportSummaryRequest\x12\x12\n" +
 	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
-	"\x02to\x18\x02 \x01(\tR\x02to\"\x98\x01\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\"\xba\x01\n" +
 	"\x15ReportSummaryResponse\x12D\n" +
-	"\x06totals\x18\x01 \x03(\v2,.ledger.v1.ReportSummaryResponse.TotalsEntryR\x06totals\x1a9\n" +
+	"\x06totals\x18\x03 \x03(\v2,.ledger.v1.ReportSummaryResponse.TotalsEntryR\x06totals\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x1a9\n" +
 	"\vTotalsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"t\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01J\x04\b\x01\x10\x02\"\xe2\x01\n" +
+	"\x0eBudgetProgress\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x16\n" +
+	"\x06period\x18\x02 \x01(\tR\x06period\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\tR\x05limit\x12\x14\n" +
+	"\x05spent\x18\x05 \x01(\tR\x05spent\x12\x1c\n" +
+	"\tremaining\x18\x06 \x01(\tR\tremaining\x12\x18\n" +
+	"\apercent\x18\a \x01(\x01R\apercent\x12\x1c\n" +
+	"\tprojected\x18\b \x01(\tR\tprojected\"I\n" +
+	"\x16BudgetProgressResponse\x12/\n" +
+	"\x05items\x18\x01 \x03(\v2\x19.ledger.v1.BudgetProgressR\x05items\"\x94\x01\n" +
+	"\x19SpendingTimeSeriesRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x16\n" +
+	"\x06bucket\x18\x03 \x01(\tR\x06bucket\x12\x1a\n" +
+	"\btimezone\x18\x04 \x01(\tR\btimezone\x12\x1f\n" +
+	"\vby_category\x18\x05 \x01(\bR\n" +
+	"byCategory\"\xe5\x01\n" +
+	"\vSeriesPoint\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x14\n" +
+	"\x05label\x18\x03 \x01(\tR\x05label\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\tR\x05total\x12G\n" +
+	"\vby_category\x18\x05 \x03(\v2&.ledger.v1.SeriesPoint.ByCategoryEntryR\n" +
+	"byCategory\x1a=\n" +
+	"\x0fByCategoryEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa0\x01\n" +
+	"\x1aSpendingTimeSeriesResponse\x12\x16\n" +
+	"\x06bucket\x18\x01 \x01(\tR\x06bucket\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x03 \x03(\tR\n" +
+	"categories\x12.\n" +
+	"\x06points\x18\x04 \x03(\v2\x16.ledger.v1.SeriesPointR\x06points\"}\n" +
+	"\x15ComparePeriodsRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12!\n" +
+	"\fcompare_from\x18\x03 \x01(\tR\vcompareFrom\x12\x1d\n" +
+	"\n" +
+	"compare_to\x18\x04 \x01(\tR\tcompareTo\"\xcb\x01\n" +
+	"\rCategoryDelta\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x18\n" +
+	"\acurrent\x18\x02 \x01(\tR\acurrent\x12\x1a\n" +
+	"\bprevious\x18\x03 \x01(\tR\bprevious\x12\x14\n" +
+	"\x05delta\x18\x04 \x01(\tR\x05delta\x12(\n" +
+	"\rdelta_percent\x18\x05 \x01(\x01H\x00R\fdeltaPercent\x88\x01\x01\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06statusB\x10\n" +
+	"\x0e_delta_percent\"\xd8\x02\n" +
+	"\x16ComparePeriodsResponse\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12!\n" +
+	"\fcompare_from\x18\x03 \x01(\tR\vcompareFrom\x12\x1d\n" +
+	"\n" +
+	"compare_to\x18\x04 \x01(\tR\tcompareTo\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12.\n" +
+	"\x05items\x18\x06 \x03(\v2\x18.ledger.v1.CategoryDeltaR\x05items\x12.\n" +
+	"\x05total\x18\a \x01(\v2\x18.ledger.v1.CategoryDeltaR\x05total\x12%\n" +
+	"\x0enew_categories\x18\b \x03(\tR\rnewCategories\x125\n" +
+	"\x16disappeared_categories\x18\t \x03(\tR\x15disappearedCategories\"\xe8\x01\n" +
+	"\aAnomaly\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12%\n" +
+	"\x0etransaction_id\x18\x03 \x01(\x03R\rtransactionId\x12\x12\n" +
+	"\x04from\x18\x04 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x05 \x01(\tR\x02to\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\tR\x06amount\x12\x18\n" +
+	"\atypical\x18\a \x01(\tR\atypical\x12\x14\n" +
+	"\x05score\x18\b \x01(\x01R\x05score\x12\x1a\n" +
+	"\bcurrency\x18\t \x01(\tR\bcurrency\"A\n" +
+	"\x15ListAnomaliesResponse\x12(\n" +
+	"\x05items\x18\x01 \x03(\v2\x12.ledger.v1.AnomalyR\x05items\"W\n" +
+	"\x0fCashFlowRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12 \n" +
+	"\vgranularity\x18\x03 \x01(\tR\vgranularity\"\x9b\x01\n" +
+	"\x0eCashFlowPeriod\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x16\n" +
+	"\x06income\x18\x03 \x01(\tR\x06income\x12\x18\n" +
+	"\aexpense\x18\x04 \x01(\tR\aexpense\x12\x10\n" +
+	"\x03net\x18\x05 \x01(\tR\x03net\x12!\n" +
+	"\fsavings_rate\x18\x06 \x01(\x01R\vsavingsRate\"\x94\x01\n" +
+	"\x10CashFlowResponse\x123\n" +
+	"\aperiods\x18\x01 \x03(\v2\x19.ledger.v1.CashFlowPeriodR\aperiods\x12/\n" +
+	"\x05total\x18\x02 \x01(\v2\x19.ledger.v1.CashFlowPeriodR\x05total\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\"\x91\x01\n" +
+	"\aAccount\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12'\n" +
+	"\x0fopening_balance\x18\x04 \x01(\tR\x0eopeningBalance\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\x05 \x01(\bR\tisDefault\"o\n" +
+	"\x14CreateAccountRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12'\n" +
+	"\x0fopening_balance\x18\x03 \x01(\tR\x0eopeningBalance\"@\n" +
+	"\x14ListAccountsResponse\x12(\n" +
+	"\x05items\x18\x01 \x03(\v2\x12.ledger.v1.AccountR\x05items\"\xdb\x01\n" +
+	"\x0fTransferRequest\x12&\n" +
+	"\x0ffrom_account_id\x18\x01 \x01(\x03R\rfromAccountId\x12\"\n" +
+	"\rto_account_id\x18\x02 \x01(\x03R\vtoAccountId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\x12 \n" +
+	"\tto_amount\x18\x04 \x01(\tH\x00R\btoAmount\x88\x01\x01\x12\x12\n" +
+	"\x04date\x18\x05 \x01(\tR\x04date\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescriptionB\f\n" +
+	"\n" +
+	"_to_amount\"|\n" +
+	"\x10TransferResponse\x126\n" +
+	"\n" +
+	"withdrawal\x18\x01 \x01(\v2\x16.ledger.v1.TransactionR\n" +
+	"withdrawal\x120\n" +
+	"\adeposit\x18\x02 \x01(\v2\x16.ledger.v1.TransactionR\adeposit\"$\n" +
+	"\x12GetBalancesRequest\x12\x0e\n" +
+	"\x02on\x18\x01 \x01(\tR\x02on\"X\n" +
+	"\x0eAccountBalance\x12,\n" +
+	"\aaccount\x18\x01 \x01(\v2\x12.ledger.v1.AccountR\aaccount\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\tR\abalance\"x\n" +
+	"\x13GetBalancesResponse\x12/\n" +
+	"\x05items\x18\x01 \x03(\v2\x19.ledger.v1.AccountBalanceR\x05items\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\tR\x05total\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\"%\n" +
+	"\x13TrialBalanceRequest\x12\x0e\n" +
+	"\x02on\x18\x01 \x01(\tR\x02on\"\x90\x01\n" +
+	"\x10TrialBalanceLine\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x14\n" +
+	"\x05debit\x18\x03 \x01(\tR\x05debit\x12\x16\n" +
+	"\x06credit\x18\x04 \x01(\tR\x06credit\x12\x18\n" +
+	"\abalance\x18\x05 \x01(\tR\abalance\"u\n" +
+	"\x14TrialBalanceResponse\x12\x0e\n" +
+	"\x02on\x18\x01 \x01(\tR\x02on\x121\n" +
+	"\x05lines\x18\x02 \x03(\v2\x1b.ledger.v1.TrialBalanceLineR\x05lines\x12\x1a\n" +
+	"\bbalanced\x18\x03 \x01(\bR\bbalanced\"s\n" +
+	"\x17AccountStatementRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\"\xda\x01\n" +
+	"\rStatementLine\x12\x19\n" +
+	"\bentry_id\x18\x01 \x01(\x03R\aentryId\x12%\n" +
+	"\x0etransaction_id\x18\x02 \x01(\x03R\rtransactionId\x12\x12\n" +
+	"\x04date\x18\x03 \x01(\tR\x04date\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\tR\x06amount\x12\x18\n" +
+	"\abalance\x18\x06 \x01(\tR\abalance\x12\x1f\n" +
+	"\vrecorded_at\x18\a \x01(\tR\n" +
+	"recordedAt\"\xb4\x01\n" +
+	"\x18AccountStatementResponse\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x18\n" +
+	"\aopening\x18\x03 \x01(\tR\aopening\x12\x18\n" +
+	"\aclosing\x18\x04 \x01(\tR\aclosing\x12.\n" +
+	"\x05lines\x18\x05 \x03(\v2\x18.ledger.v1.StatementLineR\x05lines\"\xf4\x02\n" +
+	"\x14RecurringTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\x03R\taccountId\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x1a\n" +
+	"\bcategory\x18\x06 \x01(\tR\bcategory\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\x12\x1c\n" +
+	"\tfrequency\x18\b \x01(\tR\tfrequency\x12\x1a\n" +
+	"\binterval\x18\t \x01(\x05R\binterval\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\n" +
+	" \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\v \x01(\tR\aendDate\x12\x1b\n" +
+	"\tnext_date\x18\f \x01(\tR\bnextDate\x12\x16\n" +
+	"\x06paused\x18\r \x01(\bR\x06paused\"\xb1\x02\n" +
+	"\x16CreateRecurringRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\x03R\taccountId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x1a\n" +
+	"\bcategory\x18\x05 \x01(\tR\bcategory\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x1c\n" +
+	"\tfrequency\x18\a \x01(\tR\tfrequency\x12\x1a\n" +
+	"\binterval\x18\b \x01(\x05R\binterval\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\t \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\n" +
+	" \x01(\tR\aendDate\"N\n" +
+	"\x15ListRecurringResponse\x125\n" +
+	"\x05items\x18\x01 \x03(\v2\x1f.ledger.v1.RecurringTransactionR\x05items\"C\n" +
+	"\x19SetRecurringPausedRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06paused\x18\x02 \x01(\bR\x06paused\"?\n" +
+	"\x17PreviewRecurringRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"0\n" +
+	"\x18PreviewRecurringResponse\x12\x14\n" +
+	"\x05dates\x18\x01 \x03(\tR\x05dates\"{\n" +
+	"\bCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tparent_id\x18\x03 \x01(\x03R\bparentId\x12\x12\n" +
+	"\x04icon\x18\x04 \x01(\tR\x04icon\x12\x1a\n" +
+	"\barchived\x18\x05 \x01(\bR\barchived\"\\\n" +
+	"\x15CreateCategoryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\x03R\bparentId\x12\x12\n" +
+	"\x04icon\x18\x03 \x01(\tR\x04icon\"C\n" +
+	"\x16ListCategoriesResponse\x12)\n" +
+	"\x05items\x18\x01 \x03(\v2\x13.ledger.v1.CategoryR\x05items\"\xc9\x01\n" +
+	"\x15UpdateCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12 \n" +
+	"\tparent_id\x18\x03 \x01(\x03H\x01R\bparentId\x88\x01\x01\x12\x17\n" +
+	"\x04icon\x18\x04 \x01(\tH\x02R\x04icon\x88\x01\x01\x12\x1f\n" +
+	"\barchived\x18\x05 \x01(\bH\x03R\barchived\x88\x01\x01B\a\n" +
+	"\x05_nameB\f\n" +
+	"\n" +
+	"_parent_idB\a\n" +
+	"\x05_iconB\v\n" +
+	"\t_archived\"\x84\x01\n" +
+	"\x16MergeCategoriesRequest\x12\x17\n" +
+	"\afrom_id\x18\x01 \x01(\x03R\x06fromId\x12\x17\n" +
+	"\ainto_id\x18\x02 \x01(\x03R\x06intoId\x12\x1b\n" +
+	"\tfrom_name\x18\x03 \x01(\tR\bfromName\x12\x1b\n" +
+	"\tinto_name\x18\x04 \x01(\tR\bintoName\"A\n" +
+	"\rCategoryAlias\x12\x14\n" +
+	"\x05alias\x18\x01 \x01(\tR\x05alias\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\"M\n" +
+	"\x1bListCategoryAliasesResponse\x12.\n" +
+	"\x05items\x18\x01 \x03(\v2\x18.ledger.v1.CategoryAliasR\x05items\"2\n" +
+	"\x1aDeleteCategoryAliasRequest\x12\x14\n" +
+	"\x05alias\x18\x01 \x01(\tR\x05alias\"\x97\x01\n" +
+	"\x17CategoryMergeSuggestion\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x12\n" +
+	"\x04into\x18\x02 \x01(\tR\x04into\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"from_count\x18\x04 \x01(\x03R\tfromCount\x12\x1d\n" +
+	"\n" +
+	"into_count\x18\x05 \x01(\x03R\tintoCount\"Y\n" +
+	"\x1dSuggestCategoryMergesResponse\x128\n" +
+	"\x05items\x18\x01 \x03(\v2\".ledger.v1.CategoryMergeSuggestionR\x05items\"\xcc\x02\n" +
+	"\x04Rule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bpriority\x18\x03 \x01(\x05R\bpriority\x121\n" +
+	"\x14description_contains\x18\x04 \x01(\tR\x13descriptionContains\x12+\n" +
+	"\x11description_regex\x18\x05 \x01(\tR\x10descriptionRegex\x12\x1a\n" +
+	"\bmerchant\x18\x06 \x01(\tR\bmerchant\x12\x1d\n" +
+	"\n" +
+	"min_amount\x18\a \x01(\tR\tminAmount\x12\x1d\n" +
+	"\n" +
+	"max_amount\x18\b \x01(\tR\tmaxAmount\x12\x1a\n" +
+	"\bcategory\x18\t \x01(\tR\bcategory\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x12\x1a\n" +
+	"\bdisabled\x18\v \x01(\bR\bdisabled\":\n" +
+	"\x11ListRulesResponse\x12%\n" +
+	"\x05items\x18\x01 \x03(\v2\x0f.ledger.v1.RuleR\x05items\"#\n" +
+	"\x11DeleteRuleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"L\n" +
+	"\x10TestRulesRequest\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\tR\x06amount\"g\n" +
+	"\x11TestRulesResponse\x12%\n" +
+	"\x05fired\x18\x01 \x01(\v2\x0f.ledger.v1.RuleR\x05fired\x12+\n" +
+	"\bmatching\x18\x02 \x03(\v2\x0f.ledger.v1.RuleR\bmatching\"/\n" +
+	"\bSettings\x12#\n" +
+	"\rbase_currency\x18\x01 \x01(\tR\fbaseCurrency\"t\n" +
 	"\x1dBulkImportTransactionsRequest\x129\n" +
 	"\x05items\x18\x01 \x03(\v2#.ledger.v1.CreateTransactionRequestR\x05items\x12\x18\n" +
 	"\aworkers\x18\x02 \x01(\x05R\aworkers\"=\n" +
 	"\x0fBulkImportError\x12\x14\n" +
 	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"\x8c\x01\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"]\n" +
+	"\x11BulkImportWarning\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x122\n" +
+	"\awarning\x18\x02 \x01(\v2\x18.ledger.v1.BudgetWarningR\awarning\"\xc6\x01\n" +
 	"\x1eBulkImportTransactionsResponse\x12\x1a\n" +
 	"\baccepted\x18\x01 \x01(\x03R\baccepted\x12\x1a\n" +
 	"\brejected\x18\x02 \x01(\x03R\brejected\x122\n" +
-	"\x06errors\x18\x03 \x03(\v2\x1a.ledger.v1.BulkImportErrorR\x06errors2\xfc\x03\n" +
+	"\x06errors\x18\x03 \x03(\v2\x1a.ledger.v1.BulkImportErrorR\x06errors\x128\n" +
+	"\bwarnings\x18\x04 \x03(\v2\x1c.ledger.v1.BulkImportWarningR\bwarnings2\xd8\x18\n" +
 	"\rLedgerService\x12M\n" +
-	"\x0eAddTransaction\x12#.ledger.v1.CreateTransactionRequest\x1a\x16.ledger.v1.Transaction\x12O\n" +
-	"\x10ListTransactions\x12\x16.google.protobuf.Empty\x1a#.ledger.v1.ListTransactionsResponse\x12>\n" +
+	"\x0eAddTransaction\x12#.ledger.v1.CreateTransactionRequest\x1a\x16.ledger.v1.Transaction\x12[\n" +
+	"\x10ListTransactions\x12\".ledger.v1.ListTransactionsRequest\x1a#.ledger.v1.ListTransactionsResponse\x12R\n" +
+	"\x12StreamTransactions\x12\".ledger.v1.ListTransactionsRequest\x1a\x16.ledger.v1.Transaction0\x01\x12P\n" +
+	"\x11UpdateTransaction\x12#.ledger.v1.UpdateTransactionRequest\x1a\x16.ledger.v1.Transaction\x12P\n" +
+	"\x11DeleteTransaction\x12#.ledger.v1.DeleteTransactionRequest\x1a\x16.google.protobuf.Empty\x12>\n" +
 	"\tSetBudget\x12\x1e.ledger.v1.CreateBudgetRequest\x1a\x11.ledger.v1.Budget\x12E\n" +
 	"\vListBudgets\x12\x16.google.protobuf.Empty\x1a\x1e.ledger.v1.ListBudgetsResponse\x12U\n" +
-	"\x10GetReportSummary\x12\x1f.ledger.v1.ReportSummaryRequest\x1a .ledger.v1.ReportSummaryResponse\x12m\n" +
-	"\x16BulkImportTransactions\x12(.ledger.v1.BulkImportTransactionsRequest\x1a).ledger.v1.BulkImportTransactionsResponseB\x1aZ\x18final/ledger/v1;ledgerv1b\x06proto3"
+	"\x10GetReportSummary\x12\x1f.ledger.v1.ReportSummaryRequest\x1a .ledger.v1.ReportSummaryResponse\x12F\n" +
+	"\vGetCashFlow\x12\x1a.ledger.v1.CashFlowRequest\x1a\x1b.ledger.v1.CashFlowResponse\x12Q\n" +
+	"\fGetTagReport\x12\x1f.ledger.v1.ReportSummaryRequest\x1a .ledger.v1.ReportSummaryResponse\x12W\n" +
+	"\x11GetBudgetProgress\x12\x1f.ledger.v1.ReportSummaryRequest\x1a!.ledger.v1.BudgetProgressResponse\x12d\n" +
+	"\x15GetSpendingTimeSeries\x12$.ledger.v1.SpendingTimeSeriesRequest\x1a%.ledger.v1.SpendingTimeSeriesResponse\x12U\n" +
+	"\x0eComparePeriods\x12 .ledger.v1.ComparePeriodsRequest\x1a!.ledger.v1.ComparePeriodsResponse\x12R\n" +
+	"\rListAnomalies\x12\x1f.ledger.v1.ReportSummaryRequest\x1a .ledger.v1.ListAnomaliesResponse\x12m\n" +
+	"\x16BulkImportTransactions\x12(.ledger.v1.BulkImportTransactionsRequest\x1a).ledger.v1.BulkImportTransactionsResponse\x12D\n" +
+	"\rCreateAccount\x12\x1f.ledger.v1.CreateAccountRequest\x1a\x12.ledger.v1.Account\x12G\n" +
+	"\fListAccounts\x12\x16.google.protobuf.Empty\x1a\x1f.ledger.v1.ListAccountsResponse\x12C\n" +
+	"\bTransfer\x12\x1a.ledger.v1.TransferRequest\x1a\x1b.ledger.v1.TransferResponse\x12L\n" +
+	"\vGetBalances\x12\x1d.ledger.v1.GetBalancesRequest\x1a\x1e.ledger.v1.GetBalancesResponse\x12R\n" +
+	"\x0fGetTrialBalance\x12\x1e.ledger.v1.TrialBalanceRequest\x1a\x1f.ledger.v1.TrialBalanceResponse\x12^\n" +
+	"\x13GetAccountStatement\x12\".ledger.v1.AccountStatementRequest\x1a#.ledger.v1.AccountStatementResponse\x12U\n" +
+	"\x0fCreateRecurring\x12!.ledger.v1.CreateRecurringRequest\x1a\x1f.ledger.v1.RecurringTransaction\x12I\n" +
+	"\rListRecurring\x12\x16.google.protobuf.Empty\x1a .ledger.v1.ListRecurringResponse\x12[\n" +
+	"\x12SetRecurringPaused\x12$.ledger.v1.SetRecurringPausedRequest\x1a\x1f.ledger.v1.RecurringTransaction\x12[\n" +
+	"\x10PreviewRecurring\x12\".ledger.v1.PreviewRecurringRequest\x1a#.ledger.v1.PreviewRecurringResponse\x12G\n" +
+	"\x0eCreateCategory\x12 .ledger.v1.CreateCategoryRequest\x1a\x13.ledger.v1.Category\x12K\n" +
+	"\x0eListCategories\x12\x16.google.protobuf.Empty\x1a!.ledger.v1.ListCategoriesResponse\x12G\n" +
+	"\x0eUpdateCategory\x12 .ledger.v1.UpdateCategoryRequest\x1a\x13.ledger.v1.Category\x12I\n" +
+	"\x0fMergeCategories\x12!.ledger.v1.MergeCategoriesRequest\x1a\x13.ledger.v1.Category\x12F\n" +
+	"\x10SetCategoryAlias\x12\x18.ledger.v1.CategoryAlias\x1a\x18.ledger.v1.CategoryAlias\x12U\n" +
+	"\x13ListCategoryAliases\x12\x16.google.protobuf.Empty\x1a&.ledger.v1.ListCategoryAliasesResponse\x12T\n" +
+	"\x13DeleteCategoryAlias\x12%.ledger.v1.DeleteCategoryAliasRequest\x1a\x16.google.protobuf.Empty\x12Y\n" +
+	"\x15SuggestCategoryMerges\x12\x16.google.protobuf.Empty\x1a(.ledger.v1.SuggestCategoryMergesResponse\x12.\n" +
+	"\n" +
+	"CreateRule\x12\x0f.ledger.v1.Rule\x1a\x0f.ledger.v1.Rule\x12A\n" +
+	"\tListRules\x12\x16.google.protobuf.Empty\x1a\x1c.ledger.v1.ListRulesResponse\x12.\n" +
+	"\n" +
+	"UpdateRule\x12\x0f.ledger.v1.Rule\x1a\x0f.ledger.v1.Rule\x12B\n" +
+	"\n" +
+	"DeleteRule\x12\x1c.ledger.v1.DeleteRuleRequest\x1a\x16.google.protobuf.Empty\x12F\n" +
+	"\tTestRules\x12\x1b.ledger.v1.TestRulesRequest\x1a\x1c.ledger.v1.TestRulesResponse\x12:\n" +
+	"\vGetSettings\x12\x16.google.protobuf.Empty\x1a\x13.ledger.v1.Settings\x12:\n" +
+	"\x0eUpdateSettings\x12\x13.ledger.v1.Settings\x1a\x13.ledger.v1.SettingsB\x1aZ\x18final/ledger/v1;ledgerv1b\x06proto3"
 
 var (
 	file_ledger_v1_ledger_proto_rawDescOnce sync.Once
@@ -700,45 +5115,196 @@ func file_ledger_v1_ledger_proto_rawDescGZIP() []byte {
 	return file_ledger_v1_ledger_proto_rawDescData
 }
 
-var file_ledger_v1_ledger_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_ledger_v1_ledger_proto_msgTypes = make([]protoimpl.MessageInfo, 68)
 var file_ledger_v1_ledger_proto_goTypes = []any{
-	(*Transaction)(nil),                    // 0: ledger.v1.Transaction
-	(*Budget)(nil),                         // 1: ledger.v1.Budget
-	(*CreateTransactionRequest)(nil),       // 2: ledger.v1.CreateTransactionRequest
-	(*CreateBudgetRequest)(nil),            // 3: ledger.v1.CreateBudgetRequest
-	(*ListTransactionsResponse)(nil),       // 4: ledger.v1.ListTransactionsResponse
-	(*ListBudgetsResponse)(nil),            // 5: ledger.v1.ListBudgetsResponse
-	(*ReportSummaryRequest)(nil),           // 6: ledger.v1.ReportSummaryRequest
-	(*ReportSummaryResponse)(nil),          // 7: ledger.v1.ReportSummaryResponse
-	(*BulkImportTransactionsRequest)(nil),  // 8: ledger.v1.BulkImportTransactionsRequest
-	(*BulkImportError)(nil),                // 9: ledger.v1.BulkImportError
-	(*BulkImportTransactionsResponse)(nil), // 10: ledger.v1.BulkImportTransactionsResponse
-	nil,                                    // 11: ledger.v1.ReportSummaryResponse.TotalsEntry
-	(*emptypb.Empty)(nil),                  // 12: google.protobuf.Empty
+	(*BudgetWarning)(nil),                  // 0: ledger.v1.BudgetWarning
+	(*Transaction)(nil),                    // 1: ledger.v1.Transaction
+	(*Budget)(nil),                         // 2: ledger.v1.Budget
+	(*CreateTransactionRequest)(nil),       // 3: ledger.v1.CreateTransactionRequest
+	(*UpdateTransactionRequest)(nil),       // 4: ledger.v1.UpdateTransactionRequest
+	(*TagList)(nil),                        // 5: ledger.v1.TagList
+	(*DeleteTransactionRequest)(nil),       // 6: ledger.v1.DeleteTransactionRequest
+	(*CreateBudgetRequest)(nil),            // 7: ledger.v1.CreateBudgetRequest
+	(*ListTransactionsRequest)(nil),        // 8: ledger.v1.ListTransactionsRequest
+	(*ListTransactionsResponse)(nil),       // 9: ledger.v1.ListTransactionsResponse
+	(*ListBudgetsResponse)(nil),            // 10: ledger.v1.ListBudgetsResponse
+	(*ReportSummaryRequest)(nil),           // 11: ledger.v1.ReportSummaryRequest
+	(*ReportSummaryResponse)(nil),          // 12: ledger.v1.ReportSummaryResponse
+	(*BudgetProgress)(nil),                 // 13: ledger.v1.BudgetProgress
+	(*BudgetProgressResponse)(nil),         // 14: ledger.v1.BudgetProgressResponse
+	(*SpendingTimeSeriesRequest)(nil),      // 15: ledger.v1.SpendingTimeSeriesRequest
+	(*SeriesPoint)(nil),                    // 16: ledger.v1.SeriesPoint
+	(*SpendingTimeSeriesResponse)(nil),     // 17: ledger.v1.SpendingTimeSeriesResponse
+	(*ComparePeriodsRequest)(nil),          // 18: ledger.v1.ComparePeriodsRequest
+	(*CategoryDelta)(nil),                  // 19: ledger.v1.CategoryDelta
+	(*ComparePeriodsResponse)(nil),         // 20: ledger.v1.ComparePeriodsResponse
+	(*Anomaly)(nil),                        // 21: ledger.v1.Anomaly
+	(*ListAnomaliesResponse)(nil),          // 22: ledger.v1.ListAnomaliesResponse
+	(*CashFlowRequest)(nil),                // 23: ledger.v1.CashFlowRequest
+	(*CashFlowPeriod)(nil),                 // 24: ledger.v1.CashFlowPeriod
+	(*CashFlowResponse)(nil),               // 25: ledger.v1.CashFlowResponse
+	(*Account)(nil),                        // 26: ledger.v1.Account
+	(*CreateAccountRequest)(nil),           // 27: ledger.v1.CreateAccountRequest
+	(*ListAccountsResponse)(nil),           // 28: ledger.v1.ListAccountsResponse
+	(*TransferRequest)(nil),                // 29: ledger.v1.TransferRequest
+	(*TransferResponse)(nil),               // 30: ledger.v1.TransferResponse
+	(*GetBalancesRequest)(nil),             // 31: ledger.v1.GetBalancesRequest
+	(*AccountBalance)(nil),                 // 32: ledger.v1.AccountBalance
+	(*GetBalancesResponse)(nil),            // 33: ledger.v1.GetBalancesResponse
+	(*TrialBalanceRequest)(nil),            // 34: ledger.v1.TrialBalanceRequest
+	(*TrialBalanceLine)(nil),               // 35: ledger.v1.TrialBalanceLine
+	(*TrialBalanceResponse)(nil),           // 36: ledger.v1.TrialBalanceResponse
+	(*AccountStatementRequest)(nil),        // 37: ledger.v1.AccountStatementRequest
+	(*StatementLine)(nil),                  // 38: ledger.v1.StatementLine
+	(*AccountStatementResponse)(nil),       // 39: ledger.v1.AccountStatementResponse
+	(*RecurringTransaction)(nil),           // 40: ledger.v1.RecurringTransaction
+	(*CreateRecurringRequest)(nil),         // 41: ledger.v1.CreateRecurringRequest
+	(*ListRecurringResponse)(nil),          // 42: ledger.v1.ListRecurringResponse
+	(*SetRecurringPausedRequest)(nil),      // 43: ledger.v1.SetRecurringPausedRequest
+	(*PreviewRecurringRequest)(nil),        // 44: ledger.v1.PreviewRecurringRequest
+	(*PreviewRecurringResponse)(nil),       // 45: ledger.v1.PreviewRecurringResponse
+	(*Category)(nil),                       // 46: ledger.v1.Category
+	(*CreateCategoryRequest)(nil),          // 47: ledger.v1.CreateCategoryRequest
+	(*ListCategoriesResponse)(nil),         // 48: ledger.v1.ListCategoriesResponse
+	(*UpdateCategoryRequest)(nil),          // 49: ledger.v1.UpdateCategoryRequest
+	(*MergeCategoriesRequest)(nil),         // 50: ledger.v1.MergeCategoriesRequest
+	(*CategoryAlias)(nil),                  // 51: ledger.v1.CategoryAlias
+	(*ListCategoryAliasesResponse)(nil),    // 52: ledger.v1.ListCategoryAliasesResponse
+	(*DeleteCategoryAliasRequest)(nil),     // 53: ledger.v1.DeleteCategoryAliasRequest
+	(*CategoryMergeSuggestion)(nil),        // 54: ledger.v1.CategoryMergeSuggestion
+	(*SuggestCategoryMergesResponse)(nil),  // 55: ledger.v1.SuggestCategoryMergesResponse
+	(*Rule)(nil),                           // 56: ledger.v1.Rule
+	(*ListRulesResponse)(nil),              // 57: ledger.v1.ListRulesResponse
+	(*DeleteRuleRequest)(nil),              // 58: ledger.v1.DeleteRuleRequest
+	(*TestRulesRequest)(nil),               // 59: ledger.v1.TestRulesRequest
+	(*TestRulesResponse)(nil),              // 60: ledger.v1.TestRulesResponse
+	(*Settings)(nil),                       // 61: ledger.v1.Settings
+	(*BulkImportTransactionsRequest)(nil),  // 62: ledger.v1.BulkImportTransactionsRequest
+	(*BulkImportError)(nil),                // 63: ledger.v1.BulkImportError
+	(*BulkImportWarning)(nil),              // 64: ledger.v1.BulkImportWarning
+	(*BulkImportTransactionsResponse)(nil), // 65: ledger.v1.BulkImportTransactionsResponse
+	nil,                                    // 66: ledger.v1.ReportSummaryResponse.TotalsEntry
+	nil,                                    // 67: ledger.v1.SeriesPoint.ByCategoryEntry
+	(*emptypb.Empty)(nil),                  // 68: google.protobuf.Empty
 }
 var file_ledger_v1_ledger_proto_depIdxs = []int32{
-	0,  // 0: ledger.v1.ListTransactionsResponse.items:type_name -> ledger.v1.Transaction
-	1,  // 1: ledger.v1.ListBudgetsResponse.items:type_name -> ledger.v1.Budget
-	11, // 2: ledger.v1.ReportSummaryResponse.totals:type_name -> ledger.v1.ReportSummaryResponse.TotalsEntry
-	2,  // 3: ledger.v1.BulkImportTransactionsRequest.items:type_name -> ledger.v1.CreateTransactionRequest
-	9,  // 4: ledger.v1.BulkImportTransactionsResponse.errors:type_name -> ledger.v1.BulkImportError
-	2,  // 5: ledger.v1.LedgerService.AddTransaction:input_type -> ledger.v1.CreateTransactionRequest
-	12, // 6: ledger.v1.LedgerService.ListTransactions:input_type -> google.protobuf.Empty
-	3,  // 7: ledger.v1.LedgerService.SetBudget:input_type -> ledger.v1.CreateBudgetRequest
-	12, // 8: ledger.v1.LedgerService.ListBudgets:input_type -> google.protobuf.Empty
-	6,  // 9: ledger.v1.LedgerService.GetReportSummary:input_type -> ledger.v1.ReportSummaryRequest
-	8,  // 10: ledger.v1.LedgerService.BulkImportTransactions:input_type -> ledger.v1.BulkImportTransactionsRequest
-	0,  // 11: ledger.v1.LedgerService.AddTransaction:output_type -> ledger.v1.Transaction
-	4,  // 12: ledger.v1.LedgerService.ListTransactions:output_type -> ledger.v1.ListTransactionsResponse
-	1,  // 13: ledger.v1.LedgerService.SetBudget:output_type -> ledger.v1.Budget
-	5,  // 14: ledger.v1.LedgerService.ListBudgets:output_type -> ledger.v1.ListBudgetsResponse
-	7,  // 15: ledger.v1.LedgerService.GetReportSummary:output_type -> ledger.v1.ReportSummaryResponse
-	10, // 16: ledger.v1.LedgerService.BulkImportTransactions:output_type -> ledger.v1.BulkImportTransactionsResponse
-	11, // [11:17] is the sub-list for method output_type
-	5,  // [5:11] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	0,  // 0: ledger.v1.Transaction.warnings:type_name -> ledger.v1.BudgetWarning
+	21, // 1: ledger.v1.Transaction.anomaly:type_name -> ledger.v1.Anomaly
+	5,  // 2: ledger.v1.UpdateTransactionRequest.tags:type_name -> ledger.v1.TagList
+	1,  // 3: ledger.v1.ListTransactionsResponse.items:type_name -> ledger.v1.Transaction
+	2,  // 4: ledger.v1.ListBudgetsResponse.items:type_name -> ledger.v1.Budget
+	66, // 5: ledger.v1.ReportSummaryResponse.totals:type_name -> ledger.v1.ReportSummaryResponse.TotalsEntry
+	13, // 6: ledger.v1.BudgetProgressResponse.items:type_name -> ledger.v1.BudgetProgress
+	67, // 7: ledger.v1.SeriesPoint.by_category:type_name -> ledger.v1.SeriesPoint.ByCategoryEntry
+	16, // 8: ledger.v1.SpendingTimeSeriesResponse.points:type_name -> ledger.v1.SeriesPoint
+	19, // 9: ledger.v1.ComparePeriodsResponse.items:type_name -> ledger.v1.CategoryDelta
+	19, // 10: ledger.v1.ComparePeriodsResponse.total:type_name -> ledger.v1.CategoryDelta
+	21, // 11: ledger.v1.ListAnomaliesResponse.items:type_name -> ledger.v1.Anomaly
+	24, // 12: ledger.v1.CashFlowResponse.periods:type_name -> ledger.v1.CashFlowPeriod
+	24, // 13: ledger.v1.CashFlowResponse.total:type_name -> ledger.v1.CashFlowPeriod
+	26, // 14: ledger.v1.ListAccountsResponse.items:type_name -> ledger.v1.Account
+	1,  // 15: ledger.v1.TransferResponse.withdrawal:type_name -> ledger.v1.Transaction
+	1,  // 16: ledger.v1.TransferResponse.deposit:type_name -> ledger.v1.Transaction
+	26, // 17: ledger.v1.AccountBalance.account:type_name -> ledger.v1.Account
+	32, // 18: ledger.v1.GetBalancesResponse.items:type_name -> ledger.v1.AccountBalance
+	35, // 19: ledger.v1.TrialBalanceResponse.lines:type_name -> ledger.v1.TrialBalanceLine
+	38, // 20: ledger.v1.AccountStatementResponse.lines:type_name -> ledger.v1.StatementLine
+	40, // 21: ledger.v1.ListRecurringResponse.items:type_name -> ledger.v1.RecurringTransaction
+	46, // 22: ledger.v1.ListCategoriesResponse.items:type_name -> ledger.v1.Category
+	51, // 23: ledger.v1.ListCategoryAliasesResponse.items:type_name -> ledger.v1.CategoryAlias
+	54, // 24: ledger.v1.SuggestCategoryMergesResponse.items:type_name -> ledger.v1.CategoryMergeSuggestion
+	56, // 25: ledger.v1.ListRulesResponse.items:type_name -> ledger.v1.Rule
+	56, // 26: ledger.v1.TestRulesResponse.fired:type_name -> ledger.v1.Rule
+	56, // 27: ledger.v1.TestRulesResponse.matching:type_name -> ledger.v1.Rule
+	3,  // 28: ledger.v1.BulkImportTransactionsRequest.items:type_name -> ledger.v1.CreateTransactionRequest
+	0,  // 29: ledger.v1.BulkImportWarning.warning:type_name -> ledger.v1.BudgetWarning
+	63, // 30: ledger.v1.BulkImportTransactionsResponse.errors:type_name -> ledger.v1.BulkImportError
+	64, // 31: ledger.v1.BulkImportTransactionsResponse.warnings:type_name -> ledger.v1.BulkImportWarning
+	3,  // 32: ledger.v1.LedgerService.AddTransaction:input_type -> ledger.v1.CreateTransactionRequest
+	8,  // 33: ledger.v1.LedgerService.ListTransactions:input_type -> ledger.v1.ListTransactionsRequest
+	8,  // 34: ledger.v1.LedgerService.StreamTransactions:input_type -> ledger.v1.ListTransactionsRequest
+	4,  // 35: ledger.v1.LedgerService.UpdateTransaction:input_type -> ledger.v1.UpdateTransactionRequest
+	6,  // 36: ledger.v1.LedgerService.DeleteTransaction:input_type -> ledger.v1.DeleteTransactionRequest
+	7,  // 37: ledger.v1.LedgerService.SetBudget:input_type -> ledger.v1.CreateBudgetRequest
+	68, // 38: ledger.v1.LedgerService.ListBudgets:input_type -> google.protobuf.Empty
+	11, // 39: ledger.v1.LedgerService.GetReportSummary:input_type -> ledger.v1.ReportSummaryRequest
+	23, // 40: ledger.v1.LedgerService.GetCashFlow:input_type -> ledger.v1.CashFlowRequest
+	11, // 41: ledger.v1.LedgerService.GetTagReport:input_type -> ledger.v1.ReportSummaryRequest
+	11, // 42: ledger.v1.LedgerService.GetBudgetProgress:input_type -> ledger.v1.ReportSummaryRequest
+	15, // 43: ledger.v1.LedgerService.GetSpendingTimeSeries:input_type -> ledger.v1.SpendingTimeSeriesRequest
+	18, // 44: ledger.v1.LedgerService.ComparePeriods:input_type -> ledger.v1.ComparePeriodsRequest
+	11, // 45: ledger.v1.LedgerService.ListAnomalies:input_type -> ledger.v1.ReportSummaryRequest
+	62, // 46: ledger.v1.LedgerService.BulkImportTransactions:input_type -> ledger.v1.BulkImportTransactionsRequest
+	27, // 47: ledger.v1.LedgerService.CreateAccount:input_type -> ledger.v1.CreateAccountRequest
+	68, // 48: ledger.v1.LedgerService.ListAccounts:input_type -> google.protobuf.Empty
+	29, // 49: ledger.v1.LedgerService.Transfer:input_type -> ledger.v1.TransferRequest
+	31, // 50: ledger.v1.LedgerService.GetBalances:input_type -> ledger.v1.GetBalancesRequest
+	34, // 51: ledger.v1.LedgerService.GetTrialBalance:input_type -> ledger.v1.TrialBalanceRequest
+	37, // 52: ledger.v1.LedgerService.GetAccountStatement:input_type -> ledger.v1.AccountStatementRequest
+	41, // 53: ledger.v1.LedgerService.CreateRecurring:input_type -> ledger.v1.CreateRecurringRequest
+	68, // 54: ledger.v1.LedgerService.ListRecurring:input_type -> google.protobuf.Empty
+	43, // 55: ledger.v1.LedgerService.SetRecurringPaused:input_type -> ledger.v1.SetRecurringPausedRequest
+	44, // 56: ledger.v1.LedgerService.PreviewRecurring:input_type -> ledger.v1.PreviewRecurringRequest
+	47, // 57: ledger.v1.LedgerService.CreateCategory:input_type -> ledger.v1.CreateCategoryRequest
+	68, // 58: ledger.v1.LedgerService.ListCategories:input_type -> google.protobuf.Empty
+	49, // 59: ledger.v1.LedgerService.UpdateCategory:input_type -> ledger.v1.UpdateCategoryRequest
+	50, // 60: ledger.v1.LedgerService.MergeCategories:input_type -> ledger.v1.MergeCategoriesRequest
+	51, // 61: ledger.v1.LedgerService.SetCategoryAlias:input_type -> ledger.v1.CategoryAlias
+	68, // 62: ledger.v1.LedgerService.ListCategoryAliases:input_type -> google.protobuf.Empty
+	53, // 63: ledger.v1.LedgerService.DeleteCategoryAlias:input_type -> ledger.v1.DeleteCategoryAliasRequest
+	68, // 64: ledger.v1.LedgerService.SuggestCategoryMerges:input_type -> google.protobuf.Empty
+	56, // 65: ledger.v1.LedgerService.CreateRule:input_type -> ledger.v1.Rule
+	68, // 66: ledger.v1.LedgerService.ListRules:input_type -> google.protobuf.Empty
+	56, // 67: ledger.v1.LedgerService.UpdateRule:input_type -> ledger.v1.Rule
+	58, // 68: ledger.v1.LedgerService.DeleteRule:input_type -> ledger.v1.DeleteRuleRequest
+	59, // 69: ledger.v1.LedgerService.TestRules:input_type -> ledger.v1.TestRulesRequest
+	68, // 70: ledger.v1.LedgerService.GetSettings:input_type -> google.protobuf.Empty
+	61, // 71: ledger.v1.LedgerService.UpdateSettings:input_type -> ledger.v1.Settings
+	1,  // 72: ledger.v1.LedgerService.AddTransaction:output_type -> ledger.v1.Transaction
+	9,  // 73: ledger.v1.LedgerService.ListTransactions:output_type -> ledger.v1.ListTransactionsResponse
+	1,  // 74: ledger.v1.LedgerService.StreamTransactions:output_type -> ledger.v1.Transaction
+	1,  // 75: ledger.v1.LedgerService.UpdateTransaction:output_type -> ledger.v1.Transaction
+	68, // 76: ledger.v1.LedgerService.DeleteTransaction:output_type -> google.protobuf.Empty
+	2,  // 77: ledger.v1.LedgerService.SetBudget:output_type -> ledger.v1.Budget
+	10, // 78: ledger.v1.LedgerService.ListBudgets:output_type -> ledger.v1.ListBudgetsResponse
+	12, // 79: ledger.v1.LedgerService.GetReportSummary:output_type -> ledger.v1.ReportSummaryResponse
+	25, // 80: ledger.v1.LedgerService.GetCashFlow:output_type -> ledger.v1.CashFlowResponse
+	12, // 81: ledger.v1.LedgerService.GetTagReport:output_type -> ledger.v1.ReportSummaryResponse
+	14, // 82: ledger.v1.LedgerService.GetBudgetProgress:output_type -> ledger.v1.BudgetProgressResponse
+	17, // 83: ledger.v1.LedgerService.GetSpendingTimeSeries:output_type -> ledger.v1.SpendingTimeSeriesResponse
+	20, // 84: ledger.v1.LedgerService.ComparePeriods:output_type -> ledger.v1.ComparePeriodsResponse
+	22, // 85: ledger.v1.LedgerService.ListAnomalies:output_type -> ledger.v1.ListAnomaliesResponse
+	65, // 86: ledger.v1.LedgerService.BulkImportTransactions:output_type -> ledger.v1.BulkImportTransactionsResponse
+	26, // 87: ledger.v1.LedgerService.CreateAccount:output_type -> ledger.v1.Account
+	28, // 88: ledger.v1.LedgerService.ListAccounts:output_type -> ledger.v1.ListAccountsResponse
+	30, // 89: ledger.v1.LedgerService.Transfer:output_type -> ledger.v1.TransferResponse
+	33, // 90: ledger.v1.LedgerService.GetBalances:output_type -> ledger.v1.GetBalancesResponse
+	36, // 91: ledger.v1.LedgerService.GetTrialBalance:output_type -> ledger.v1.TrialBalanceResponse
+	39, // 92: ledger.v1.LedgerService.GetAccountStatement:output_type -> ledger.v1.AccountStatementResponse
+	40, // 93: ledger.v1.LedgerService.CreateRecurring:output_type -> ledger.v1.RecurringTransaction
+	42, // 94: ledger.v1.LedgerService.ListRecurring:output_type -> ledger.v1.ListRecurringResponse
+	40, // 95: ledger.v1.LedgerService.SetRecurringPaused:output_type -> ledger.v1.RecurringTransaction
+	45, // 96: ledger.v1.LedgerService.PreviewRecurring:output_type -> ledger.v1.PreviewRecurringResponse
+	46, // 97: ledger.v1.LedgerService.CreateCategory:output_type -> ledger.v1.Category
+	48, // 98: ledger.v1.LedgerService.ListCategories:output_type -> ledger.v1.ListCategoriesResponse
+	46, // 99: ledger.v1.LedgerService.UpdateCategory:output_type -> ledger.v1.Category
+	46, // 100: ledger.v1.LedgerService.MergeCategories:output_type -> ledger.v1.Category
+	51, // 101: ledger.v1.LedgerService.SetCategoryAlias:output_type -> ledger.v1.CategoryAlias
+	52, // 102: ledger.v1.LedgerService.ListCategoryAliases:output_type -> ledger.v1.ListCategoryAliasesResponse
+	68, // 103: ledger.v1.LedgerService.DeleteCategoryAlias:output_type -> google.protobuf.Empty
+	55, // 104: ledger.v1.LedgerService.SuggestCategoryMerges:output_type -> ledger.v1.SuggestCategoryMergesResponse
+	56, // 105: ledger.v1.LedgerService.CreateRule:output_type -> ledger.v1.Rule
+	57, // 106: ledger.v1.LedgerService.ListRules:output_type -> ledger.v1.ListRulesResponse
+	56, // 107: ledger.v1.LedgerService.UpdateRule:output_type -> ledger.v1.Rule
+	68, // 108: ledger.v1.LedgerService.DeleteRule:output_type -> google.protobuf.Empty
+	60, // 109: ledger.v1.LedgerService.TestRules:output_type -> ledger.v1.TestRulesResponse
+	61, // 110: ledger.v1.LedgerService.GetSettings:output_type -> ledger.v1.Settings
+	61, // 111: ledger.v1.LedgerService.UpdateSettings:output_type -> ledger.v1.Settings
+	72, // [72:112] is the sub-list for method output_type
+	32, // [32:72] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
 }
 
 func init() { file_ledger_v1_ledger_proto_init() }
@@ -746,13 +5312,18 @@ func file_ledger_v1_ledger_proto_init() {
 	if File_ledger_v1_ledger_proto != nil {
 		return
 	}
+	file_ledger_v1_ledger_proto_msgTypes[4].OneofWrappers = []any{}
+	file_ledger_v1_ledger_proto_msgTypes[8].OneofWrappers = []any{}
+	file_ledger_v1_ledger_proto_msgTypes[19].OneofWrappers = []any{}
+	file_ledger_v1_ledger_proto_msgTypes[29].OneofWrappers = []any{}
+	file_ledger_v1_ledger_proto_msgTypes[49].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ledger_v1_ledger_proto_rawDesc), len(file_ledger_v1_ledger_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   68,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,10 +22,44 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	LedgerService_AddTransaction_FullMethodName         = "/ledger.v1.LedgerService/AddTransaction"
 	LedgerService_ListTransactions_FullMethodName       = "/ledger.v1.LedgerService/ListTransactions"
+	LedgerService_StreamTransactions_FullMethodName     = "/ledger.v1.LedgerService/StreamTransactions"
+	LedgerService_UpdateTransaction_FullMethodName      = "/ledger.v1.LedgerService/UpdateTransaction"
+	LedgerService_DeleteTransaction_FullMethodName      = "/ledger.v1.LedgerService/DeleteTransaction"
 	LedgerService_SetBudget_FullMethodName              = "/ledger.v1.LedgerService/SetBudget"
 	LedgerService_ListBudgets_FullMethodName            = "/ledger.v1.LedgerService/ListBudgets"
 	LedgerService_GetReportSummary_FullMethodName       = "/ledger.v1.LedgerService/GetReportSummary"
+	LedgerService_GetCashFlow_FullMethodName            = "/ledger.v1.LedgerService/GetCashFlow"
+	LedgerService_GetTagReport_FullMethodName           = "/ledger.v1.LedgerService/GetTagReport"
+	LedgerService_GetBudgetProgress_FullMethodName      = "/ledger.v1.LedgerService/GetBudgetProgress"
+	LedgerService_GetSpendingTimeSeries_FullMethodName  = "/ledger.v1.LedgerService/GetSpendingTimeSeries"
+	LedgerService_ComparePeriods_FullMethodName         = "/ledger.v1.LedgerService/ComparePeriods"
+	LedgerService_ListAnomalies_FullMethodName          = "/ledger.v1.LedgerService/ListAnomalies"
 	LedgerService_BulkImportTransactions_FullMethodName = "/ledger.v1.LedgerService/BulkImportTransactions"
+	LedgerService_CreateAccount_FullMethodName          = "/ledger.v1.LedgerService/CreateAccount"
+	LedgerService_ListAccounts_FullMethodName           = "/ledger.v1.LedgerService/ListAccounts"
+	LedgerService_Transfer_FullMethodName               = "/ledger.v1.LedgerService/Transfer"
+	LedgerService_GetBalances_FullMethodName            = "/ledger.v1.LedgerService/GetBalances"
+	LedgerService_GetTrialBalance_FullMethodName        = "/ledger.v1.LedgerService/GetTrialBalance"
+	LedgerService_GetAccountStatement_FullMethodName    = "/ledger.v1.LedgerService/GetAccountStatement"
+	LedgerService_CreateRecurring_FullMethodName        = "/ledger.v1.LedgerService/CreateRecurring"
+	LedgerService_ListRecurring_FullMethodName          = "/ledger.v1.LedgerService/ListRecurring"
+	LedgerService_SetRecurringPaused_FullMethodName     = "/ledger.v1.LedgerService/SetRecurringPaused"
+	LedgerService_PreviewRecurring_FullMethodName       = "/ledger.v1.LedgerService/PreviewRecurring"
+	LedgerService_CreateCategory_FullMethodName         = "/ledger.v1.LedgerService/CreateCategory"
+	LedgerService_ListCategories_FullMethodName         = "/ledger.v1.LedgerService/ListCategories"
+	LedgerService_UpdateCategory_FullMethodName         = "/ledger.v1.LedgerService/UpdateCategory"
+	LedgerService_MergeCategories_FullMethodName        = "/ledger.v1.LedgerService/MergeCategories"
+	LedgerService_SetCategoryAlias_FullMethodName       = "/ledger.v1.LedgerService/SetCategoryAlias"
+	LedgerService_ListCategoryAliases_FullMethodName    = "/ledger.v1.LedgerService/ListCategoryAliases"
+	LedgerService_DeleteCategoryAlias_FullMethodName    = "/ledger.v1.LedgerService/DeleteCategoryAlias"
+	LedgerService_SuggestCategoryMerges_FullMethodName  = "/ledger.v1.LedgerService/SuggestCategoryMerges"
+	LedgerService_CreateRule_FullMethodName             = "/ledger.v1.LedgerService/CreateRule"
+	LedgerService_ListRules_FullMethodName              = "/ledger.v1.LedgerService/ListRules"
+	LedgerService_UpdateRule_FullMethodName             = "/ledger.v1.LedgerService/UpdateRule"
+	LedgerService_DeleteRule_FullMethodName             = "/ledger.v1.LedgerService/DeleteRule"
+	LedgerService_TestRules_FullMethodName              = "/ledger.v1.LedgerService/TestRules"
+	LedgerService_GetSettings_FullMethodName            = "/ledger.v1.LedgerService/GetSettings"
+	LedgerService_UpdateSettings_FullMethodName         = "/ledger.v1.LedgerService/UpdateSettings"
 )
 
 // LedgerServiceClient is the client API for LedgerService service.
@@ -33,11 +67,51 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type LedgerServiceClient interface {
 	AddTransaction(ctx context.Context, in *CreateTransactionRequest, opts ...grpc.CallOption) (*Transaction, error)
-	ListTransactions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
+	ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
+	// Выгрузка всех транзакций под фильтром; page_size игнорируется.
+	StreamTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Transaction], error)
+	UpdateTransaction(ctx context.Context, in *UpdateTransactionRequest, opts ...grpc.CallOption) (*Transaction, error)
+	DeleteTransaction(ctx context.Context, in *DeleteTransactionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	SetBudget(ctx context.Context, in *CreateBudgetRequest, opts ...grpc.CallOption) (*Budget, error)
 	ListBudgets(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListBudgetsResponse, error)
 	GetReportSummary(ctx context.Context, in *ReportSummaryRequest, opts ...grpc.CallOption) (*ReportSummaryResponse, error)
+	GetCashFlow(ctx context.Context, in *CashFlowRequest, opts ...grpc.CallOption) (*CashFlowResponse, error)
+	// Траты по меткам; транзакция с несколькими метками входит в каждую.
+	GetTagReport(ctx context.Context, in *ReportSummaryRequest, opts ...grpc.CallOption) (*ReportSummaryResponse, error)
+	GetBudgetProgress(ctx context.Context, in *ReportSummaryRequest, opts ...grpc.CallOption) (*BudgetProgressResponse, error)
+	GetSpendingTimeSeries(ctx context.Context, in *SpendingTimeSeriesRequest, opts ...grpc.CallOption) (*SpendingTimeSeriesResponse, error)
+	ComparePeriods(ctx context.Context, in *ComparePeriodsRequest, opts ...grpc.CallOption) (*ComparePeriodsResponse, error)
+	ListAnomalies(ctx context.Context, in *ReportSummaryRequest, opts ...grpc.CallOption) (*ListAnomaliesResponse, error)
 	BulkImportTransactions(ctx context.Context, in *BulkImportTransactionsRequest, opts ...grpc.CallOption) (*BulkImportTransactionsResponse, error)
+	CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*Account, error)
+	ListAccounts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListAccountsResponse, error)
+	Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	GetBalances(ctx context.Context, in *GetBalancesRequest, opts ...grpc.CallOption) (*GetBalancesResponse, error)
+	// Журнал двойной записи.
+	GetTrialBalance(ctx context.Context, in *TrialBalanceRequest, opts ...grpc.CallOption) (*TrialBalanceResponse, error)
+	GetAccountStatement(ctx context.Context, in *AccountStatementRequest, opts ...grpc.CallOption) (*AccountStatementResponse, error)
+	// Повторяющиеся транзакции создаются планировщиком ledger.
+	CreateRecurring(ctx context.Context, in *CreateRecurringRequest, opts ...grpc.CallOption) (*RecurringTransaction, error)
+	ListRecurring(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListRecurringResponse, error)
+	SetRecurringPaused(ctx context.Context, in *SetRecurringPausedRequest, opts ...grpc.CallOption) (*RecurringTransaction, error)
+	PreviewRecurring(ctx context.Context, in *PreviewRecurringRequest, opts ...grpc.CallOption) (*PreviewRecurringResponse, error)
+	// Дерево категорий: отчёты и бюджеты родителя включают подкатегории.
+	CreateCategory(ctx context.Context, in *CreateCategoryRequest, opts ...grpc.CallOption) (*Category, error)
+	ListCategories(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListCategoriesResponse, error)
+	UpdateCategory(ctx context.Context, in *UpdateCategoryRequest, opts ...grpc.CallOption) (*Category, error)
+	MergeCategories(ctx context.Context, in *MergeCategoriesRequest, opts ...grpc.CallOption) (*Category, error)
+	SetCategoryAlias(ctx context.Context, in *CategoryAlias, opts ...grpc.CallOption) (*CategoryAlias, error)
+	ListCategoryAliases(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListCategoryAliasesResponse, error)
+	DeleteCategoryAlias(ctx context.Context, in *DeleteCategoryAliasRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SuggestCategoryMerges(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SuggestCategoryMergesResponse, error)
+	// Правила автокатегоризации.
+	CreateRule(ctx context.Context, in *Rule, opts ...grpc.CallOption) (*Rule, error)
+	ListRules(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListRulesResponse, error)
+	UpdateRule(ctx context.Context, in *Rule, opts ...grpc.CallOption) (*Rule, error)
+	DeleteRule(ctx context.Context, in *DeleteRuleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	TestRules(ctx context.Context, in *TestRulesRequest, opts ...grpc.CallOption) (*TestRulesResponse, error)
+	GetSettings(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Settings, error)
+	UpdateSettings(ctx context.Context, in *Settings, opts ...grpc.CallOption) (*Settings, error)
 }
 
 type ledgerServiceClient struct {
@@ -58,7 +132,7 @@ func (c *ledgerServiceClient) AddTransaction(ctx context.Context, in *CreateTran
 	return out, nil
 }
 
-func (c *ledgerServiceClient) ListTransactions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListTransactionsResponse, error) {
+func (c *ledgerServiceClient) ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListTransactionsResponse)
 	err := c.cc.Invoke(ctx, LedgerService_ListTransactions_FullMethodName, in, out, cOpts...)
@@ -68,6 +142,45 @@ func (c *ledgerServiceClient) ListTransactions(ctx context.Context, in *emptypb.
 	return out, nil
 }
 
+func (c *ledgerServiceClient) StreamTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Transaction], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LedgerService_ServiceDesc.Streams[0], LedgerService_StreamTransactions_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListTransactionsRequest, Transaction]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LedgerService_StreamTransactionsClient = grpc.ServerStreamingClient[Transaction]
+
+func (c *ledgerServiceClient) UpdateTransaction(ctx context.Context, in *UpdateTransactionRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, LedgerService_UpdateTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) DeleteTransaction(ctx context.Context, in *DeleteTransactionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LedgerService_DeleteTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *ledgerServiceClient) SetBudget(ctx context.Context, in *CreateBudgetRequest, opts ...grpc.CallOption) (*Budget, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Budget)
@@ -75,202 +188,1259 @@ func (c *ledgerServiceClient) SetBudget(ctx context.Context, in *CreateBudgetReq
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *ledgerServiceClient) ListBudgets(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListBudgetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBudgetsResponse)
+	err := c.cc.Invoke(ctx, LedgerService_ListBudgets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GetReportSummary(ctx context.Context, in *ReportSummaryRequest, opts ...grpc.CallOption) (*ReportSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportSummaryResponse)
+	err := c.cc.Invoke(ctx, LedgerService_GetReportSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GetCashFlow(ctx context.Context, in *CashFlowRequest, opts ...grpc.CallOption) (*CashFlowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CashFlowResponse)
+	err := c.cc.Invoke(ctx, LedgerService_GetCashFlow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GetTagReport(ctx context.Context, in *ReportSummaryRequest, opts ...grpc.CallOption) (*ReportSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportSummaryResponse)
+	err := c.cc.Invoke(ctx, LedgerService_GetTagReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GetBudgetProgress(ctx context.Context, in *ReportSummaryRequest, opts ...grpc.CallOption) (*BudgetProgressResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BudgetProgressResponse)
+	err := c.cc.Invoke(ctx, LedgerService_GetBudgetProgress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GetSpendingTimeSeries(ctx context.Context, in *SpendingTimeSeriesRequest, opts ...grpc.CallOption) (*SpendingTimeSeriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SpendingTimeSeriesResponse)
+	err := c.cc.Invoke(ctx, LedgerService_GetSpendingTimeSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) ComparePeriods(ctx context.Context, in *ComparePeriodsRequest, opts ...grpc.CallOption) (*ComparePeriodsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ComparePeriodsResponse)
+	err := c.cc.Invoke(ctx, LedgerService_ComparePeriods_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) ListAnomalies(ctx context.Context, in *ReportSummaryRequest, opts ...grpc.CallOption) (*ListAnomaliesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAnomaliesResponse)
+	err := c.cc.Invoke(ctx, LedgerService_ListAnomalies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) BulkImportTransactions(ctx context.Context, in *BulkImportTransactionsRequest, opts ...grpc.CallOption) (*BulkImportTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkImportTransactionsResponse)
+	err := c.cc.Invoke(ctx, LedgerService_BulkImportTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Account)
+	err := c.cc.Invoke(ctx, LedgerService_CreateAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) ListAccounts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccountsResponse)
+	err := c.cc.Invoke(ctx, LedgerService_ListAccounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, LedgerService_Transfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GetBalances(ctx context.Context, in *GetBalancesRequest, opts ...grpc.CallOption) (*GetBalancesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBalancesResponse)
+	err := c.cc.Invoke(ctx, LedgerService_GetBalances_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GetTrialBalance(ctx context.Context, in *TrialBalanceRequest, opts ...grpc.CallOption) (*TrialBalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrialBalanceResponse)
+	err := c.cc.Invoke(ctx, LedgerService_GetTrialBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GetAccountStatement(ctx context.Context, in *AccountStatementRequest, opts ...grpc.CallOption) (*AccountStatementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountStatementResponse)
+	err := c.cc.Invoke(ctx, LedgerService_GetAccountStatement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) CreateRecurring(ctx context.Context, in *CreateRecurringRequest, opts ...grpc.CallOption) (*RecurringTransaction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecurringTransaction)
+	err := c.cc.Invoke(ctx, LedgerService_CreateRecurring_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) ListRecurring(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListRecurringResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRecurringResponse)
+	err := c.cc.Invoke(ctx, LedgerService_ListRecurring_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) SetRecurringPaused(ctx context.Context, in *SetRecurringPausedRequest, opts ...grpc.CallOption) (*RecurringTransaction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecurringTransaction)
+	err := c.cc.Invoke(ctx, LedgerService_SetRecurringPaused_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) PreviewRecurring(ctx context.Context, in *PreviewRecurringRequest, opts ...grpc.CallOption) (*PreviewRecurringResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewRecurringResponse)
+	err := c.cc.Invoke(ctx, LedgerService_PreviewRecurring_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) CreateCategory(ctx context.Context, in *CreateCategoryRequest, opts ...grpc.CallOption) (*Category, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Category)
+	err := c.cc.Invoke(ctx, LedgerService_CreateCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) ListCategories(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListCategoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCategoriesResponse)
+	err := c.cc.Invoke(ctx, LedgerService_ListCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) UpdateCategory(ctx context.Context, in *UpdateCategoryRequest, opts ...grpc.CallOption) (*Category, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Category)
+	err := c.cc.Invoke(ctx, LedgerService_UpdateCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) MergeCategories(ctx context.Context, in *MergeCategoriesRequest, opts ...grpc.CallOption) (*Category, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Category)
+	err := c.cc.Invoke(ctx, LedgerService_MergeCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) SetCategoryAlias(ctx context.Context, in *CategoryAlias, opts ...grpc.CallOption) (*CategoryAlias, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CategoryAlias)
+	err := c.cc.Invoke(ctx, LedgerService_SetCategoryAlias_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) ListCategoryAliases(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListCategoryAliasesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCategoryAliasesResponse)
+	err := c.cc.Invoke(ctx, LedgerService_ListCategoryAliases_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) DeleteCategoryAlias(ctx context.Context, in *DeleteCategoryAliasRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LedgerService_DeleteCategoryAlias_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) SuggestCategoryMerges(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SuggestCategoryMergesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestCategoryMergesResponse)
+	err := c.cc.Invoke(ctx, LedgerService_SuggestCategoryMerges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) CreateRule(ctx context.Context, in *Rule, opts ...grpc.CallOption) (*Rule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Rule)
+	err := c.cc.Invoke(ctx, LedgerService_CreateRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) ListRules(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRulesResponse)
+	err := c.cc.Invoke(ctx, LedgerService_ListRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) UpdateRule(ctx context.Context, in *Rule, opts ...grpc.CallOption) (*Rule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Rule)
+	err := c.cc.Invoke(ctx, LedgerService_UpdateRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) DeleteRule(ctx context.Context, in *DeleteRuleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LedgerService_DeleteRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) TestRules(ctx context.Context, in *TestRulesRequest, opts ...grpc.CallOption) (*TestRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestRulesResponse)
+	err := c.cc.Invoke(ctx, LedgerService_TestRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) GetSettings(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Settings, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Settings)
+	err := c.cc.Invoke(ctx, LedgerService_GetSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerServiceClient) UpdateSettings(ctx context.Context, in *Settings, opts ...grpc.CallOption) (*Settings, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Settings)
+	err := c.cc.Invoke(ctx, LedgerService_UpdateSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LedgerServiceServer is the server API for LedgerService service.
+// All implementations must embed UnimplementedLedgerServiceServer
+// for forward compatibility.
+type LedgerServiceServer interface {
+	AddTransaction(context.Context, *CreateTransactionRequest) (*Transaction, error)
+	ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error)
+	// Выгрузка всех транзакций под фильтром; page_size игнорируется.
+	StreamTransactions(*ListTransactionsRequest, grpc.ServerStreamingServer[Transaction]) error
+	UpdateTransaction(context.Context, *UpdateTransactionRequest) (*Transaction, error)
+	DeleteTransaction(context.Context, *DeleteTransactionRequest) (*emptypb.Empty, error)
+	SetBudget(context.Context, *CreateBudgetRequest) (*Budget, error)
+	ListBudgets(context.Context, *emptypb.Empty) (*ListBudgetsResponse, error)
+	GetReportSummary(context.Context, *ReportSummaryRequest) (*ReportSummaryResponse, error)
+	GetCashFlow(context.Context, *CashFlowRequest) (*CashFlowResponse, error)
+	// Траты по меткам; транзакция с несколькими метками входит в каждую.
+	GetTagReport(context.Context, *ReportSummaryRequest) (*ReportSummaryResponse, error)
+	GetBudgetProgress(context.Context, *ReportSummaryRequest) (*BudgetProgressResponse, error)
+	GetSpendingTimeSeries(context.Context, *SpendingTimeSeriesRequest) (*SpendingTimeSeriesResponse, error)
+	ComparePeriods(context.Context, *ComparePeriodsRequest) (*ComparePeriodsResponse, error)
+	ListAnomalies(context.Context, *ReportSummaryRequest) (*ListAnomaliesResponse, error)
+	BulkImportTransactions(context.Context, *BulkImportTransactionsRequest) (*BulkImportTransactionsResponse, error)
+	CreateAccount(context.Context, *CreateAccountRequest) (*Account, error)
+	ListAccounts(context.Context, *emptypb.Empty) (*ListAccountsResponse, error)
+	Transfer(context.Context, *TransferRequest) (*TransferResponse, error)
+	GetBalances(context.Context, *GetBalancesRequest) (*GetBalancesResponse, error)
+	// Журнал двойной записи.
+	GetTrialBalance(context.Context, *TrialBalanceRequest) (*TrialBalanceResponse, error)
+	GetAccountStatement(context.Context, *AccountStatementRequest) (*AccountStatementResponse, error)
+	// Повторяющиеся транзакции создаются планировщиком ledger.
+	CreateRecurring(context.Context, *CreateRecurringRequest) (*RecurringTransaction, error)
+	ListRecurring(context.Context, *emptypb.Empty) (*ListRecurringResponse, error)
+	SetRecurringPaused(context.Context, *SetRecurringPausedRequest) (*RecurringTransaction, error)
+	PreviewRecurring(context.Context, *PreviewRecurringRequest) (*PreviewRecurringResponse, error)
+	// Дерево категорий: отчёты и бюджеты родителя включают подкатегории.
+	CreateCategory(context.Context, *CreateCategoryRequest) (*Category, error)
+	ListCategories(context.Context, *emptypb.Empty) (*ListCategoriesResponse, error)
+	UpdateCategory(context.Context, *UpdateCategoryRequest) (*Category, error)
+	MergeCategories(context.Context, *MergeCategoriesRequest) (*Category, error)
+	SetCategoryAlias(context.Context, *CategoryAlias) (*CategoryAlias, error)
+	ListCategoryAliases(context.Context, *emptypb.Empty) (*ListCategoryAliasesResponse, error)
+	DeleteCategoryAlias(context.Context, *DeleteCategoryAliasRequest) (*emptypb.Empty, error)
+	SuggestCategoryMerges(context.Context, *emptypb.Empty) (*SuggestCategoryMergesResponse, error)
+	// Правила автокатегоризации.
+	CreateRule(context.Context, *Rule) (*Rule, error)
+	ListRules(context.Context, *emptypb.Empty) (*ListRulesResponse, error)
+	UpdateRule(context.Context, *Rule) (*Rule, error)
+	DeleteRule(context.Context, *DeleteRuleRequest) (*emptypb.Empty, error)
+	TestRules(context.Context, *TestRulesRequest) (*TestRulesResponse, error)
+	GetSettings(context.Context, *emptypb.Empty) (*Settings, error)
+	UpdateSettings(context.Context, *Settings) (*Settings, error)
+	mustEmbedUnimplementedLedgerServiceServer()
+}
+
+// UnimplementedLedgerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLedgerServiceServer struct{}
+
+func (UnimplementedLedgerServiceServer) AddTransaction(context.Context, *CreateTransactionRequest) (*Transaction, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddTransaction not implemented")
+}
+func (UnimplementedLedgerServiceServer) ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTransactions not implemented")
+}
+func (UnimplementedLedgerServiceServer) StreamTransactions(*ListTransactionsRequest, grpc.ServerStreamingServer[Transaction]) error {
+	return status.Error(codes.Unimplemented, "method StreamTransactions not implemented")
+}
+func (UnimplementedLedgerServiceServer) UpdateTransaction(context.Context, *UpdateTransactionRequest) (*Transaction, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateTransaction not implemented")
+}
+func (UnimplementedLedgerServiceServer) DeleteTransaction(context.Context, *DeleteTransactionRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteTransaction not implemented")
+}
+func (UnimplementedLedgerServiceServer) SetBudget(context.Context, *CreateBudgetRequest) (*Budget, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetBudget not implemented")
+}
+func (UnimplementedLedgerServiceServer) ListBudgets(context.Context, *emptypb.Empty) (*ListBudgetsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListBudgets not implemented")
+}
+func (UnimplementedLedgerServiceServer) GetReportSummary(context.Context, *ReportSummaryRequest) (*ReportSummaryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetReportSummary not implemented")
+}
+func (UnimplementedLedgerServiceServer) GetCashFlow(context.Context, *CashFlowRequest) (*CashFlowResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCashFlow not implemented")
+}
+func (UnimplementedLedgerServiceServer) GetTagReport(context.Context, *ReportSummaryRequest) (*ReportSummaryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTagReport not implemented")
+}
+func (UnimplementedLedgerServiceServer) GetBudgetProgress(context.Context, *ReportSummaryRequest) (*BudgetProgressResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBudgetProgress not implemented")
+}
+func (UnimplementedLedgerServiceServer) GetSpendingTimeSeries(context.Context, *SpendingTimeSeriesRequest) (*SpendingTimeSeriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSpendingTimeSeries not implemented")
+}
+func (UnimplementedLedgerServiceServer) ComparePeriods(context.Context, *ComparePeriodsRequest) (*ComparePeriodsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ComparePeriods not implemented")
+}
+func (UnimplementedLedgerServiceServer) ListAnomalies(context.Context, *ReportSummaryRequest) (*ListAnomaliesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAnomalies not implemented")
+}
+func (UnimplementedLedgerServiceServer) BulkImportTransactions(context.Context, *BulkImportTransactionsRequest) (*BulkImportTransactionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BulkImportTransactions not implemented")
+}
+func (UnimplementedLedgerServiceServer) CreateAccount(context.Context, *CreateAccountRequest) (*Account, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateAccount not implemented")
+}
+func (UnimplementedLedgerServiceServer) ListAccounts(context.Context, *emptypb.Empty) (*ListAccountsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAccounts not implemented")
+}
+func (UnimplementedLedgerServiceServer) Transfer(context.Context, *TransferRequest) (*TransferResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Transfer not implemented")
+}
+func (UnimplementedLedgerServiceServer) GetBalances(context.Context, *GetBalancesRequest) (*GetBalancesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBalances not implemented")
+}
+func (UnimplementedLedgerServiceServer) GetTrialBalance(context.Context, *TrialBalanceRequest) (*TrialBalanceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTrialBalance not implemented")
+}
+func (UnimplementedLedgerServiceServer) GetAccountStatement(context.Context, *AccountStatementRequest) (*AccountStatementResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAccountStatement not implemented")
+}
+func (UnimplementedLedgerServiceServer) CreateRecurring(context.Context, *CreateRecurringRequest) (*RecurringTransaction, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateRecurring not implemented")
+}
+func (UnimplementedLedgerServiceServer) ListRecurring(context.Context, *emptypb.Empty) (*ListRecurringResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRecurring not implemented")
+}
+func (UnimplementedLedgerServiceServer) SetRecurringPaused(context.Context, *SetRecurringPausedRequest) (*RecurringTransaction, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetRecurringPaused not implemented")
+}
+func (UnimplementedLedgerServiceServer) PreviewRecurring(context.Context, *PreviewRecurringRequest) (*PreviewRecurringResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PreviewRecurring not implemented")
+}
+func (UnimplementedLedgerServiceServer) CreateCategory(context.Context, *CreateCategoryRequest) (*Category, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateCategory not implemented")
+}
+func (UnimplementedLedgerServiceServer) ListCategories(context.Context, *emptypb.Empty) (*ListCategoriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCategories not implemented")
+}
+func (UnimplementedLedgerServiceServer) UpdateCategory(context.Context, *UpdateCategoryRequest) (*Category, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateCategory not implemented")
+}
+func (UnimplementedLedgerServiceServer) MergeCategories(context.Context, *MergeCategoriesRequest) (*Category, error) {
+	return nil, status.Error(codes.Unimplemented, "method MergeCategories not implemented")
+}
+func (UnimplementedLedgerServiceServer) SetCategoryAlias(context.Context, *CategoryAlias) (*CategoryAlias, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetCategoryAlias not implemented")
+}
+func (UnimplementedLedgerServiceServer) ListCategoryAliases(context.Context, *emptypb.Empty) (*ListCategoryAliasesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCategoryAliases not implemented")
+}
+func (UnimplementedLedgerServiceServer) DeleteCategoryAlias(context.Context, *DeleteCategoryAliasRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteCategoryAlias not implemented")
+}
+func (UnimplementedLedgerServiceServer) SuggestCategoryMerges(context.Context, *emptypb.Empty) (*SuggestCategoryMergesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SuggestCategoryMerges not implemented")
+}
+func (UnimplementedLedgerServiceServer) CreateRule(context.Context, *Rule) (*Rule, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateRule not implemented")
+}
+func (UnimplementedLedgerServiceServer) ListRules(context.Context, *emptypb.Empty) (*ListRulesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRules not implemented")
+}
+func (UnimplementedLedgerServiceServer) UpdateRule(context.Context, *Rule) (*Rule, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateRule not implemented")
+}
+func (UnimplementedLedgerServiceServer) DeleteRule(context.Context, *DeleteRuleRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteRule not implemented")
+}
+func (UnimplementedLedgerServiceServer) TestRules(context.Context, *TestRulesRequest) (*TestRulesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TestRules not implemented")
+}
+func (UnimplementedLedgerServiceServer) GetSettings(context.Context, *emptypb.Empty) (*Settings, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSettings not implemented")
+}
+func (UnimplementedLedgerServiceServer) UpdateSettings(context.Context, *Settings) (*Settings, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateSettings not implemented")
+}
+func (UnimplementedLedgerServiceServer) mustEmbedUnimplementedLedgerServiceServer() {}
+func (UnimplementedLedgerServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeLedgerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LedgerServiceServer will
+// result in compilation errors.
+type UnsafeLedgerServiceServer interface {
+	mustEmbedUnimplementedLedgerServiceServer()
+}
+
+func RegisterLedgerServiceServer(s grpc.ServiceRegistrar, srv LedgerServiceServer) {
+	// If the following call panics, it indicates UnimplementedLedgerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LedgerService_ServiceDesc, srv)
+}
+
+func _LedgerService_AddTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).AddTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_AddTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).AddTransaction(ctx, req.(*CreateTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).ListTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_ListTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).ListTransactions(ctx, req.(*ListTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_StreamTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListTransactionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LedgerServiceServer).StreamTransactions(m, &grpc.GenericServerStream[ListTransactionsRequest, Transaction]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LedgerService_StreamTransactionsServer = grpc.ServerStreamingServer[Transaction]
+
+func _LedgerService_UpdateTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).UpdateTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_UpdateTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).UpdateTransaction(ctx, req.(*UpdateTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_DeleteTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).DeleteTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_DeleteTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).DeleteTransaction(ctx, req.(*DeleteTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_SetBudget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBudgetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).SetBudget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_SetBudget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).SetBudget(ctx, req.(*CreateBudgetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_ListBudgets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).ListBudgets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_ListBudgets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).ListBudgets(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_GetReportSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GetReportSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GetReportSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GetReportSummary(ctx, req.(*ReportSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_GetCashFlow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CashFlowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GetCashFlow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GetCashFlow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GetCashFlow(ctx, req.(*CashFlowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_GetTagReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GetTagReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GetTagReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GetTagReport(ctx, req.(*ReportSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_GetBudgetProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GetBudgetProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GetBudgetProgress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GetBudgetProgress(ctx, req.(*ReportSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_GetSpendingTimeSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SpendingTimeSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GetSpendingTimeSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GetSpendingTimeSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GetSpendingTimeSeries(ctx, req.(*SpendingTimeSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_ComparePeriods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComparePeriodsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).ComparePeriods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_ComparePeriods_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).ComparePeriods(ctx, req.(*ComparePeriodsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_ListAnomalies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).ListAnomalies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_ListAnomalies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).ListAnomalies(ctx, req.(*ReportSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_BulkImportTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkImportTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).BulkImportTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_BulkImportTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).BulkImportTransactions(ctx, req.(*BulkImportTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_CreateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).CreateAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_CreateAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).CreateAccount(ctx, req.(*CreateAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).ListAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_ListAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).ListAccounts(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_Transfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).Transfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_Transfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).Transfer(ctx, req.(*TransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_GetBalances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalancesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GetBalances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GetBalances_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GetBalances(ctx, req.(*GetBalancesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_GetTrialBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrialBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GetTrialBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GetTrialBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GetTrialBalance(ctx, req.(*TrialBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_GetAccountStatement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountStatementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).GetAccountStatement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_GetAccountStatement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).GetAccountStatement(ctx, req.(*AccountStatementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_CreateRecurring_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRecurringRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).CreateRecurring(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_CreateRecurring_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).CreateRecurring(ctx, req.(*CreateRecurringRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_ListRecurring_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).ListRecurring(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_ListRecurring_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).ListRecurring(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_SetRecurringPaused_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRecurringPausedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).SetRecurringPaused(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_SetRecurringPaused_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).SetRecurringPaused(ctx, req.(*SetRecurringPausedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_PreviewRecurring_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewRecurringRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).PreviewRecurring(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_PreviewRecurring_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).PreviewRecurring(ctx, req.(*PreviewRecurringRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_CreateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).CreateCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_CreateCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).CreateCategory(ctx, req.(*CreateCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *ledgerServiceClient) ListBudgets(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListBudgetsResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ListBudgetsResponse)
-	err := c.cc.Invoke(ctx, LedgerService_ListBudgets_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _LedgerService_ListCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).ListCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_ListCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).ListCategories(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *ledgerServiceClient) GetReportSummary(ctx context.Context, in *ReportSummaryRequest, opts ...grpc.CallOption) (*ReportSummaryResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ReportSummaryResponse)
-	err := c.cc.Invoke(ctx, LedgerService_GetReportSummary_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _LedgerService_UpdateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCategoryRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).UpdateCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_UpdateCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).UpdateCategory(ctx, req.(*UpdateCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *ledgerServiceClient) BulkImportTransactions(ctx context.Context, in *BulkImportTransactionsRequest, opts ...grpc.CallOption) (*BulkImportTransactionsResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(BulkImportTransactionsResponse)
-	err := c.cc.Invoke(ctx, LedgerService_BulkImportTransactions_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _LedgerService_MergeCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeCategoriesRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).MergeCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_MergeCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).MergeCategories(ctx, req.(*MergeCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// LedgerServiceServer is the server API for LedgerService service.
-// All implementations must embed UnimplementedLedgerServiceServer
-// for forward compatibility.
-type LedgerServiceServer interface {
-	AddTransaction(context.Context, *CreateTransactionRequest) (*Transaction, error)
-	ListTransactions(context.Context, *emptypb.Empty) (*ListTransactionsResponse, error)
-	SetBudget(context.Context, *CreateBudgetRequest) (*Budget, error)
-	ListBudgets(context.Context, *emptypb.Empty) (*ListBudgetsResponse, error)
-	GetReportSummary(context.Context, *ReportSummaryRequest) (*ReportSummaryResponse, error)
-	BulkImportTransactions(context.Context, *BulkImportTransactionsRequest) (*BulkImportTransactionsResponse, error)
-	mustEmbedUnimplementedLedgerServiceServer()
+func _LedgerService_SetCategoryAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CategoryAlias)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).SetCategoryAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_SetCategoryAlias_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).SetCategoryAlias(ctx, req.(*CategoryAlias))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedLedgerServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedLedgerServiceServer struct{}
-
-func (UnimplementedLedgerServiceServer) AddTransaction(context.Context, *CreateTransactionRequest) (*Transaction, error) {
-	return nil, status.Error(codes.Unimplemented, "method AddTransaction not implemented")
-}
-func (UnimplementedLedgerServiceServer) ListTransactions(context.Context, *emptypb.Empty) (*ListTransactionsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ListTransactions not implemented")
-}
-func (UnimplementedLedgerServiceServer) SetBudget(context.Context, *CreateBudgetRequest) (*Budget, error) {
-	return nil, status.Error(codes.Unimplemented, "method SetBudget not implemented")
-}
-func (UnimplementedLedgerServiceServer) ListBudgets(context.Context, *emptypb.Empty) (*ListBudgetsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method ListBudgets not implemented")
-}
-func (UnimplementedLedgerServiceServer) GetReportSummary(context.Context, *ReportSummaryRequest) (*ReportSummaryResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method GetReportSummary not implemented")
-}
-func (UnimplementedLedgerServiceServer) BulkImportTransactions(context.Context, *BulkImportTransactionsRequest) (*BulkImportTransactionsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method BulkImportTransactions not implemented")
+func _LedgerService_ListCategoryAliases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).ListCategoryAliases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_ListCategoryAliases_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).ListCategoryAliases(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedLedgerServiceServer) mustEmbedUnimplementedLedgerServiceServer() {}
-func (UnimplementedLedgerServiceServer) testEmbeddedByValue()                       {}
 
-// UnsafeLedgerServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to LedgerServiceServer will
-// result in compilation errors.
-type UnsafeLedgerServiceServer interface {
-	mustEmbedUnimplementedLedgerServiceServer()
+func _LedgerService_DeleteCategoryAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCategoryAliasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).DeleteCategoryAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_DeleteCategoryAlias_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).DeleteCategoryAlias(ctx, req.(*DeleteCategoryAliasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterLedgerServiceServer(s grpc.ServiceRegistrar, srv LedgerServiceServer) {
-	// If the following call panics, it indicates UnimplementedLedgerServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _LedgerService_SuggestCategoryMerges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&LedgerService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).SuggestCategoryMerges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_SuggestCategoryMerges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).SuggestCategoryMerges(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_AddTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateTransactionRequest)
+func _LedgerService_CreateRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Rule)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).AddTransaction(ctx, in)
+		return srv.(LedgerServiceServer).CreateRule(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LedgerService_AddTransaction_FullMethodName,
+		FullMethod: LedgerService_CreateRule_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).AddTransaction(ctx, req.(*CreateTransactionRequest))
+		return srv.(LedgerServiceServer).CreateRule(ctx, req.(*Rule))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _LedgerService_ListRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).ListTransactions(ctx, in)
+		return srv.(LedgerServiceServer).ListRules(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LedgerService_ListTransactions_FullMethodName,
+		FullMethod: LedgerService_ListRules_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).ListTransactions(ctx, req.(*emptypb.Empty))
+		return srv.(LedgerServiceServer).ListRules(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_SetBudget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateBudgetRequest)
+func _LedgerService_UpdateRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Rule)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).SetBudget(ctx, in)
+		return srv.(LedgerServiceServer).UpdateRule(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LedgerService_SetBudget_FullMethodName,
+		FullMethod: LedgerService_UpdateRule_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).SetBudget(ctx, req.(*CreateBudgetRequest))
+		return srv.(LedgerServiceServer).UpdateRule(ctx, req.(*Rule))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_ListBudgets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _LedgerService_DeleteRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRuleRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).ListBudgets(ctx, in)
+		return srv.(LedgerServiceServer).DeleteRule(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LedgerService_ListBudgets_FullMethodName,
+		FullMethod: LedgerService_DeleteRule_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).ListBudgets(ctx, req.(*emptypb.Empty))
+		return srv.(LedgerServiceServer).DeleteRule(ctx, req.(*DeleteRuleRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_GetReportSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReportSummaryRequest)
+func _LedgerService_TestRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestRulesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).GetReportSummary(ctx, in)
+		return srv.(LedgerServiceServer).TestRules(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LedgerService_GetReportSummary_FullMethodName,
+		FullMethod: LedgerService_TestRules_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).GetReportSummary(ctx, req.(*ReportSummaryRequest))
+		return srv.(LedgerServiceServer).TestRules(ctx, req.(*TestRulesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LedgerService_BulkImportTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BulkImportTransactionsRequest)
+func _LedgerService_GetSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LedgerServiceServer).BulkImportTransactions(ctx, in)
+		return srv.(LedgerServiceServer).GetSettings(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: LedgerService_BulkImportTransactions_FullMethodName,
+		FullMethod: LedgerService_GetSettings_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LedgerServiceServer).BulkImportTransactions(ctx, req.(*BulkImportTransactionsRequest))
+		return srv.(LedgerServiceServer).GetSettings(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LedgerService_UpdateSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Settings)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServiceServer).UpdateSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LedgerService_UpdateSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServiceServer).UpdateSettings(ctx, req.(*Settings))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -290,6 +1460,14 @@ var LedgerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListTransactions",
 			Handler:    _LedgerService_ListTransactions_Handler,
 		},
+		{
+			MethodName: "UpdateTransaction",
+			Handler:    _LedgerService_UpdateTransaction_Handler,
+		},
+		{
+			MethodName: "DeleteTransaction",
+			Handler:    _LedgerService_DeleteTransaction_Handler,
+		},
 		{
 			MethodName: "SetBudget",
 			Handler:    _LedgerService_SetBudget_Handler,
@@ -302,11 +1480,141 @@ var LedgerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetReportSummary",
 			Handler:    _LedgerService_GetReportSummary_Handler,
 		},
+		{
+			MethodName: "GetCashFlow",
+			Handler:    _LedgerService_GetCashFlow_Handler,
+		},
+		{
+			MethodName: "GetTagReport",
+			Handler:    _LedgerService_GetTagReport_Handler,
+		},
+		{
+			MethodName: "GetBudgetProgress",
+			Handler:    _LedgerService_GetBudgetProgress_Handler,
+		},
+		{
+			MethodName: "GetSpendingTimeSeries",
+			Handler:    _LedgerService_GetSpendingTimeSeries_Handler,
+		},
+		{
+			MethodName: "ComparePeriods",
+			Handler:    _LedgerService_ComparePeriods_Handler,
+		},
+		{
+			MethodName: "ListAnomalies",
+			Handler:    _LedgerService_ListAnomalies_Handler,
+		},
 		{
 			MethodName: "BulkImportTransactions",
 			Handler:    _LedgerService_BulkImportTransactions_Handler,
 		},
+		{
+			MethodName: "CreateAccount",
+			Handler:    _LedgerService_CreateAccount_Handler,
+		},
+		{
+			MethodName: "ListAccounts",
+			Handler:    _LedgerService_ListAccounts_Handler,
+		},
+		{
+			MethodName: "Transfer",
+			Handler:    _LedgerService_Transfer_Handler,
+		},
+		{
+			MethodName: "GetBalances",
+			Handler:    _LedgerService_GetBalances_Handler,
+		},
+		{
+			MethodName: "GetTrialBalance",
+			Handler:    _LedgerService_GetTrialBalance_Handler,
+		},
+		{
+			MethodName: "GetAccountStatement",
+			Handler:    _LedgerService_GetAccountStatement_Handler,
+		},
+		{
+			MethodName: "CreateRecurring",
+			Handler:    _LedgerService_CreateRecurring_Handler,
+		},
+		{
+			MethodName: "ListRecurring",
+			Handler:    _LedgerService_ListRecurring_Handler,
+		},
+		{
+			MethodName: "SetRecurringPaused",
+			Handler:    _LedgerService_SetRecurringPaused_Handler,
+		},
+		{
+			MethodName: "PreviewRecurring",
+			Handler:    _LedgerService_PreviewRecurring_Handler,
+		},
+		{
+			MethodName: "CreateCategory",
+			Handler:    _LedgerService_CreateCategory_Handler,
+		},
+		{
+			MethodName: "ListCategories",
+			Handler:    _LedgerService_ListCategories_Handler,
+		},
+		{
+			MethodName: "UpdateCategory",
+			Handler:    _LedgerService_UpdateCategory_Handler,
+		},
+		{
+			MethodName: "MergeCategories",
+			Handler:    _LedgerService_MergeCategories_Handler,
+		},
+		{
+			MethodName: "SetCategoryAlias",
+			Handler:    _LedgerService_SetCategoryAlias_Handler,
+		},
+		{
+			MethodName: "ListCategoryAliases",
+			Handler:    _LedgerService_ListCategoryAliases_Handler,
+		},
+		{
+			MethodName: "DeleteCategoryAlias",
+			Handler:    _LedgerService_DeleteCategoryAlias_Handler,
+		},
+		{
+			MethodName: "SuggestCategoryMerges",
+			Handler:    _LedgerService_SuggestCategoryMerges_Handler,
+		},
+		{
+			MethodName: "CreateRule",
+			Handler:    _LedgerService_CreateRule_Handler,
+		},
+		{
+			MethodName: "ListRules",
+			Handler:    _LedgerService_ListRules_Handler,
+		},
+		{
+			MethodName: "UpdateRule",
+			Handler:    _LedgerService_UpdateRule_Handler,
+		},
+		{
+			MethodName: "DeleteRule",
+			Handler:    _LedgerService_DeleteRule_Handler,
+		},
+		{
+			MethodName: "TestRules",
+			Handler:    _LedgerService_TestRules_Handler,
+		},
+		{
+			MethodName: "GetSettings",
+			Handler:    _LedgerService_GetSettings_Handler,
+		},
+		{
+			MethodName: "UpdateSettings",
+			Handler:    _LedgerService_UpdateSettings_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamTransactions",
+			Handler:       _LedgerService_StreamTransactions_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "ledger/v1/ledger.proto",
 }
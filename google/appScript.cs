@@ -6,6 +6,7 @@ function onOpen() {
     .createMenu("CashApp")
     .addItem("Register", "registerUser")
     .addItem("Login", "login")
+    .addItem("Refresh Token", "refreshToken")
     .addSeparator()
     .addItem("Push Budgets", "pushBudgets")
     .addItem("Push Transactions", "addTransactions")
@@ -24,9 +25,10 @@ function getToken_() {
   return sh.getRange("C2").getValue(); // access token
 }
 
-function setToken_(access) {
+function setToken_(access, refresh) {
   const sh = getAuthSheet_();
   if (access) sh.getRange("C2").setValue(access);
+  if (refresh) sh.getRange("D2").setValue(refresh); // refresh token
 }
 
 function getCreds_() {
@@ -62,10 +64,26 @@ function login() {
     throw new Error(resp.getContentText());
   }
   const data = JSON.parse(resp.getContentText());
-  setToken_(data.access_token);
+  setToken_(data.access_token, data.refresh_token);
   SpreadsheetApp.getUi().alert("Logged in");
 }
 
+function refreshToken() {
+  const refresh = getAuthSheet_().getRange("D2").getValue();
+  if (!refresh) throw new Error("Refresh token missing. Run login()");
+  const resp = UrlFetchApp.fetch(AUTH_URL + "/auth/refresh", {
+    method: "post",
+    contentType: "application/json",
+    payload: JSON.stringify({ refresh_token: refresh }),
+    muteHttpExceptions: true
+  });
+  if (resp.getResponseCode() !== 200) {
+    throw new Error(resp.getContentText());
+  }
+  const data = JSON.parse(resp.getContentText());
+  setToken_(data.access_token, data.refresh_token);
+}
+
 function authHeaders_() {
   const access = getToken_();
   if (!access) throw new Error("JWT token missing. Run login()");
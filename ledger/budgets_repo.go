@@ -67,7 +67,7 @@ func ListBudgets() ([]Budget, error) {
 	out := make([]Budget, 0)
 	for rows.Next() {
 		var cat string
-		var lim float64
+		var lim Money
 		if err := rows.Scan(&cat, &lim); err != nil {
 			return nil, err
 		}
@@ -86,8 +86,8 @@ func ListBudgets() ([]Budget, error) {
 	return out, nil
 }
 
-func getBudgetLimit(ctx context.Context, db *sql.DB, category string) (float64, bool, error) {
-	var lim float64
+func getBudgetLimit(ctx context.Context, db *sql.DB, category string) (Money, bool, error) {
+	var lim Money
 	err := db.QueryRowContext(ctx, `SELECT limit_amount FROM budgets WHERE category=$1`, category).Scan(&lim)
 	if err == sql.ErrNoRows {
 		return 0, false, nil
@@ -8,9 +8,11 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 
 	ledgerv1 "final/gen/ledger/v1"
 	"final/ledger"
+	"final/ledger/internal/grpcx"
 	"google.golang.org/grpc"
 )
 
@@ -30,7 +32,10 @@ func main() {
 		os.Exit(1)
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(grpcx.UserIDUnaryInterceptor()),
+		grpc.StreamInterceptor(grpcx.UserIDStreamInterceptor()),
+	)
 	ledgerv1.RegisterLedgerServiceServer(grpcServer, ledger.NewGRPCServer(svc))
 
 	fmt.Println("Ledger gRPC started on", addr)
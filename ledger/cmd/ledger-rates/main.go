@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"final/ledger/internal/app"
+)
+
+func main() {
+	file := flag.String("file", "", "XML с курсами ЦБ РФ (XML_daily.asp) или ЕЦБ (eurofxref-*.xml)")
+	flag.Parse()
+
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	n, err := app.LoadRates(context.Background(), *file)
+	if err != nil {
+		fmt.Println("load rates error:", err)
+		os.Exit(1)
+	}
+	fmt.Println("loaded rates:", n)
+}
@@ -33,24 +33,111 @@ func (s *GRPCServer) AddTransaction(ctx context.Context, req *ledgerv1.CreateTra
 	return txToPB(created), nil
 }
 
-func (s *GRPCServer) ListTransactions(ctx context.Context, _ *emptypb.Empty) (*ledgerv1.ListTransactionsResponse, error) {
-	items, err := s.svc.ListTransactions(ctx)
+func (s *GRPCServer) ListTransactions(ctx context.Context, req *ledgerv1.ListTransactionsRequest) (*ledgerv1.ListTransactionsResponse, error) {
+	f, err := filterFromReq(req)
 	if err != nil {
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	out := make([]*ledgerv1.Transaction, 0, len(items))
-	for _, t := range items {
+
+	page, err := s.svc.ListTransactions(ctx, f)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	out := make([]*ledgerv1.Transaction, 0, len(page.Items))
+	for _, t := range page.Items {
 		tt := t
 		out = append(out, txToPB(tt))
 	}
-	return &ledgerv1.ListTransactionsResponse{Items: out}, nil
+	return &ledgerv1.ListTransactionsResponse{Items: out, NextPageToken: page.NextPageToken}, nil
+}
+
+func (s *GRPCServer) StreamTransactions(req *ledgerv1.ListTransactionsRequest, stream ledgerv1.LedgerService_StreamTransactionsServer) error {
+	f, err := filterFromReq(req)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.svc.StreamTransactions(stream.Context(), f, func(t Transaction) error {
+		return stream.Send(txToPB(t))
+	})
+	if err != nil {
+		return mapServiceErr(err)
+	}
+	return nil
+}
+
+func (s *GRPCServer) UpdateTransaction(ctx context.Context, req *ledgerv1.UpdateTransactionRequest) (*ledgerv1.Transaction, error) {
+	var p TransactionPatch
+	if req.AccountId != nil {
+		v := int(req.GetAccountId())
+		p.AccountID = &v
+	}
+	if req.Kind != nil {
+		v := req.GetKind()
+		p.Kind = &v
+	}
+	if req.Amount != nil {
+		v, err := ParseMoney(req.GetAmount())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid amount")
+		}
+		p.Amount = &v
+	}
+	if req.Category != nil {
+		v := req.GetCategory()
+		p.Category = &v
+	}
+	if req.Description != nil {
+		v := req.GetDescription()
+		p.Description = &v
+	}
+	if req.Date != nil {
+		dt, err := time.Parse(time.RFC3339, req.GetDate())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid date")
+		}
+		p.Date = &dt
+	}
+	if req.Currency != nil {
+		v := req.GetCurrency()
+		p.Currency = &v
+	}
+	if req.Tags != nil {
+		v := req.GetTags().GetValues()
+		p.Tags = &v
+	}
+
+	updated, err := s.svc.UpdateTransaction(ctx, int(req.GetId()), p)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	return txToPB(updated), nil
+}
+
+func (s *GRPCServer) DeleteTransaction(ctx context.Context, req *ledgerv1.DeleteTransactionRequest) (*emptypb.Empty, error) {
+	if err := s.svc.DeleteTransaction(ctx, int(req.GetId())); err != nil {
+		return nil, mapServiceErr(err)
+	}
+	return &emptypb.Empty{}, nil
 }
 
 func (s *GRPCServer) SetBudget(ctx context.Context, req *ledgerv1.CreateBudgetRequest) (*ledgerv1.Budget, error) {
+	limit, err := ParseMoney(req.GetLimit())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid limit")
+	}
 	b := Budget{
 		Category: req.GetCategory(),
-		Limit:    req.GetLimit(),
+		Limit:    limit,
 		Period:   req.GetPeriod(),
+		StartDay: int(req.GetStartDay()),
+		Timezone: req.GetTimezone(),
+
+		Enforcement: req.GetEnforcement(),
+		Currency:    req.GetCurrency(),
+	}
+	for _, th := range req.GetWarnThresholds() {
+		b.WarnThresholds = append(b.WarnThresholds, int(th))
 	}
 	created, err := s.svc.SetBudget(ctx, b)
 	if err != nil {
@@ -62,7 +149,7 @@ func (s *GRPCServer) SetBudget(ctx context.Context, req *ledgerv1.CreateBudgetRe
 func (s *GRPCServer) ListBudgets(ctx context.Context, _ *emptypb.Empty) (*ledgerv1.ListBudgetsResponse, error) {
 	items, err := s.svc.ListBudgets(ctx)
 	if err != nil {
-		return nil, status.Error(codes.Internal, "internal error")
+		return nil, mapServiceErr(err)
 	}
 	out := make([]*ledgerv1.Budget, 0, len(items))
 	for _, b := range items {
@@ -86,8 +173,609 @@ func (s *GRPCServer) GetReportSummary(ctx context.Context, req *ledgerv1.ReportS
 	if err != nil {
 		return nil, mapServiceErr(err)
 	}
+	settings, err := s.svc.GetSettings(ctx)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+
+	out := make(map[string]string, len(totals))
+	for cat, sum := range totals {
+		out[cat] = sum.String()
+	}
+	return &ledgerv1.ReportSummaryResponse{Totals: out, Currency: settings.BaseCurrency}, nil
+}
+
+func (s *GRPCServer) GetTagReport(ctx context.Context, req *ledgerv1.ReportSummaryRequest) (*ledgerv1.ReportSummaryResponse, error) {
+	from, err := time.Parse("2006-01-02", req.GetFrom())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid from")
+	}
+	to, err := time.Parse("2006-01-02", req.GetTo())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid to")
+	}
+
+	totals, err := s.svc.ReportByTag(ctx, from, to)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	settings, err := s.svc.GetSettings(ctx)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
 
-	return &ledgerv1.ReportSummaryResponse{Totals: totals}, nil
+	out := make(map[string]string, len(totals))
+	for tag, sum := range totals {
+		out[tag] = sum.String()
+	}
+	return &ledgerv1.ReportSummaryResponse{Totals: out, Currency: settings.BaseCurrency}, nil
+}
+
+func (s *GRPCServer) GetBudgetProgress(ctx context.Context, req *ledgerv1.ReportSummaryRequest) (*ledgerv1.BudgetProgressResponse, error) {
+	from, err := time.Parse("2006-01-02", req.GetFrom())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid from")
+	}
+	to, err := time.Parse("2006-01-02", req.GetTo())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid to")
+	}
+
+	report, err := s.svc.BudgetProgress(ctx, from, to)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	out := make([]*ledgerv1.BudgetProgress, 0, len(report.Progress))
+	for _, p := range report.Progress {
+		out = append(out, &ledgerv1.BudgetProgress{
+			Category:  p.Category,
+			Period:    p.Period,
+			Currency:  p.Currency,
+			Limit:     p.Limit.String(),
+			Spent:     p.Spent.String(),
+			Remaining: p.Remaining.String(),
+			Percent:   p.Percent,
+			Projected: p.Projected.String(),
+		})
+	}
+	return &ledgerv1.BudgetProgressResponse{Items: out}, nil
+}
+
+func (s *GRPCServer) GetSpendingTimeSeries(ctx context.Context, req *ledgerv1.SpendingTimeSeriesRequest) (*ledgerv1.SpendingTimeSeriesResponse, error) {
+	q := SeriesQuery{Bucket: req.GetBucket(), Timezone: req.GetTimezone(), ByCategory: req.GetByCategory()}
+	loc, err := q.Location()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if q.From, err = ParseDay(req.GetFrom(), loc); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid from")
+	}
+	if req.GetTo() != "" {
+		if q.To, err = ParseDay(req.GetTo(), loc); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid to")
+		}
+	}
+
+	series, err := s.svc.SpendingTimeSeries(ctx, q)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	out := &ledgerv1.SpendingTimeSeriesResponse{
+		Bucket:     series.Bucket,
+		Currency:   series.Currency,
+		Categories: series.Categories,
+		Points:     make([]*ledgerv1.SeriesPoint, 0, len(series.Points)),
+	}
+	for _, p := range series.Points {
+		pt := &ledgerv1.SeriesPoint{
+			From:  p.From.Format("2006-01-02"),
+			To:    p.To.Format("2006-01-02"),
+			Label: p.Label,
+			Total: p.Total.String(),
+		}
+		if p.ByCategory != nil {
+			pt.ByCategory = make(map[string]string, len(p.ByCategory))
+			for cat, sum := range p.ByCategory {
+				pt.ByCategory[cat] = sum.String()
+			}
+		}
+		out.Points = append(out.Points, pt)
+	}
+	return out, nil
+}
+
+func (s *GRPCServer) ComparePeriods(ctx context.Context, req *ledgerv1.ComparePeriodsRequest) (*ledgerv1.ComparePeriodsResponse, error) {
+	from, err := time.Parse("2006-01-02", req.GetFrom())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid from")
+	}
+	to, err := time.Parse("2006-01-02", req.GetTo())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid to")
+	}
+	var prevFrom, prevTo time.Time
+	if req.GetCompareFrom() != "" {
+		if prevFrom, err = time.Parse("2006-01-02", req.GetCompareFrom()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid compare_from")
+		}
+	}
+	if req.GetCompareTo() != "" {
+		if prevTo, err = time.Parse("2006-01-02", req.GetCompareTo()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid compare_to")
+		}
+	}
+
+	c, err := s.svc.ComparePeriods(ctx, from, to, prevFrom, prevTo)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	out := &ledgerv1.ComparePeriodsResponse{
+		From:                  c.From.Format("2006-01-02"),
+		To:                    c.To.Format("2006-01-02"),
+		CompareFrom:           c.PreviousFrom.Format("2006-01-02"),
+		CompareTo:             c.PreviousTo.Format("2006-01-02"),
+		Currency:              c.Currency,
+		Items:                 make([]*ledgerv1.CategoryDelta, 0, len(c.Items)),
+		Total:                 categoryDeltaToPB(c.Total),
+		NewCategories:         c.New,
+		DisappearedCategories: c.Disappeared,
+	}
+	for _, d := range c.Items {
+		out.Items = append(out.Items, categoryDeltaToPB(d))
+	}
+	return out, nil
+}
+
+func (s *GRPCServer) ListAnomalies(ctx context.Context, req *ledgerv1.ReportSummaryRequest) (*ledgerv1.ListAnomaliesResponse, error) {
+	from, err := time.Parse("2006-01-02", req.GetFrom())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid from")
+	}
+	to, err := time.Parse("2006-01-02", req.GetTo())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid to")
+	}
+
+	items, err := s.svc.ListAnomalies(ctx, from, to)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	out := &ledgerv1.ListAnomaliesResponse{Items: make([]*ledgerv1.Anomaly, 0, len(items))}
+	for _, a := range items {
+		out.Items = append(out.Items, anomalyToPB(a))
+	}
+	return out, nil
+}
+
+func (s *GRPCServer) GetCashFlow(ctx context.Context, req *ledgerv1.CashFlowRequest) (*ledgerv1.CashFlowResponse, error) {
+	from, err := time.Parse("2006-01-02", req.GetFrom())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid from")
+	}
+	to, err := time.Parse("2006-01-02", req.GetTo())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid to")
+	}
+
+	cf, err := s.svc.CashFlow(ctx, from, to, req.GetGranularity())
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+
+	out := &ledgerv1.CashFlowResponse{
+		Periods:  make([]*ledgerv1.CashFlowPeriod, 0, len(cf.Periods)),
+		Total:    cashFlowPeriodToPB(cf.Total),
+		Currency: cf.Currency,
+	}
+	for _, p := range cf.Periods {
+		out.Periods = append(out.Periods, cashFlowPeriodToPB(p))
+	}
+	return out, nil
+}
+
+func (s *GRPCServer) CreateAccount(ctx context.Context, req *ledgerv1.CreateAccountRequest) (*ledgerv1.Account, error) {
+	acc := Account{Name: req.GetName(), Currency: req.GetCurrency()}
+	if req.GetOpeningBalance() != "" {
+		v, err := ParseMoney(req.GetOpeningBalance())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid opening_balance")
+		}
+		acc.OpeningBalance = v
+	}
+	created, err := s.svc.CreateAccount(ctx, acc)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	return accountToPB(created), nil
+}
+
+func (s *GRPCServer) ListAccounts(ctx context.Context, _ *emptypb.Empty) (*ledgerv1.ListAccountsResponse, error) {
+	items, err := s.svc.ListAccounts(ctx)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	out := make([]*ledgerv1.Account, 0, len(items))
+	for _, a := range items {
+		out = append(out, accountToPB(a))
+	}
+	return &ledgerv1.ListAccountsResponse{Items: out}, nil
+}
+
+func (s *GRPCServer) Transfer(ctx context.Context, req *ledgerv1.TransferRequest) (*ledgerv1.TransferResponse, error) {
+	amount, err := ParseMoney(req.GetAmount())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid amount")
+	}
+	dt, err := time.Parse(time.RFC3339, req.GetDate())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid date")
+	}
+	tr := Transfer{
+		FromAccountID: int(req.GetFromAccountId()),
+		ToAccountID:   int(req.GetToAccountId()),
+		Amount:        amount,
+		Date:          dt,
+		Description:   req.GetDescription(),
+	}
+	if req.ToAmount != nil {
+		v, err := ParseMoney(req.GetToAmount())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid to_amount")
+		}
+		tr.ToAmount = &v
+	}
+
+	out, in, err := s.svc.Transfer(ctx, tr)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	return &ledgerv1.TransferResponse{Withdrawal: txToPB(out), Deposit: txToPB(in)}, nil
+}
+
+func (s *GRPCServer) GetBalances(ctx context.Context, req *ledgerv1.GetBalancesRequest) (*ledgerv1.GetBalancesResponse, error) {
+	var on time.Time
+	if req.GetOn() != "" {
+		v, err := time.Parse("2006-01-02", req.GetOn())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid on")
+		}
+		on = v
+	}
+
+	b, err := s.svc.GetBalances(ctx, on)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	out := &ledgerv1.GetBalancesResponse{
+		Items:    make([]*ledgerv1.AccountBalance, 0, len(b.Items)),
+		Total:    b.Total.String(),
+		Currency: b.Currency,
+	}
+	for _, it := range b.Items {
+		out.Items = append(out.Items, &ledgerv1.AccountBalance{Account: accountToPB(it.Account), Balance: it.Balance.String()})
+	}
+	return out, nil
+}
+
+func (s *GRPCServer) GetTrialBalance(ctx context.Context, req *ledgerv1.TrialBalanceRequest) (*ledgerv1.TrialBalanceResponse, error) {
+	var on time.Time
+	if req.GetOn() != "" {
+		v, err := time.Parse("2006-01-02", req.GetOn())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid on")
+		}
+		on = v
+	}
+
+	tb, err := s.svc.TrialBalance(ctx, on)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	out := &ledgerv1.TrialBalanceResponse{
+		On:       tb.On.Format("2006-01-02"),
+		Lines:    make([]*ledgerv1.TrialBalanceLine, 0, len(tb.Lines)),
+		Balanced: tb.Balanced(),
+	}
+	for _, l := range tb.Lines {
+		out.Lines = append(out.Lines, &ledgerv1.TrialBalanceLine{
+			Account:  l.Account,
+			Currency: l.Currency,
+			Debit:    l.Debit.String(),
+			Credit:   l.Credit.String(),
+			Balance:  l.Balance().String(),
+		})
+	}
+	return out, nil
+}
+
+func (s *GRPCServer) GetAccountStatement(ctx context.Context, req *ledgerv1.AccountStatementRequest) (*ledgerv1.AccountStatementResponse, error) {
+	from, err := time.Parse("2006-01-02", req.GetFrom())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid from")
+	}
+	to, err := time.Parse("2006-01-02", req.GetTo())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid to")
+	}
+
+	st, err := s.svc.AccountStatement(ctx, req.GetAccount(), req.GetCurrency(), from, to)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	out := &ledgerv1.AccountStatementResponse{
+		Account:  st.Account,
+		Currency: st.Currency,
+		Opening:  st.Opening.String(),
+		Closing:  st.Closing.String(),
+		Lines:    make([]*ledgerv1.StatementLine, 0, len(st.Lines)),
+	}
+	for _, l := range st.Lines {
+		out.Lines = append(out.Lines, &ledgerv1.StatementLine{
+			EntryId:       int64(l.EntryID),
+			TransactionId: int64(l.TransactionID),
+			Date:          l.Date.Format("2006-01-02"),
+			Description:   l.Description,
+			Amount:        l.Amount.String(),
+			Balance:       l.Balance.String(),
+			RecordedAt:    l.RecordedAt.Format(time.RFC3339),
+		})
+	}
+	return out, nil
+}
+
+func (s *GRPCServer) CreateRecurring(ctx context.Context, req *ledgerv1.CreateRecurringRequest) (*ledgerv1.RecurringTransaction, error) {
+	amount, err := ParseMoney(req.GetAmount())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid amount")
+	}
+	start, err := time.Parse("2006-01-02", req.GetStartDate())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid start_date")
+	}
+	r := Recurring{
+		AccountID:   int(req.GetAccountId()),
+		Kind:        req.GetKind(),
+		Amount:      amount,
+		Currency:    req.GetCurrency(),
+		Category:    req.GetCategory(),
+		Description: req.GetDescription(),
+		Frequency:   req.GetFrequency(),
+		Interval:    int(req.GetInterval()),
+		StartDate:   start,
+	}
+	if req.GetEndDate() != "" {
+		if r.EndDate, err = time.Parse("2006-01-02", req.GetEndDate()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid end_date")
+		}
+	}
+
+	created, err := s.svc.CreateRecurring(ctx, r)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	return recurringToPB(created), nil
+}
+
+func (s *GRPCServer) ListRecurring(ctx context.Context, _ *emptypb.Empty) (*ledgerv1.ListRecurringResponse, error) {
+	items, err := s.svc.ListRecurring(ctx)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	out := make([]*ledgerv1.RecurringTransaction, 0, len(items))
+	for _, r := range items {
+		out = append(out, recurringToPB(r))
+	}
+	return &ledgerv1.ListRecurringResponse{Items: out}, nil
+}
+
+func (s *GRPCServer) SetRecurringPaused(ctx context.Context, req *ledgerv1.SetRecurringPausedRequest) (*ledgerv1.RecurringTransaction, error) {
+	r, err := s.svc.SetRecurringPaused(ctx, int(req.GetId()), req.GetPaused())
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	return recurringToPB(r), nil
+}
+
+func (s *GRPCServer) PreviewRecurring(ctx context.Context, req *ledgerv1.PreviewRecurringRequest) (*ledgerv1.PreviewRecurringResponse, error) {
+	dates, err := s.svc.PreviewRecurring(ctx, int(req.GetId()), int(req.GetCount()))
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	out := make([]string, 0, len(dates))
+	for _, d := range dates {
+		out = append(out, d.Format("2006-01-02"))
+	}
+	return &ledgerv1.PreviewRecurringResponse{Dates: out}, nil
+}
+
+func (s *GRPCServer) CreateCategory(ctx context.Context, req *ledgerv1.CreateCategoryRequest) (*ledgerv1.Category, error) {
+	c, err := s.svc.CreateCategory(ctx, Category{
+		Name:     req.GetName(),
+		ParentID: int(req.GetParentId()),
+		Icon:     req.GetIcon(),
+	})
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	return categoryToPB(c), nil
+}
+
+func (s *GRPCServer) ListCategories(ctx context.Context, _ *emptypb.Empty) (*ledgerv1.ListCategoriesResponse, error) {
+	items, err := s.svc.ListCategories(ctx)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	out := make([]*ledgerv1.Category, 0, len(items))
+	for _, c := range items {
+		out = append(out, categoryToPB(c))
+	}
+	return &ledgerv1.ListCategoriesResponse{Items: out}, nil
+}
+
+func (s *GRPCServer) UpdateCategory(ctx context.Context, req *ledgerv1.UpdateCategoryRequest) (*ledgerv1.Category, error) {
+	var p CategoryPatch
+	if req.Name != nil {
+		v := req.GetName()
+		p.Name = &v
+	}
+	if req.ParentId != nil {
+		v := int(req.GetParentId())
+		p.ParentID = &v
+	}
+	if req.Icon != nil {
+		v := req.GetIcon()
+		p.Icon = &v
+	}
+	if req.Archived != nil {
+		v := req.GetArchived()
+		p.Archived = &v
+	}
+	c, err := s.svc.UpdateCategory(ctx, int(req.GetId()), p)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	return categoryToPB(c), nil
+}
+
+func (s *GRPCServer) MergeCategories(ctx context.Context, req *ledgerv1.MergeCategoriesRequest) (*ledgerv1.Category, error) {
+	var c Category
+	var err error
+	if req.GetFromId() == 0 && req.GetFromName() != "" {
+		c, err = s.svc.MergeCategoryNames(ctx, req.GetFromName(), req.GetIntoName())
+	} else {
+		c, err = s.svc.MergeCategory(ctx, int(req.GetFromId()), int(req.GetIntoId()))
+	}
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	return categoryToPB(c), nil
+}
+
+func (s *GRPCServer) SetCategoryAlias(ctx context.Context, req *ledgerv1.CategoryAlias) (*ledgerv1.CategoryAlias, error) {
+	a, err := s.svc.SetCategoryAlias(ctx, req.GetAlias(), req.GetCategory())
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	return &ledgerv1.CategoryAlias{Alias: a.Alias, Category: a.Category}, nil
+}
+
+func (s *GRPCServer) ListCategoryAliases(ctx context.Context, _ *emptypb.Empty) (*ledgerv1.ListCategoryAliasesResponse, error) {
+	items, err := s.svc.ListCategoryAliases(ctx)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	out := make([]*ledgerv1.CategoryAlias, 0, len(items))
+	for _, a := range items {
+		out = append(out, &ledgerv1.CategoryAlias{Alias: a.Alias, Category: a.Category})
+	}
+	return &ledgerv1.ListCategoryAliasesResponse{Items: out}, nil
+}
+
+func (s *GRPCServer) DeleteCategoryAlias(ctx context.Context, req *ledgerv1.DeleteCategoryAliasRequest) (*emptypb.Empty, error) {
+	if err := s.svc.DeleteCategoryAlias(ctx, req.GetAlias()); err != nil {
+		return nil, mapServiceErr(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *GRPCServer) SuggestCategoryMerges(ctx context.Context, _ *emptypb.Empty) (*ledgerv1.SuggestCategoryMergesResponse, error) {
+	items, err := s.svc.SuggestCategoryMerges(ctx)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	out := make([]*ledgerv1.CategoryMergeSuggestion, 0, len(items))
+	for _, m := range items {
+		out = append(out, &ledgerv1.CategoryMergeSuggestion{
+			From:      m.From,
+			Into:      m.Into,
+			Reason:    m.Reason,
+			FromCount: int64(m.FromCount),
+			IntoCount: int64(m.IntoCount),
+		})
+	}
+	return &ledgerv1.SuggestCategoryMergesResponse{Items: out}, nil
+}
+
+func (s *GRPCServer) CreateRule(ctx context.Context, req *ledgerv1.Rule) (*ledgerv1.Rule, error) {
+	r, err := ruleFromPB(req)
+	if err != nil {
+		return nil, err
+	}
+	created, err := s.svc.CreateRule(ctx, r)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	return ruleToPB(created), nil
+}
+
+func (s *GRPCServer) ListRules(ctx context.Context, _ *emptypb.Empty) (*ledgerv1.ListRulesResponse, error) {
+	items, err := s.svc.ListRules(ctx)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	out := make([]*ledgerv1.Rule, 0, len(items))
+	for _, r := range items {
+		out = append(out, ruleToPB(r))
+	}
+	return &ledgerv1.ListRulesResponse{Items: out}, nil
+}
+
+func (s *GRPCServer) UpdateRule(ctx context.Context, req *ledgerv1.Rule) (*ledgerv1.Rule, error) {
+	r, err := ruleFromPB(req)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := s.svc.UpdateRule(ctx, r)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	return ruleToPB(updated), nil
+}
+
+func (s *GRPCServer) DeleteRule(ctx context.Context, req *ledgerv1.DeleteRuleRequest) (*emptypb.Empty, error) {
+	if err := s.svc.DeleteRule(ctx, int(req.GetId())); err != nil {
+		return nil, mapServiceErr(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *GRPCServer) TestRules(ctx context.Context, req *ledgerv1.TestRulesRequest) (*ledgerv1.TestRulesResponse, error) {
+	t := Transaction{Description: req.GetDescription()}
+	if req.GetAmount() != "" {
+		amount, err := ParseMoney(req.GetAmount())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid amount")
+		}
+		t.Amount = amount
+	}
+	matching, err := s.svc.TestRules(ctx, t)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	out := &ledgerv1.TestRulesResponse{}
+	for _, r := range matching {
+		out.Matching = append(out.Matching, ruleToPB(r))
+	}
+	if len(out.Matching) > 0 {
+		out.Fired = out.Matching[0]
+	}
+	return out, nil
+}
+
+func (s *GRPCServer) GetSettings(ctx context.Context, _ *emptypb.Empty) (*ledgerv1.Settings, error) {
+	settings, err := s.svc.GetSettings(ctx)
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	return &ledgerv1.Settings{BaseCurrency: settings.BaseCurrency}, nil
+}
+
+func (s *GRPCServer) UpdateSettings(ctx context.Context, req *ledgerv1.Settings) (*ledgerv1.Settings, error) {
+	settings, err := s.svc.UpdateSettings(ctx, UserSettings{BaseCurrency: req.GetBaseCurrency()})
+	if err != nil {
+		return nil, mapServiceErr(err)
+	}
+	return &ledgerv1.Settings{BaseCurrency: settings.BaseCurrency}, nil
 }
 
 func (s *GRPCServer) BulkImportTransactions(ctx context.Context, req *ledgerv1.BulkImportTransactionsRequest) (*ledgerv1.BulkImportTransactionsResponse, error) {
@@ -115,41 +803,265 @@ func (s *GRPCServer) BulkImportTransactions(ctx context.Context, req *ledgerv1.B
 		errs = append(errs, &ledgerv1.ImportError{Index: int32(ee.Index), Error: ee.Error})
 	}*/
 
+	warns := make([]*ledgerv1.BulkImportWarning, 0, len(summary.Warnings))
+	for _, w := range summary.Warnings {
+		warns = append(warns, &ledgerv1.BulkImportWarning{Index: int32(w.Index), Warning: warningToPB(w.Warning)})
+	}
+
 	return &ledgerv1.BulkImportTransactionsResponse{
 		Accepted: summary.Accepted,
 		Rejected: summary.Rejected,
 		//Errors:   errs,
+		Warnings: warns,
 	}, nil
 }
 
+func filterFromReq(req *ledgerv1.ListTransactionsRequest) (TransactionFilter, error) {
+	f := TransactionFilter{
+		AccountIDs: make([]int, 0, len(req.GetAccountIds())),
+		Kinds:      req.GetKinds(),
+		Categories: req.GetCategories(),
+		Tags:       req.GetTags(),
+		Query:      req.GetQuery(),
+		Sort:       req.GetSort(),
+		PageSize:   int(req.GetPageSize()),
+		PageToken:  req.GetPageToken(),
+	}
+	for _, id := range req.GetAccountIds() {
+		f.AccountIDs = append(f.AccountIDs, int(id))
+	}
+	if req.GetFrom() != "" {
+		from, err := time.Parse("2006-01-02", req.GetFrom())
+		if err != nil {
+			return TransactionFilter{}, errors.New("invalid from")
+		}
+		f.From = from
+	}
+	if req.GetTo() != "" {
+		to, err := time.Parse("2006-01-02", req.GetTo())
+		if err != nil {
+			return TransactionFilter{}, errors.New("invalid to")
+		}
+		f.To = to
+	}
+	if req.MinAmount != nil {
+		v, err := ParseMoney(req.GetMinAmount())
+		if err != nil {
+			return TransactionFilter{}, errors.New("invalid min_amount")
+		}
+		f.MinAmount = &v
+	}
+	if req.MaxAmount != nil {
+		v, err := ParseMoney(req.GetMaxAmount())
+		if err != nil {
+			return TransactionFilter{}, errors.New("invalid max_amount")
+		}
+		f.MaxAmount = &v
+	}
+	return f, nil
+}
+
 func txFromReq(req *ledgerv1.CreateTransactionRequest) (Transaction, error) {
 	dt, err := time.Parse(time.RFC3339, req.GetDate())
 	if err != nil {
 		return Transaction{}, errors.New("invalid date")
 	}
+	amount, err := ParseMoney(req.GetAmount())
+	if err != nil {
+		return Transaction{}, errors.New("invalid amount")
+	}
 	return Transaction{
-		Amount:      req.GetAmount(),
+		AccountID:   int(req.GetAccountId()),
+		Kind:        req.GetKind(),
+		Amount:      amount,
 		Category:    req.GetCategory(),
 		Description: req.GetDescription(),
 		Date:        dt,
+		Currency:    req.GetCurrency(),
+		Tags:        req.GetTags(),
+
+		AutoCategory: req.GetAutoCategory(),
+		CheckAnomaly: req.GetCheckAnomaly(),
 	}, nil
 }
 
 func txToPB(t Transaction) *ledgerv1.Transaction {
-	return &ledgerv1.Transaction{
+	out := &ledgerv1.Transaction{
 		Id:          int64(t.ID),
-		Amount:      t.Amount,
+		Amount:      t.Amount.String(),
 		Category:    t.Category,
 		Description: t.Description,
 		Date:        t.Date.Format(time.RFC3339),
+		Currency:    t.Currency,
+		Kind:        t.Kind,
+		AccountId:   int64(t.AccountID),
+		TransferId:  int64(t.TransferID),
+		Tags:        t.Tags,
+	}
+	for _, w := range t.Warnings {
+		out.Warnings = append(out.Warnings, warningToPB(w))
+	}
+	if t.Anomaly != nil {
+		out.Anomaly = anomalyToPB(*t.Anomaly)
+	}
+	return out
+}
+
+func anomalyToPB(a Anomaly) *ledgerv1.Anomaly {
+	return &ledgerv1.Anomaly{
+		Kind:          a.Kind,
+		Category:      a.Category,
+		TransactionId: int64(a.TransactionID),
+		From:          a.From.Format("2006-01-02"),
+		To:            a.To.Format("2006-01-02"),
+		Amount:        a.Amount.String(),
+		Typical:       a.Typical.String(),
+		Score:         a.Score,
+		Currency:      a.Currency,
+	}
+}
+
+func categoryDeltaToPB(d CategoryDelta) *ledgerv1.CategoryDelta {
+	out := &ledgerv1.CategoryDelta{
+		Category: d.Category,
+		Current:  d.Current.String(),
+		Previous: d.Previous.String(),
+		Delta:    d.Delta.String(),
+		Status:   d.Status,
+	}
+	if d.Previous != 0 {
+		out.DeltaPercent = &d.DeltaPercent
+	}
+	return out
+}
+
+func warningToPB(w BudgetWarning) *ledgerv1.BudgetWarning {
+	return &ledgerv1.BudgetWarning{
+		Category:  w.Category,
+		Threshold: int32(w.Threshold),
+		Limit:     w.Limit.String(),
+		Spent:     w.Spent.String(),
+		Percent:   w.Percent,
+		Exceeded:  w.Exceeded,
+		Currency:  w.Currency,
 	}
 }
 
 func budgetToPB(b Budget) *ledgerv1.Budget {
-	return &ledgerv1.Budget{
+	out := &ledgerv1.Budget{
 		Category: b.Category,
-		Limit:    b.Limit,
+		Limit:    b.Limit.String(),
 		Period:   b.Period,
+		StartDay: int32(b.StartDay),
+		Timezone: b.Timezone,
+
+		Enforcement: b.Enforcement,
+		Currency:    b.Currency,
+	}
+	for _, th := range b.WarnThresholds {
+		out.WarnThresholds = append(out.WarnThresholds, int32(th))
+	}
+	return out
+}
+
+func accountToPB(a Account) *ledgerv1.Account {
+	return &ledgerv1.Account{
+		Id:             int64(a.ID),
+		Name:           a.Name,
+		Currency:       a.Currency,
+		OpeningBalance: a.OpeningBalance.String(),
+		IsDefault:      a.IsDefault,
+	}
+}
+
+func categoryToPB(c Category) *ledgerv1.Category {
+	return &ledgerv1.Category{
+		Id:       int64(c.ID),
+		Name:     c.Name,
+		ParentId: int64(c.ParentID),
+		Icon:     c.Icon,
+		Archived: c.Archived,
+	}
+}
+
+func ruleFromPB(req *ledgerv1.Rule) (Rule, error) {
+	r := Rule{
+		ID:                  int(req.GetId()),
+		Name:                req.GetName(),
+		Priority:            int(req.GetPriority()),
+		DescriptionContains: req.GetDescriptionContains(),
+		DescriptionRegex:    req.GetDescriptionRegex(),
+		Merchant:            req.GetMerchant(),
+		Category:            req.GetCategory(),
+		Tags:                req.GetTags(),
+		Disabled:            req.GetDisabled(),
+	}
+	var err error
+	if req.GetMinAmount() != "" {
+		if r.MinAmount, err = ParseMoney(req.GetMinAmount()); err != nil {
+			return Rule{}, status.Error(codes.InvalidArgument, "invalid min_amount")
+		}
+	}
+	if req.GetMaxAmount() != "" {
+		if r.MaxAmount, err = ParseMoney(req.GetMaxAmount()); err != nil {
+			return Rule{}, status.Error(codes.InvalidArgument, "invalid max_amount")
+		}
+	}
+	return r, nil
+}
+
+func ruleToPB(r Rule) *ledgerv1.Rule {
+	out := &ledgerv1.Rule{
+		Id:                  int64(r.ID),
+		Name:                r.Name,
+		Priority:            int32(r.Priority),
+		DescriptionContains: r.DescriptionContains,
+		DescriptionRegex:    r.DescriptionRegex,
+		Merchant:            r.Merchant,
+		Category:            r.Category,
+		Tags:                r.Tags,
+		Disabled:            r.Disabled,
+	}
+	if r.MinAmount != 0 {
+		out.MinAmount = r.MinAmount.String()
+	}
+	if r.MaxAmount != 0 {
+		out.MaxAmount = r.MaxAmount.String()
+	}
+	return out
+}
+
+func recurringToPB(r Recurring) *ledgerv1.RecurringTransaction {
+	out := &ledgerv1.RecurringTransaction{
+		Id:          int64(r.ID),
+		AccountId:   int64(r.AccountID),
+		Kind:        r.Kind,
+		Amount:      r.Amount.String(),
+		Currency:    r.Currency,
+		Category:    r.Category,
+		Description: r.Description,
+		Frequency:   r.Frequency,
+		Interval:    int32(r.Interval),
+		StartDate:   r.StartDate.Format("2006-01-02"),
+		Paused:      r.Paused,
+	}
+	if !r.EndDate.IsZero() {
+		out.EndDate = r.EndDate.Format("2006-01-02")
+	}
+	if !r.NextDate.IsZero() {
+		out.NextDate = r.NextDate.Format("2006-01-02")
+	}
+	return out
+}
+
+func cashFlowPeriodToPB(p CashFlowPeriod) *ledgerv1.CashFlowPeriod {
+	return &ledgerv1.CashFlowPeriod{
+		From:        p.From.Format("2006-01-02"),
+		To:          p.To.Format("2006-01-02"),
+		Income:      p.Income.String(),
+		Expense:     p.Expense.String(),
+		Net:         p.Net().String(),
+		SavingsRate: p.SavingsRate(),
 	}
 }
 
@@ -157,6 +1069,27 @@ func mapServiceErr(err error) error {
 	if errors.Is(err, ErrBudgetExceeded) || err.Error() == "budget exceeded" {
 		return status.Error(codes.FailedPrecondition, "budget exceeded")
 	}
+	if errors.Is(err, ErrUnauthenticated) {
+		return status.Error(codes.Unauthenticated, "missing user")
+	}
+	if errors.Is(err, ErrNotFound) {
+		return status.Error(codes.NotFound, "not found")
+	}
+	if errors.Is(err, ErrForbidden) {
+		return status.Error(codes.PermissionDenied, "forbidden")
+	}
+	if errors.Is(err, ErrAccountNotFound) {
+		return status.Error(codes.NotFound, "account not found")
+	}
+	if errors.Is(err, ErrCategoryNotFound) {
+		return status.Error(codes.NotFound, "category not found")
+	}
+	if errors.Is(err, ErrCategoryArchived) {
+		return status.Error(codes.FailedPrecondition, "category is archived")
+	}
+	if errors.Is(err, ErrNoRate) {
+		return status.Error(codes.FailedPrecondition, "exchange rate not found")
+	}
 	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
 		return status.Error(codes.DeadlineExceeded, "timeout")
 	}
@@ -176,11 +1109,60 @@ func isValidationErr(err error) bool {
 	msg := err.Error()
 	switch msg {
 	case "amount must be > 0",
+		"amount must not be 0",
+		"invalid transaction kind",
+		"invalid granularity",
+		"invalid bucket",
+		"too many buckets",
+		"compare range is incomplete",
+		"account name is empty",
+		"opening balance is too large",
+		"transfer accounts must differ",
+		"transfer cannot be edited",
+		"invalid ledger account",
+		"invalid frequency",
+		"invalid interval",
+		"start date is required",
+		"end date must be >= start date",
+		"invalid count",
+		"category name is empty",
+		"icon is too long",
+		"category already exists",
+		"category cannot be moved under itself",
+		"cannot merge category into itself",
+		"cannot merge category into its subcategory",
+		"alias is empty",
+		"alias must differ from category",
+		"alias conflicts with category",
+		"category name is an alias",
+		"rule has no conditions",
+		"regex is too long",
+		"invalid regex",
+		"invalid amount range",
+		"rule category is empty",
+		"too many tags",
+		"tag is empty",
+		"tag is too long",
+		"invalid on",
+		"amount is too large",
+		"invalid amount",
 		"transaction category is empty",
 		"date is required",
 		"budget category is empty",
 		"limit must be > 0",
+		"limit is too large",
+		"invalid limit",
+		"invalid budget period",
+		"invalid start day",
+		"invalid timezone",
+		"invalid enforcement mode",
+		"invalid warning threshold",
+		"invalid currency",
 		"from must be <= to",
+		"min_amount must be <= max_amount",
+		"invalid sort",
+		"invalid page size",
+		"invalid page token",
 		"invalid date",
 		"invalid from",
 		"invalid to":
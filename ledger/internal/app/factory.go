@@ -10,6 +10,7 @@ import (
 
 	_ "github.com/jackc/pgx/v5/stdlib"
 
+	"final/ledger/internal/journal"
 	"final/ledger/internal/repository/pg"
 	"final/ledger/internal/service"
 )
@@ -36,11 +37,43 @@ func FromEnv() Config {
 }
 
 func Build(ctx context.Context) (service.Service, func() error, error) {
-	cfg := FromEnv()
+	db, err := openDB(ctx, FromEnv())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	txm := pg.NewTxManager(db)
+	jRepo := pg.NewJournalRepo(db)
+	bRepo := pg.NewBudgetRepo(db)
+	eRepo := journal.NewExpenseRepo(pg.NewExpenseRepo(db), jRepo, txm)
+	aRepo := journal.NewAccountRepo(pg.NewAccountRepo(db), jRepo, txm)
+	cRepo := journal.NewCategoryRepo(pg.NewCategoryRepo(db), jRepo, txm)
+
+	svc := service.New(bRepo, eRepo, aRepo, jRepo, pg.NewRecurringRepo(db), cRepo, pg.NewRuleRepo(db), pg.NewSettingsRepo(db), pg.NewRateRepo(db), txm)
+
+	// Планировщик повторяющихся транзакций; RECURRING_INTERVAL=0 отключает его
+	// (например, если он запущен только в одной из реплик).
+	schedCtx, stopSched := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if every := getenvDuration("RECURRING_INTERVAL", time.Minute); every > 0 {
+			svc.RunScheduler(schedCtx, every)
+		}
+	}()
+	closeFn := func() error {
+		stopSched()
+		<-done
+		return db.Close()
+	}
+
+	return svc, closeFn, nil
+}
 
+func openDB(ctx context.Context, cfg Config) (*sql.DB, error) {
 	db, err := sql.Open("pgx", cfg.DSN)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	db.SetMaxOpenConns(getenvInt("DB_MAX_OPEN_CONNS", 10))
@@ -49,16 +82,9 @@ func Build(ctx context.Context) (service.Service, func() error, error) {
 
 	if err := db.PingContext(ctx); err != nil {
 		_ = db.Close()
-		return nil, nil, err
+		return nil, err
 	}
-
-	bRepo := pg.NewBudgetRepo(db)
-	eRepo := pg.NewExpenseRepo(db)
-
-	svc := service.New(bRepo, eRepo)
-	closeFn := func() error { return db.Close() }
-
-	return svc, closeFn, nil
+	return db, nil
 }
 
 func getenv(key, def string) string {
@@ -68,6 +94,15 @@ func getenv(key, def string) string {
 	return def
 }
 
+func getenvDuration(key string, def time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return def
+}
+
 func getenvInt(key string, def int) int {
 	if v := os.Getenv(key); v != "" {
 		if x, err := strconv.Atoi(v); err == nil {
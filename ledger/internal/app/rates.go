@@ -0,0 +1,39 @@
+package app
+
+import (
+	"context"
+	"os"
+
+	"final/ledger/internal/rates"
+	"final/ledger/internal/repository/pg"
+)
+
+// LoadRates загружает курсы из XML-выгрузки ЦБ или ЕЦБ в exchange_rates.
+// Повторная загрузка того же файла перезаписывает курсы на те же даты.
+func LoadRates(ctx context.Context, path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	parsed, err := rates.Parse(f)
+	if err != nil {
+		return 0, err
+	}
+
+	db, err := openDB(ctx, FromEnv())
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	repo := pg.NewRateRepo(db)
+	err = pg.NewTxManager(db).WithinTx(ctx, func(ctx context.Context) error {
+		return repo.Upsert(ctx, parsed)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(parsed), nil
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// DefaultAccountName — счёт, который создаётся для транзакций без явного счёта.
+const DefaultAccountName = "main"
+
+// Account — место, где лежат деньги: карта, наличные, накопительный счёт.
+// Баланс не хранится, а считается по транзакциям от OpeningBalance.
+type Account struct {
+	ID             int
+	UserID         string
+	Name           string
+	Currency       string
+	OpeningBalance Money
+	// IsDefault — счёт для транзакций, в которых счёт не указан.
+	IsDefault bool
+}
+
+func (a Account) Validate() error {
+	if strings.TrimSpace(a.Name) == "" {
+		return errors.New("account name is empty")
+	}
+	if a.Currency != "" && !IsValidCurrency(NormalizeCurrency(a.Currency)) {
+		return errors.New("invalid currency")
+	}
+	if a.OpeningBalance > MaxAmount || a.OpeningBalance < -MaxAmount {
+		return errors.New("opening balance is too large")
+	}
+	return nil
+}
+
+type AccountBalance struct {
+	Account Account
+	// Balance — в валюте счёта.
+	Balance Money
+}
+
+type Balances struct {
+	Items []AccountBalance
+	// Total — сумма балансов в базовой валюте Currency.
+	Total    Money
+	Currency string
+}
+
+// Transfer перемещает деньги между счетами пользователя. ToAmount задаётся,
+// если банк зачислил сумму по своему курсу; иначе она пересчитывается по
+// курсу на дату перевода.
+type Transfer struct {
+	FromAccountID int
+	ToAccountID   int
+	Amount        Money
+	ToAmount      *Money
+	Date          time.Time
+	Description   string
+}
+
+func (t Transfer) Validate() error {
+	if t.FromAccountID == t.ToAccountID {
+		return errors.New("transfer accounts must differ")
+	}
+	if t.Amount <= 0 || (t.ToAmount != nil && *t.ToAmount <= 0) {
+		return errors.New("amount must be > 0")
+	}
+	if t.Amount > MaxAmount || (t.ToAmount != nil && *t.ToAmount > MaxAmount) {
+		return errors.New("amount is too large")
+	}
+	if t.Date.IsZero() {
+		return errors.New("date is required")
+	}
+	return nil
+}
+
+// BalanceEffect — изменение баланса счёта от транзакции в её валюте.
+func (t Transaction) BalanceEffect() Money {
+	switch t.Kind {
+	case "", KindExpense:
+		return -t.Amount
+	default:
+		return t.Amount
+	}
+}
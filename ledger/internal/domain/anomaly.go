@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	AnomalyTransaction = "transaction"
+	AnomalyWeekly      = "weekly"
+)
+
+const (
+	// AnomalyThreshold — порог модифицированной z-оценки (Iglewicz, Hoaglin).
+	AnomalyThreshold = 3.5
+	// AnomalyMinSamples — сколько наблюдений нужно в истории, чтобы ей верить:
+	// трат категории для транзакции, недель с тратами для недели.
+	AnomalyMinSamples = 5
+	// AnomalyHistoryWeeks — длина истории перед оцениваемой датой.
+	AnomalyHistoryWeeks = 12
+)
+
+// Anomaly — необычная трата. У транзакции From и To — её дата, у недели —
+// понедельник и воскресенье. Typical — медиана истории; Score больше нуля,
+// если потрачено больше обычного, и меньше нуля, если меньше.
+type Anomaly struct {
+	Kind          string
+	Category      string
+	TransactionID int
+	From          time.Time
+	To            time.Time
+	Currency      string
+	Amount        Money
+	Typical       Money
+	Score         float64
+}
+
+// RobustScore возвращает модифицированную z-оценку x относительно истории:
+// 0.6745·(x − медиана)/MAD. При MAD = 0 вместо MAD берётся среднее
+// абсолютное отклонение. ok = false, если история короче AnomalyMinSamples
+// или все значения в ней равны.
+func RobustScore(x Money, history []Money) (score float64, typical Money, ok bool) {
+	if len(history) < AnomalyMinSamples {
+		return 0, 0, false
+	}
+	xs := make([]float64, len(history))
+	for i, m := range history {
+		xs[i] = float64(m)
+	}
+	med := median(xs)
+	dev := make([]float64, len(xs))
+	var sum float64
+	for i, v := range xs {
+		dev[i] = math.Abs(v - med)
+		sum += dev[i]
+	}
+	typical = Money(math.Round(med))
+	if mad := median(dev); mad > 0 {
+		return 0.6745 * (float64(x) - med) / mad, typical, true
+	}
+	if mean := sum / float64(len(dev)); mean > 0 {
+		return (float64(x) - med) / (1.253314 * mean), typical, true
+	}
+	return 0, typical, false
+}
+
+// median сортирует xs.
+func median(xs []float64) float64 {
+	sort.Float64s(xs)
+	n := len(xs)
+	if n%2 == 1 {
+		return xs[n/2]
+	}
+	return (xs[n/2-1] + xs[n/2]) / 2
+}
+
+// AnomalyHistoryFrom возвращает начало истории, нужной FindAnomalies для
+// периода с from: AnomalyHistoryWeeks недель до понедельника недели from.
+func AnomalyHistoryFrom(from time.Time) time.Time {
+	start, _ := bucketRange(dayOf(from), BucketWeek)
+	return start.AddDate(0, 0, -7*AnomalyHistoryWeeks)
+}
+
+// CheckTransaction сравнивает расход с расходами той же категории из
+// history; аномалия — расход заметно больше обычного.
+func CheckTransaction(t Transaction, history []Money) (Anomaly, bool) {
+	if t.Kind != KindExpense {
+		return Anomaly{}, false
+	}
+	score, typical, ok := RobustScore(t.Amount, history)
+	if !ok || score <= AnomalyThreshold {
+		return Anomaly{}, false
+	}
+	day := dayOf(t.Date)
+	return Anomaly{
+		Kind:          AnomalyTransaction,
+		Category:      t.Category,
+		TransactionID: t.ID,
+		From:          day,
+		To:            day,
+		Currency:      t.Currency,
+		Amount:        t.Amount,
+		Typical:       typical,
+		Score:         score,
+	}, true
+}
+
+// FindAnomalies ищет аномалии за [from, to] в расходах и возвратах txs с
+// суммами в одной валюте; txs должны начинаться с AnomalyHistoryFrom(from).
+// Расход сравнивается с расходами категории за AnomalyHistoryWeeks недель
+// до его даты. Траты категории за неделю (расходы минус возвраты)
+// сравниваются с AnomalyHistoryWeeks предыдущими неделями, включая недели
+// без трат; оцениваются недели с недели from, закончившиеся не позже to.
+// Результат — по убыванию даты, затем по категории.
+func FindAnomalies(txs []Transaction, from, to time.Time) []Anomaly {
+	from, to = dayOf(from), dayOf(to)
+	out := []Anomaly{}
+
+	byCat := map[string][]Transaction{}
+	weekly := map[string]map[time.Time]Money{}
+	for _, t := range txs {
+		if t.Kind == KindExpense {
+			byCat[t.Category] = append(byCat[t.Category], t)
+		}
+		week, _ := bucketRange(dayOf(t.Date), BucketWeek)
+		if weekly[t.Category] == nil {
+			weekly[t.Category] = map[time.Time]Money{}
+		}
+		weekly[t.Category][week] += t.Spending()
+	}
+
+	for _, list := range byCat {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].Date.Before(list[j].Date) })
+		for i, t := range list {
+			day := dayOf(t.Date)
+			if day.Before(from) || day.After(to) {
+				continue
+			}
+			since := day.AddDate(0, 0, -7*AnomalyHistoryWeeks)
+			lo := sort.Search(i, func(k int) bool { return !dayOf(list[k].Date).Before(since) })
+			var history []Money
+			for _, h := range list[lo:i] {
+				if dayOf(h.Date).Before(day) {
+					history = append(history, h.Amount)
+				}
+			}
+			if a, ok := CheckTransaction(t, history); ok {
+				out = append(out, a)
+			}
+		}
+	}
+
+	first, _ := bucketRange(from, BucketWeek)
+	for cat, weeks := range weekly {
+		for week := first; !week.AddDate(0, 0, 6).After(to); week = week.AddDate(0, 0, 7) {
+			history := make([]Money, 0, AnomalyHistoryWeeks)
+			active := 0
+			for i := AnomalyHistoryWeeks; i > 0; i-- {
+				m := weeks[week.AddDate(0, 0, -7*i)]
+				if m != 0 {
+					active++
+				}
+				history = append(history, m)
+			}
+			if active < AnomalyMinSamples {
+				continue
+			}
+			score, typical, ok := RobustScore(weeks[week], history)
+			if !ok || math.Abs(score) <= AnomalyThreshold {
+				continue
+			}
+			out = append(out, Anomaly{
+				Kind:     AnomalyWeekly,
+				Category: cat,
+				From:     week,
+				To:       week.AddDate(0, 0, 6),
+				Currency: currencyOf(txs),
+				Amount:   weeks[week],
+				Typical:  typical,
+				Score:    score,
+			})
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if !a.From.Equal(b.From) {
+			return a.From.After(b.From)
+		}
+		if a.Category != b.Category {
+			return a.Category < b.Category
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.TransactionID < b.TransactionID
+	})
+	return out
+}
+
+func currencyOf(txs []Transaction) string {
+	if len(txs) == 0 {
+		return ""
+	}
+	return txs[0].Currency
+}
+
+func dayOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
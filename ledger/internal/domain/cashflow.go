@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// CashFlowPeriod — доходы, расходы и их разница за период [From, To].
+// Expense — расходы за вычетом возвратов.
+type CashFlowPeriod struct {
+	From    time.Time
+	To      time.Time
+	Income  Money
+	Expense Money
+}
+
+func (p CashFlowPeriod) Net() Money {
+	return p.Income - p.Expense
+}
+
+// SavingsRate — доля сбережений от дохода в процентах; только для отображения.
+func (p CashFlowPeriod) SavingsRate() float64 {
+	return p.Net().Percent(p.Income)
+}
+
+// Add учитывает сумму вида kind; переводы не меняют ни доходы, ни расходы.
+func (p *CashFlowPeriod) Add(kind string, m Money) {
+	switch kind {
+	case KindIncome:
+		p.Income += m
+	case "", KindExpense:
+		p.Expense += m
+	case KindRefund:
+		p.Expense -= m
+	}
+}
+
+type CashFlow struct {
+	Currency string
+	Periods  []CashFlowPeriod
+	Total    CashFlowPeriod
+}
+
+// NewCashFlow размечает [from, to] на периоды той же календарной сетки, что
+// у бюджетов (weekly — с понедельника, monthly/quarterly/yearly — с 1-го числа).
+// Крайние периоды обрезаются по from и to; "fixed" — один период на весь диапазон.
+func NewCashFlow(from, to time.Time, granularity string) (CashFlow, error) {
+	if granularity == "" {
+		granularity = PeriodMonthly
+	}
+	if !IsValidPeriod(granularity) {
+		return CashFlow{}, errors.New("invalid granularity")
+	}
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	if from.After(to) {
+		return CashFlow{}, errors.New("from must be <= to")
+	}
+
+	cf := CashFlow{Total: CashFlowPeriod{From: from, To: to}}
+	grid := Budget{Period: granularity}
+	for day := from; !day.After(to); {
+		_, end, bounded := grid.PeriodRange(day)
+		if !bounded || end.After(to) {
+			end = to
+		}
+		cf.Periods = append(cf.Periods, CashFlowPeriod{From: day, To: end})
+		day = end.AddDate(0, 0, 1)
+	}
+	return cf, nil
+}
+
+// Add относит сумму к периоду, в который попадает её дата; даты вне
+// диапазона отчёта пропускаются.
+func (cf *CashFlow) Add(a DatedAmount, m Money) {
+	day := time.Date(a.Date.Year(), a.Date.Month(), a.Date.Day(), 0, 0, 0, 0, time.UTC)
+	i := sort.Search(len(cf.Periods), func(i int) bool { return !cf.Periods[i].To.Before(day) })
+	if i == len(cf.Periods) || day.Before(cf.Periods[i].From) {
+		return
+	}
+	cf.Periods[i].Add(a.Kind, m)
+	cf.Total.Add(a.Kind, m)
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+)
+
+// Category — узел дерева категорий пользователя. Категории транзакций и
+// бюджетов по-прежнему хранятся строкой (Name); категория, которой нет в
+// дереве, считается корневой без потомков.
+type Category struct {
+	ID     int
+	UserID string
+	Name   string
+	// ParentID — родитель; 0 — корневая категория.
+	ParentID int
+	Icon     string
+	// Archived — категорию нельзя выбрать для новых транзакций, бюджетов и
+	// шаблонов, история остаётся.
+	Archived bool
+}
+
+func (c Category) Validate() error {
+	if NormalizeCategory(c.Name) == "" {
+		return errors.New("category name is empty")
+	}
+	if len(c.Icon) > 64 {
+		return errors.New("icon is too long")
+	}
+	return nil
+}
+
+// CategoryPatch — частичное изменение категории; nil — поле не меняется.
+type CategoryPatch struct {
+	Name     *string
+	ParentID *int
+	Icon     *string
+	Archived *bool
+}
+
+// CategoryTree — дерево категорий одного пользователя.
+type CategoryTree struct {
+	byName   map[string]Category
+	byID     map[int]Category
+	children map[int][]string
+}
+
+func NewCategoryTree(cats []Category) CategoryTree {
+	t := CategoryTree{
+		byName:   make(map[string]Category, len(cats)),
+		byID:     make(map[int]Category, len(cats)),
+		children: map[int][]string{},
+	}
+	for _, c := range cats {
+		t.byName[c.Name] = c
+		t.byID[c.ID] = c
+		if c.ParentID != 0 {
+			t.children[c.ParentID] = append(t.children[c.ParentID], c.Name)
+		}
+	}
+	for _, names := range t.children {
+		sort.Strings(names)
+	}
+	return t
+}
+
+func (t CategoryTree) Get(name string) (Category, bool) {
+	c, ok := t.byName[name]
+	return c, ok
+}
+
+func (t CategoryTree) ByID(id int) (Category, bool) {
+	c, ok := t.byID[id]
+	return c, ok
+}
+
+// Lineage возвращает категорию и всех её предков, от неё к корню.
+func (t CategoryTree) Lineage(name string) []string {
+	out := []string{name}
+	c, ok := t.byName[name]
+	// len(out) ограничивает обход, если в данных всё же оказался цикл.
+	for ok && c.ParentID != 0 && len(out) <= len(t.byID) {
+		if c, ok = t.byID[c.ParentID]; ok {
+			out = append(out, c.Name)
+		}
+	}
+	return out
+}
+
+// Subtree возвращает категорию и всех её потомков.
+func (t CategoryTree) Subtree(name string) []string {
+	out := []string{name}
+	for i := 0; i < len(out) && i <= len(t.byID); i++ {
+		if c, ok := t.byName[out[i]]; ok {
+			out = append(out, t.children[c.ID]...)
+		}
+	}
+	return out
+}
+
+// IsDescendant сообщает, что категория id лежит в поддереве ancestorID (или совпадает с ней).
+func (t CategoryTree) IsDescendant(id, ancestorID int) bool {
+	for i := 0; id != 0 && i <= len(t.byID); i++ {
+		if id == ancestorID {
+			return true
+		}
+		id = t.byID[id].ParentID
+	}
+	return false
+}
+
+// RollUp добавляет суммы категорий ко всем их предкам: сумма родителя
+// включает суммы потомков.
+func (t CategoryTree) RollUp(totals map[string]Money) map[string]Money {
+	out := make(map[string]Money, len(totals))
+	for name, sum := range totals {
+		for _, n := range t.Lineage(name) {
+			out[n] += sum
+		}
+	}
+	return out
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+const (
+	CompareNew         = "new"
+	CompareDisappeared = "disappeared"
+)
+
+// CategoryDelta — траты категории в двух периодах. DeltaPercent имеет
+// смысл только при Previous != 0; Status — new, disappeared или пустой.
+type CategoryDelta struct {
+	Category     string
+	Current      Money
+	Previous     Money
+	Delta        Money
+	DeltaPercent float64
+	Status       string
+}
+
+type Comparison struct {
+	From         time.Time
+	To           time.Time
+	PreviousFrom time.Time
+	PreviousTo   time.Time
+	Currency     string
+	// Items — по убыванию модуля изменения, при равенстве — по имени.
+	Items       []CategoryDelta
+	Total       CategoryDelta
+	New         []string
+	Disappeared []string
+}
+
+// PreviousRange возвращает период той же длины перед [from, to]. Если
+// [from, to] — целые календарные месяцы, предыдущий период — столько же
+// предыдущих месяцев: для декабря это ноябрь, а не 31 день до 1 декабря.
+func PreviousRange(from, to time.Time) (time.Time, time.Time, error) {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	if from.After(to) {
+		return time.Time{}, time.Time{}, errors.New("from must be <= to")
+	}
+	if from.Day() == 1 && to.AddDate(0, 0, 1).Day() == 1 {
+		months := (to.Year()-from.Year())*12 + int(to.Month()-from.Month()) + 1
+		return from.AddDate(0, -months, 0), from.AddDate(0, 0, -1), nil
+	}
+	days := daysIn(from, to)
+	return from.AddDate(0, 0, -days), from.AddDate(0, 0, -1), nil
+}
+
+// Compare сопоставляет траты по категориям за текущий и предыдущий периоды.
+// current и previous — траты самих категорий без подкатегорий. В Items сумма
+// родителя включает подкатегории, как в сводном отчёте, а Total считается по
+// исходным суммам, чтобы трата подкатегории не вошла в него дважды.
+func Compare(tree CategoryTree, current, previous map[string]Money) Comparison {
+	var c Comparison
+	for _, m := range current {
+		c.Total.Current += m
+	}
+	for _, m := range previous {
+		c.Total.Previous += m
+	}
+	current, previous = tree.RollUp(current), tree.RollUp(previous)
+
+	names := make(map[string]bool, len(current)+len(previous))
+	for name := range current {
+		names[name] = true
+	}
+	for name := range previous {
+		names[name] = true
+	}
+	for name := range names {
+		d := newDelta(name, current[name], previous[name])
+		switch {
+		case current[name] != 0 && previous[name] == 0:
+			d.Status = CompareNew
+			c.New = append(c.New, name)
+		case current[name] == 0 && previous[name] != 0:
+			d.Status = CompareDisappeared
+			c.Disappeared = append(c.Disappeared, name)
+		}
+		c.Items = append(c.Items, d)
+	}
+	c.Total = newDelta("", c.Total.Current, c.Total.Previous)
+
+	sort.Slice(c.Items, func(i, j int) bool {
+		a, b := c.Items[i].Delta, c.Items[j].Delta
+		if a < 0 {
+			a = -a
+		}
+		if b < 0 {
+			b = -b
+		}
+		if a != b {
+			return a > b
+		}
+		return c.Items[i].Category < c.Items[j].Category
+	})
+	sort.Strings(c.New)
+	sort.Strings(c.Disappeared)
+	return c
+}
+
+func newDelta(name string, current, previous Money) CategoryDelta {
+	d := CategoryDelta{Category: name, Current: current, Previous: previous, Delta: current - previous}
+	if previous != 0 {
+		d.DeltaPercent = d.Delta.Percent(previous)
+	}
+	return d
+}
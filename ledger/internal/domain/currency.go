@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"errors"
+	"math/big"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultCurrency — базовая валюта пользователя, пока он не выбрал другую.
+const DefaultCurrency = "RUB"
+
+func NormalizeCurrency(s string) string {
+	return strings.ToUpper(strings.TrimSpace(s))
+}
+
+// IsValidCurrency проверяет, что код похож на ISO 4217: три латинские буквы.
+func IsValidCurrency(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'A' || s[i] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+type UserSettings struct {
+	UserID       string
+	BaseCurrency string
+}
+
+func (s UserSettings) Validate() error {
+	if !IsValidCurrency(NormalizeCurrency(s.BaseCurrency)) {
+		return errors.New("invalid currency")
+	}
+	return nil
+}
+
+// ExchangeRate — сколько единиц Quote стоит одна единица Base на дату Date.
+type ExchangeRate struct {
+	Base  string
+	Quote string
+	Date  time.Time
+	Rate  *big.Rat
+}
+
+// DatedAmount — сумма трат в одной валюте за одну дату; курс берётся на эту дату.
+type DatedAmount struct {
+	Currency string
+	Date     time.Time
+	Amount   Money
+
+	// Kind заполняется только в выборке для отчёта о движении денег.
+	Kind string
+}
+
+// Convert переводит сумму по курсу с округлением до копейки (половина — от нуля).
+func (m Money) Convert(rate *big.Rat) Money {
+	v := new(big.Rat).Mul(new(big.Rat).SetInt64(int64(m)), rate)
+	num, den := v.Num(), v.Denom()
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	// |r| * 2 >= den — округляем от нуля.
+	if r.Sign() != 0 && new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).Cmp(den) >= 0 {
+		if num.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return Money(q.Int64())
+}
+
+// RateLookup возвращает курс base→quote (сколько quote за одну base) на нужную дату.
+type RateLookup func(base, quote string) (*big.Rat, bool, error)
+
+// pivotCurrencies — через какие валюты строится кросс-курс: ЦБ публикует
+// курсы к рублю, ЕЦБ — к евро.
+var pivotCurrencies = []string{"RUB", "EUR", "USD"}
+
+// WithPivots дополняет валюты опорными: их курсы нужны FindRate для кросс-курсов.
+func WithPivots(currencies []string) []string {
+	out := append([]string{}, currencies...)
+	for _, p := range pivotCurrencies {
+		if !slices.Contains(out, p) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// RateTable — курсы, загруженные заранее: Rate отвечает так же, как
+// RateRepo.Rate, но без запросов к БД.
+type RateTable map[[2]string][]ExchangeRate
+
+// NewRateTable раскладывает курсы по парам в порядке дат.
+func NewRateTable(rates []ExchangeRate) RateTable {
+	t := RateTable{}
+	for _, r := range rates {
+		key := [2]string{r.Base, r.Quote}
+		t[key] = append(t[key], r)
+	}
+	for _, list := range t {
+		sort.Slice(list, func(i, j int) bool { return list[i].Date.Before(list[j].Date) })
+	}
+	return t
+}
+
+// Rate возвращает последний курс base→quote на дату on или раньше.
+func (t RateTable) Rate(base, quote string, on time.Time) (*big.Rat, bool) {
+	list := t[[2]string{base, quote}]
+	day := time.Date(on.Year(), on.Month(), on.Day(), 0, 0, 0, 0, time.UTC)
+	i := sort.Search(len(list), func(i int) bool { return list[i].Date.After(day) })
+	if i == 0 {
+		return nil, false
+	}
+	return list[i-1].Rate, true
+}
+
+// FindRate ищет курс from→to: прямой, обратный или кросс-курс через опорную валюту.
+func FindRate(from, to string, lookup RateLookup) (*big.Rat, bool, error) {
+	if from == to {
+		return big.NewRat(1, 1), true, nil
+	}
+	if r, ok, err := pairRate(from, to, lookup); err != nil || ok {
+		return r, ok, err
+	}
+	for _, p := range pivotCurrencies {
+		if p == from || p == to {
+			continue
+		}
+		a, ok, err := pairRate(from, p, lookup)
+		if err != nil {
+			return nil, false, err
+		}
+		if !ok {
+			continue
+		}
+		b, ok, err := pairRate(p, to, lookup)
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			return new(big.Rat).Mul(a, b), true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func pairRate(from, to string, lookup RateLookup) (*big.Rat, bool, error) {
+	if r, ok, err := lookup(from, to); err != nil || ok {
+		return r, ok, err
+	}
+	r, ok, err := lookup(to, from)
+	if err != nil || !ok || r.Sign() == 0 {
+		return nil, false, err
+	}
+	return new(big.Rat).Inv(r), true, nil
+}
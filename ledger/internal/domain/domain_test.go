@@ -1,6 +1,10 @@
 package domain
 
 import (
+	"encoding/json"
+	"math/big"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
@@ -63,6 +67,31 @@ func TestTransactionValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:    "income",
+			tx:      Transaction{Kind: KindIncome, Amount: 10, Category: "зарплата", Date: time.Now()},
+			wantErr: false,
+		},
+		{
+			name:    "negative_refund",
+			tx:      Transaction{Kind: KindRefund, Amount: -10, Category: "еда", Date: time.Now()},
+			wantErr: true,
+		},
+		{
+			name:    "outgoing_transfer",
+			tx:      Transaction{Kind: KindTransfer, Amount: -10, Category: "накопления", Date: time.Now()},
+			wantErr: false,
+		},
+		{
+			name:    "zero_transfer",
+			tx:      Transaction{Kind: KindTransfer, Amount: 0, Category: "накопления", Date: time.Now()},
+			wantErr: true,
+		},
+		{
+			name:    "unknown_kind",
+			tx:      Transaction{Kind: "gift", Amount: 10, Category: "еда", Date: time.Now()},
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range cases {
@@ -92,6 +121,10 @@ func TestBudgetValidate(t *testing.T) {
 		{name: "zero_limit", b: Budget{Category: "еда", Limit: 0, Period: "fixed"}, wantErr: true},
 		{name: "negative_limit", b: Budget{Category: "еда", Limit: -1, Period: "fixed"}, wantErr: true},
 		{name: "empty_category", b: Budget{Category: "   ", Limit: 10, Period: "fixed"}, wantErr: true},
+		{name: "monthly", b: Budget{Category: "еда", Limit: 10, Period: "monthly", StartDay: 25, Timezone: "Europe/Moscow"}, wantErr: false},
+		{name: "bad_period", b: Budget{Category: "еда", Limit: 10, Period: "daily"}, wantErr: true},
+		{name: "bad_weekly_start_day", b: Budget{Category: "еда", Limit: 10, Period: "weekly", StartDay: 8}, wantErr: true},
+		{name: "bad_timezone", b: Budget{Category: "еда", Limit: 10, Period: "monthly", Timezone: "Mars/Olympus"}, wantErr: true},
 	}
 
 	for _, tc := range cases {
@@ -108,3 +141,836 @@ func TestBudgetValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestBudgetPeriodRange(t *testing.T) {
+	t.Parallel()
+
+	d := func(s string) time.Time {
+		v, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			t.Fatalf("bad date %q", s)
+		}
+		return v
+	}
+
+	cases := []struct {
+		name     string
+		b        Budget
+		at       time.Time
+		from, to string
+		bounded  bool
+	}{
+		{name: "fixed", b: Budget{Period: "fixed"}, at: d("2025-12-19"), bounded: false},
+		{name: "monthly_default", b: Budget{Period: "monthly"}, at: d("2025-12-19"), from: "2025-12-01", to: "2025-12-31", bounded: true},
+		{name: "monthly_start_day_before", b: Budget{Period: "monthly", StartDay: 25}, at: d("2025-12-19"), from: "2025-11-25", to: "2025-12-24", bounded: true},
+		{name: "monthly_start_day_after", b: Budget{Period: "monthly", StartDay: 10}, at: d("2025-12-19"), from: "2025-12-10", to: "2026-01-09", bounded: true},
+		{name: "monthly_clamped", b: Budget{Period: "monthly", StartDay: 31}, at: d("2026-02-28"), from: "2026-02-28", to: "2026-03-30", bounded: true},
+		{name: "weekly_monday", b: Budget{Period: "weekly", StartDay: 1}, at: d("2025-12-19"), from: "2025-12-15", to: "2025-12-21", bounded: true},
+		{name: "weekly_sunday", b: Budget{Period: "weekly", StartDay: 7}, at: d("2025-12-19"), from: "2025-12-14", to: "2025-12-20", bounded: true},
+		{name: "quarterly", b: Budget{Period: "quarterly"}, at: d("2025-12-19"), from: "2025-10-01", to: "2025-12-31", bounded: true},
+		{name: "quarterly_crosses_year", b: Budget{Period: "quarterly", StartDay: 20}, at: d("2026-01-05"), from: "2025-10-20", to: "2026-01-19", bounded: true},
+		{name: "yearly", b: Budget{Period: "yearly"}, at: d("2025-12-19"), from: "2025-01-01", to: "2025-12-31", bounded: true},
+		{
+			// Транзакция хранится под датой 30 ноября, в ноябре её и учитываем.
+			name:    "timezone_keeps_stored_day",
+			b:       Budget{Period: "monthly", Timezone: "Europe/Moscow"},
+			at:      time.Date(2025, 11, 30, 22, 0, 0, 0, time.UTC),
+			from:    "2025-11-01",
+			to:      "2025-11-30",
+			bounded: true,
+		},
+		{
+			name:    "utc_midnight_west_timezone",
+			b:       Budget{Period: "monthly", Timezone: "America/New_York"},
+			at:      d("2025-12-01"),
+			from:    "2025-12-01",
+			to:      "2025-12-31",
+			bounded: true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			from, to, bounded := tc.b.PeriodRange(tc.at)
+			if bounded != tc.bounded {
+				t.Fatalf("expected bounded=%v, got %v", tc.bounded, bounded)
+			}
+			if !bounded {
+				return
+			}
+			if got := from.Format("2006-01-02"); got != tc.from {
+				t.Fatalf("expected from %s, got %s", tc.from, got)
+			}
+			if got := to.Format("2006-01-02"); got != tc.to {
+				t.Fatalf("expected to %s, got %s", tc.to, got)
+			}
+		})
+	}
+
+	// Часовой пояс бюджета задаёт только «сегодня».
+	if got := (Budget{Timezone: "Europe/Moscow"}).Day(time.Date(2025, 11, 30, 22, 0, 0, 0, time.UTC)); got.Format("2006-01-02") != "2025-12-01" {
+		t.Fatalf("expected today 2025-12-01, got %s", got.Format("2006-01-02"))
+	}
+}
+
+func TestBudgetEvaluate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		b          Budget
+		spent      Money
+		amount     Money
+		wantReject bool
+		wantTh     []int
+	}{
+		{name: "hard_under", b: Budget{Limit: 100, Enforcement: "hard"}, spent: 10, amount: 20},
+		{name: "hard_over", b: Budget{Limit: 100, Enforcement: "hard"}, spent: 90, amount: 20, wantReject: true},
+		{name: "default_is_hard", b: Budget{Limit: 100}, spent: 90, amount: 20, wantReject: true},
+		{name: "hard_warns_below_limit", b: Budget{Limit: 100, Enforcement: "hard", WarnThresholds: []int{80, 100}}, spent: 70, amount: 15, wantTh: []int{80}},
+		{name: "soft_over_stores", b: Budget{Limit: 100, Enforcement: "soft", WarnThresholds: []int{80, 100}}, spent: 70, amount: 40, wantTh: []int{80, 100}},
+		{name: "soft_already_crossed", b: Budget{Limit: 100, Enforcement: "soft", WarnThresholds: []int{80}}, spent: 85, amount: 5},
+		{name: "soft_over_without_thresholds", b: Budget{Limit: 100, Enforcement: "soft"}, spent: 120, amount: 5, wantTh: []int{100}},
+		{name: "off", b: Budget{Limit: 100, Enforcement: "off", WarnThresholds: []int{80}}, spent: 90, amount: 500},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			warns, reject := tc.b.Evaluate(tc.spent, tc.amount)
+			if reject != tc.wantReject {
+				t.Fatalf("expected reject=%v, got %v", tc.wantReject, reject)
+			}
+			if len(warns) != len(tc.wantTh) {
+				t.Fatalf("expected %d warnings, got %+v", len(tc.wantTh), warns)
+			}
+			for i, w := range warns {
+				if w.Threshold != tc.wantTh[i] {
+					t.Fatalf("expected threshold %d, got %d", tc.wantTh[i], w.Threshold)
+				}
+			}
+		})
+	}
+}
+
+func TestTransactionFilterValidate(t *testing.T) {
+	t.Parallel()
+
+	lo, hi := Money(10), Money(5)
+	token := CursorAfter(SortDateDesc, Transaction{ID: 3, Date: time.Now()}).Encode()
+
+	cases := []struct {
+		name    string
+		f       TransactionFilter
+		wantErr bool
+	}{
+		{name: "empty", f: TransactionFilter{}},
+		{name: "from_after_to", f: TransactionFilter{From: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}, wantErr: true},
+		{name: "min_over_max", f: TransactionFilter{MinAmount: &lo, MaxAmount: &hi}, wantErr: true},
+		{name: "bad_sort", f: TransactionFilter{Sort: "category"}, wantErr: true},
+		{name: "page_too_big", f: TransactionFilter{PageSize: MaxPageSize + 1}, wantErr: true},
+		{name: "token_ok", f: TransactionFilter{PageToken: token}},
+		{name: "token_other_sort", f: TransactionFilter{PageToken: token, Sort: SortAmountDesc}, wantErr: true},
+		{name: "token_garbage", f: TransactionFilter{PageToken: "%%%"}, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := tc.f.Validate()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("wantErr=%v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		in      string
+		want    Money
+		str     string
+		wantErr bool
+	}{
+		{in: "1500", want: 150000, str: "1500"},
+		{in: "0.1", want: 10, str: "0.1"},
+		{in: "12.30", want: 1230, str: "12.3"},
+		{in: "0.05", want: 5, str: "0.05"},
+		{in: "-2.5", want: -250, str: "-2.5"},
+		{in: ".5", want: 50, str: "0.5"},
+		{in: "1.500", want: 150, str: "1.5"},
+		{in: "1.005", wantErr: true},
+		{in: "1e3", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "1.", wantErr: true},
+		{in: "abc", wantErr: true},
+		{in: "99999999999999999999", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseMoney(tc.in)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("wantErr=%v, got %v", tc.wantErr, err)
+			}
+			if tc.wantErr {
+				return
+			}
+			if got != tc.want || got.String() != tc.str {
+				t.Fatalf("expected %d (%s), got %d (%s)", tc.want, tc.str, got, got.String())
+			}
+		})
+	}
+}
+
+func TestMoneyExactBudgetCheck(t *testing.T) {
+	t.Parallel()
+
+	a, _ := ParseMoney("0.1")
+	b, _ := ParseMoney("0.2")
+	limit, _ := ParseMoney("0.3")
+
+	bud := Budget{Category: "еда", Limit: limit, WarnThresholds: []int{100}}
+	warns, reject := bud.Evaluate(a, b)
+	if reject {
+		t.Fatalf("0.1 + 0.2 must fit into 0.3")
+	}
+	if len(warns) != 1 || warns[0].Exceeded || warns[0].Spent != limit {
+		t.Fatalf("expected reaching 100%% without exceeding, got %+v", warns)
+	}
+
+	var m Money
+	if err := json.Unmarshal([]byte(`"19.99"`), &m); err != nil || m != 1999 {
+		t.Fatalf("unmarshal string: %d %v", m, err)
+	}
+	if err := json.Unmarshal([]byte(`19.9`), &m); err != nil || m != 1990 {
+		t.Fatalf("unmarshal number: %d %v", m, err)
+	}
+	out, _ := json.Marshal(struct{ A Money }{A: 1999})
+	if string(out) != `{"A":19.99}` {
+		t.Fatalf("unexpected json: %s", out)
+	}
+}
+
+func TestFindRateAndConvert(t *testing.T) {
+	t.Parallel()
+
+	rates := map[[2]string]*big.Rat{
+		{"USD", "RUB"}: big.NewRat(80, 1),
+		{"EUR", "RUB"}: big.NewRat(90, 1),
+	}
+	lookup := func(base, quote string) (*big.Rat, bool, error) {
+		r, ok := rates[[2]string{base, quote}]
+		return r, ok, nil
+	}
+
+	cases := []struct {
+		from, to string
+		amount   Money
+		want     Money
+	}{
+		{"USD", "RUB", 1050, 84000},
+		{"RUB", "USD", 100, 1},   // 1/80 руб. → 0.0125 → 0.01
+		{"RUB", "USD", 4000, 50}, // ровно 0.5
+		{"EUR", "USD", 800, 900}, // кросс через RUB
+		{"USD", "USD", 123, 123},
+	}
+	for _, tc := range cases {
+		r, ok, err := FindRate(tc.from, tc.to, lookup)
+		if err != nil || !ok {
+			t.Fatalf("%s→%s: rate not found: %v", tc.from, tc.to, err)
+		}
+		if got := tc.amount.Convert(r); got != tc.want {
+			t.Fatalf("%s→%s: expected %s, got %s", tc.from, tc.to, tc.want, got)
+		}
+	}
+
+	if _, ok, _ := FindRate("GBP", "RUB", lookup); ok {
+		t.Fatalf("expected no rate for GBP")
+	}
+	if got := Money(-150).Convert(big.NewRat(1, 2)); got != -75 {
+		t.Fatalf("expected -0.75, got %s", got)
+	}
+	if got := Money(-1).Convert(big.NewRat(1, 2)); got != -1 {
+		t.Fatalf("half must round away from zero, got %s", got)
+	}
+}
+
+func TestRateTable(t *testing.T) {
+	t.Parallel()
+
+	day := func(d int) time.Time { return time.Date(2025, 12, d, 0, 0, 0, 0, time.UTC) }
+	table := NewRateTable([]ExchangeRate{
+		{Base: "EUR", Quote: "RUB", Date: day(15), Rate: big.NewRat(90, 1)},
+		{Base: "EUR", Quote: "RUB", Date: day(1), Rate: big.NewRat(100, 1)},
+	})
+
+	cases := []struct {
+		on   time.Time
+		want *big.Rat
+	}{
+		{day(1), big.NewRat(100, 1)},
+		{day(14).Add(23 * time.Hour), big.NewRat(100, 1)},
+		{day(15), big.NewRat(90, 1)},
+		{day(31), big.NewRat(90, 1)},
+	}
+	for _, tc := range cases {
+		r, ok := table.Rate("EUR", "RUB", tc.on)
+		if !ok || r.Cmp(tc.want) != 0 {
+			t.Fatalf("%s: expected %s, got %v", tc.on, tc.want.RatString(), r)
+		}
+	}
+	if _, ok := table.Rate("EUR", "RUB", day(1).Add(-time.Hour)); ok {
+		t.Fatal("expected no rate before the first one")
+	}
+	if _, ok := table.Rate("RUB", "EUR", day(15)); ok {
+		t.Fatal("table must not invert rates itself")
+	}
+}
+
+func TestNewCashFlow(t *testing.T) {
+	t.Parallel()
+
+	day := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
+
+	cf, err := NewCashFlow(day(1, 15), day(3, 10), PeriodMonthly)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	if len(cf.Periods) != 3 || !cf.Periods[0].From.Equal(day(1, 15)) || !cf.Periods[0].To.Equal(day(1, 31)) ||
+		!cf.Periods[1].From.Equal(day(2, 1)) || !cf.Periods[2].To.Equal(day(3, 10)) {
+		t.Fatalf("unexpected periods: %+v", cf.Periods)
+	}
+
+	cf.Add(DatedAmount{Kind: KindIncome, Date: day(2, 5)}, 1000)
+	cf.Add(DatedAmount{Kind: KindExpense, Date: day(2, 6)}, 400)
+	cf.Add(DatedAmount{Kind: KindRefund, Date: day(2, 7)}, 100)
+	cf.Add(DatedAmount{Kind: KindExpense, Date: day(3, 11)}, 999)
+
+	feb := cf.Periods[1]
+	if feb.Income != 1000 || feb.Expense != 300 || feb.Net() != 700 || feb.SavingsRate() != 70 {
+		t.Fatalf("unexpected february: %+v", feb)
+	}
+	if cf.Total.Net() != 700 {
+		t.Fatalf("amounts outside the range must be skipped, got %+v", cf.Total)
+	}
+
+	if _, err := NewCashFlow(day(1, 1), day(1, 2), "daily"); err == nil {
+		t.Fatalf("expected invalid granularity")
+	}
+	if cf, _ := NewCashFlow(day(1, 1), day(12, 31), PeriodFixed); len(cf.Periods) != 1 {
+		t.Fatalf("fixed must produce one period, got %d", len(cf.Periods))
+	}
+}
+
+func TestJournalEntry(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		tx      Transaction
+		counter string
+		asset   Money
+	}{
+		{Transaction{Kind: KindExpense, AccountID: 1, Amount: 300, Category: "еда"}, "expenses:еда", -300},
+		{Transaction{Kind: KindRefund, AccountID: 1, Amount: 100, Category: "еда"}, "expenses:еда", 100},
+		{Transaction{Kind: KindIncome, AccountID: 2, Amount: 900, Category: "зарплата"}, "income:зарплата", 900},
+		{Transaction{Kind: KindTransfer, AccountID: 2, Amount: -50, Category: "transfer"}, EquityTransfers, -50},
+	}
+	for _, tc := range cases {
+		tc.tx.Currency, tc.tx.Date = "RUB", date
+		e := EntryFor(tc.tx)
+		if err := e.Validate(); err != nil {
+			t.Fatalf("%s: %v", tc.tx.Kind, err)
+		}
+		if e.Postings[0].Account != AssetAccount(tc.tx.AccountID) || e.Postings[0].Amount != tc.asset ||
+			e.Postings[1].Account != tc.counter || e.Postings[1].Amount != -tc.asset {
+			t.Fatalf("%s: unexpected postings %+v", tc.tx.Kind, e.Postings)
+		}
+		r := e.Reversal()
+		if r.Postings[0].Amount != -tc.asset || e.Postings[0].Amount != tc.asset {
+			t.Fatalf("%s: reversal must negate a copy, got %+v", tc.tx.Kind, r.Postings)
+		}
+	}
+
+	unbalanced := JournalEntry{Postings: []Posting{
+		{Account: "assets:1", Currency: "RUB", Amount: 100},
+		{Account: "expenses:еда", Currency: "EUR", Amount: -100},
+	}}
+	if err := unbalanced.Validate(); err == nil || err.Error() != "unbalanced journal entry" {
+		t.Fatalf("expected unbalanced journal entry, got %v", err)
+	}
+
+	if _, ok := OpeningEntry(Account{ID: 1, Currency: "RUB"}, date); ok {
+		t.Fatalf("zero opening balance must not be journaled")
+	}
+
+	tb := TrialBalance{Lines: []TrialBalanceLine{
+		{Account: "assets:1", Currency: "RUB", Debit: 500, Credit: 200},
+		{Account: "expenses:еда", Currency: "RUB", Debit: 200},
+		{Account: "income:зарплата", Currency: "RUB", Credit: 500},
+	}}
+	if !tb.Balanced() {
+		t.Fatalf("expected balanced trial balance")
+	}
+	tb.Lines[1].Debit = 199
+	if tb.Balanced() {
+		t.Fatalf("expected unbalanced trial balance")
+	}
+
+	for _, code := range []string{"assets:x", "assets:0", "cash:1", "expenses:", "income"} {
+		if _, _, err := ParseLedgerAccount(code); err == nil {
+			t.Fatalf("%q: expected invalid ledger account", code)
+		}
+	}
+}
+
+func TestRecurringOccurrences(t *testing.T) {
+	t.Parallel()
+
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	dates := func(ts []time.Time) string {
+		out := make([]string, len(ts))
+		for i, d := range ts {
+			out[i] = d.Format("2006-01-02")
+		}
+		return strings.Join(out, ",")
+	}
+
+	cases := []struct {
+		name string
+		r    Recurring
+		from time.Time
+		want string
+	}{
+		{"month_end_clamped", Recurring{Frequency: FreqMonthly, Interval: 1, StartDate: day(2024, 1, 31)}, day(2024, 1, 1),
+			"2024-01-31,2024-02-29,2024-03-31,2024-04-30"},
+		{"quarterly_from_middle", Recurring{Frequency: FreqMonthly, Interval: 3, StartDate: day(2025, 1, 15)}, day(2025, 5, 1),
+			"2025-07-15,2025-10-15,2026-01-15,2026-04-15"},
+		{"weekly_until_end", Recurring{Frequency: FreqWeekly, Interval: 2, StartDate: day(2025, 12, 1), EndDate: day(2025, 12, 29)}, day(2025, 12, 2),
+			"2025-12-15,2025-12-29"},
+		{"yearly_leap_day", Recurring{Frequency: FreqYearly, Interval: 1, StartDate: day(2024, 2, 29)}, day(2024, 3, 1),
+			"2025-02-28,2026-02-28,2027-02-28,2028-02-29"},
+	}
+	for _, tc := range cases {
+		if got := dates(tc.r.Upcoming(tc.from, 4)); got != tc.want {
+			t.Fatalf("%s: expected %s, got %s", tc.name, tc.want, got)
+		}
+	}
+
+	valid := Recurring{Frequency: FreqMonthly, Interval: 1, StartDate: day(2025, 1, 1), Kind: KindExpense, Amount: 100, Category: "аренда"}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	for _, mutate := range []func(*Recurring){
+		func(r *Recurring) { r.Frequency = "daily" },
+		func(r *Recurring) { r.Interval = 0 },
+		func(r *Recurring) { r.EndDate = day(2024, 12, 31) },
+		func(r *Recurring) { r.Amount = 0 },
+		func(r *Recurring) { r.Category = " " },
+	} {
+		r := valid
+		mutate(&r)
+		if err := r.Validate(); err == nil {
+			t.Fatalf("expected validation error for %+v", r)
+		}
+	}
+}
+
+func TestCategoryTree(t *testing.T) {
+	t.Parallel()
+
+	tree := NewCategoryTree([]Category{
+		{ID: 1, Name: "еда"},
+		{ID: 2, Name: "кафе", ParentID: 1},
+		{ID: 3, Name: "кофе", ParentID: 2},
+		{ID: 4, Name: "продукты", ParentID: 1},
+		{ID: 5, Name: "транспорт"},
+	})
+
+	if got := strings.Join(tree.Lineage("кофе"), ","); got != "кофе,кафе,еда" {
+		t.Fatalf("unexpected lineage: %s", got)
+	}
+	if got := strings.Join(tree.Lineage("без категории"), ","); got != "без категории" {
+		t.Fatalf("unknown category must be a root, got %s", got)
+	}
+	if got := strings.Join(tree.Subtree("еда"), ","); got != "еда,кафе,продукты,кофе" {
+		t.Fatalf("unexpected subtree: %s", got)
+	}
+	if !tree.IsDescendant(3, 1) || tree.IsDescendant(1, 3) || tree.IsDescendant(5, 1) {
+		t.Fatalf("unexpected descendant check")
+	}
+
+	rolled := tree.RollUp(map[string]Money{"кофе": 100, "кафе": 50, "продукты": 200, "такси": 7})
+	if rolled["еда"] != 350 || rolled["кафе"] != 150 || rolled["кофе"] != 100 || rolled["такси"] != 7 {
+		t.Fatalf("unexpected roll-up: %v", rolled)
+	}
+
+	e := ReclassEntry("u1", "кафе", "рестораны", []TrialBalanceLine{
+		{Account: "expenses:кафе", Currency: "RUB", Debit: 500, Credit: 100},
+		{Account: "expenses:кафе", Currency: "EUR", Debit: 10, Credit: 10},
+		{Account: "income:кафе", Currency: "RUB", Credit: 30},
+		{Account: "expenses:кафе/десерты", Currency: "RUB", Debit: 1},
+	}, time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC))
+	if err := e.Validate(); err != nil || len(e.Postings) != 4 ||
+		e.Postings[0].Account != "expenses:рестораны" || e.Postings[0].Amount != 400 ||
+		e.Postings[2].Account != "income:рестораны" || e.Postings[2].Amount != -30 {
+		t.Fatalf("unexpected reclass entry: %+v (%v)", e.Postings, err)
+	}
+}
+
+func TestNormalizeCategory(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{in: " Такси ", want: "такси"},
+		{in: "тaкси", want: "такси"}, // латинская a
+		{in: "TАКСИ", want: "такси"}, // латинские T, K
+		{in: "tаxi", want: "taxi"},   // кириллическая а
+		{in: "taxi", want: "taxi"},
+		{in: "Ёлка", want: "елка"},
+		{in: "е\u0308лка", want: "елка"}, // ё из двух code point
+		{in: "ｃａｆｅ", want: "cafe"},       // полноширинные
+		{in: "кафе  и\tбары", want: "кафе и бары"},
+		{in: "кафе/бары", want: "кафе/бары"},
+	}
+
+	for _, tc := range cases {
+		if got := NormalizeCategory(tc.in); got != tc.want {
+			t.Fatalf("NormalizeCategory(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestSuggestMerges(t *testing.T) {
+	t.Parallel()
+
+	usage := map[string]int{
+		"такси":     10,
+		"тaкси":     2, // сохранено до нормализации
+		"taxi":      3,
+		"ёлка":      1,
+		"продукты":  5,
+		"продуктыы": 1,
+		"кафе":      4,
+		"кофе":      4,
+		"ндфл 2024": 1,
+		"ндфл 2025": 1,
+	}
+	got := SuggestMerges(usage, map[string]string{"taxi": "такси"})
+	want := []MergeSuggestion{
+		{From: "taxi", Into: "такси", Reason: SuggestAlias, FromCount: 3, IntoCount: 10},
+		{From: "продуктыы", Into: "продукты", Reason: SuggestSimilar, FromCount: 1, IntoCount: 5},
+		{From: "тaкси", Into: "такси", Reason: SuggestNormalized, FromCount: 2, IntoCount: 10},
+		{From: "ёлка", Into: "елка", Reason: SuggestNormalized, FromCount: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected suggestions: %+v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("suggestion %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestRuleSet(t *testing.T) {
+	t.Parallel()
+
+	for _, r := range []Rule{
+		{Category: "такси"},
+		{Merchant: "yandex", DescriptionRegex: "(", Category: "такси"},
+		{Merchant: "yandex", MinAmount: 500, MaxAmount: 100, Category: "такси"},
+		{Merchant: "yandex"},
+		{Merchant: "yandex", Category: "такси", Tags: []string{" "}},
+	} {
+		if err := r.Validate(); err == nil {
+			t.Fatalf("expected validation error for %+v", r)
+		}
+	}
+
+	rules := NewRuleSet([]Rule{
+		{ID: 1, Priority: 10, DescriptionContains: "Такси", Category: "такси"},
+		{ID: 2, Priority: 5, Merchant: "yandex taxi", MinAmount: 100000, Category: "такси дальнее"},
+		{ID: 3, Priority: 10, DescriptionRegex: `^pyaterochka\s+\d+`, Category: "продукты"},
+		{ID: 4, Priority: 0, DescriptionContains: "такси", Category: "отключено", Disabled: true},
+		{ID: 5, Priority: 20, MaxAmount: 50, Category: "мелочи"},
+	})
+
+	cases := []struct {
+		desc   string
+		amount Money
+		want   []int
+	}{
+		{desc: "YANDEX*TAXI Moscow", amount: 150000, want: []int{2}},
+		{desc: "YANDEX*TAXI Moscow", amount: 50000},
+		{desc: "yandextaxi", amount: 150000},
+		{desc: "Поездка на тaкси", amount: 30000, want: []int{1}},
+		{desc: "PYATEROCHKA 1234 SPB", amount: 40, want: []int{3, 5}},
+		{desc: "", amount: 10, want: []int{5}},
+	}
+	for _, tc := range cases {
+		var got []int
+		for _, r := range rules.Matching(Transaction{Description: tc.desc, Amount: tc.amount}) {
+			got = append(got, r.ID)
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Fatalf("%q %d: expected rules %v, got %v", tc.desc, tc.amount, tc.want, got)
+		}
+		r, ok := rules.Match(Transaction{Description: tc.desc, Amount: tc.amount})
+		if ok != (len(tc.want) > 0) || (ok && r.ID != tc.want[0]) {
+			t.Fatalf("%q %d: unexpected fired rule %+v", tc.desc, tc.amount, r)
+		}
+	}
+
+	if got := NormalizeTags([]string{"Отпуск-2026", " отпуск-2026", "Work"}); !slices.Equal(got, []string{"отпуск-2026", "work"}) {
+		t.Fatalf("unexpected tags: %v", got)
+	}
+}
+
+func TestFilterTags(t *testing.T) {
+	t.Parallel()
+
+	f := TransactionFilter{Tags: []string{" Отпуск", "", "кафе", "отпуск"}}.Normalize()
+	if !slices.Equal(f.Tags, []string{"отпуск", "кафе"}) {
+		t.Fatalf("unexpected tags: %v", f.Tags)
+	}
+	if !f.Matches(Transaction{Tags: []string{"работа", "кафе"}}) {
+		t.Fatalf("expected match by any tag")
+	}
+	if f.Matches(Transaction{Tags: []string{"работа"}}) || f.Matches(Transaction{}) {
+		t.Fatalf("unexpected match")
+	}
+	if err := (Transaction{Amount: 1, Category: "еда", Date: time.Now(), Tags: []string{strings.Repeat("x", MaxTagLen+1)}}).Validate(); err == nil || err.Error() != "tag is too long" {
+		t.Fatalf("expected tag is too long, got %v", err)
+	}
+}
+
+func TestBudgetProgress(t *testing.T) {
+	t.Parallel()
+
+	day := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
+	monthly := Budget{Category: "еда", Limit: 3100, Period: PeriodMonthly, Currency: "RUB"}
+
+	cases := []struct {
+		b        Budget
+		from, to time.Time
+		want     Money
+	}{
+		{b: monthly, from: day(12, 1), to: day(12, 31), want: 3100},
+		{b: monthly, from: day(12, 1), to: day(12, 10), want: 1000},
+		{b: monthly, from: day(11, 16), to: day(12, 15), want: 1550 + 1500},
+		{b: monthly, from: day(1, 1), to: day(12, 31), want: 12 * 3100},
+		{b: Budget{Limit: 700, Period: PeriodWeekly}, from: day(12, 1), to: day(12, 14), want: 1400},
+		{b: Budget{Limit: 500}, from: day(12, 1), to: day(12, 3), want: 500},
+		{b: monthly, from: day(12, 2), to: day(12, 1), want: 0},
+	}
+	for _, tc := range cases {
+		if got := tc.b.LimitInRange(tc.from, tc.to); got != tc.want {
+			t.Fatalf("LimitInRange(%s, %s) = %d, want %d", tc.from.Format(time.DateOnly), tc.to.Format(time.DateOnly), got, tc.want)
+		}
+	}
+
+	// 10 дней из 31 прошли: прогноз переносит темп на весь месяц.
+	p := NewBudgetProgress(monthly, 1000, day(12, 1), day(12, 31), day(12, 10).Add(12*time.Hour))
+	if p.Limit != 3100 || p.Remaining != 2100 || p.Projected != 3100 || p.Currency != "RUB" || p.Period != PeriodMonthly {
+		t.Fatalf("unexpected progress: %+v", p)
+	}
+	// В часовом поясе бюджета уже наступило 10 декабря.
+	tokyo := monthly
+	tokyo.Timezone = "Asia/Tokyo"
+	if p = NewBudgetProgress(tokyo, 1000, day(12, 1), day(12, 31), day(12, 9).Add(20*time.Hour)); p.Projected != 3100 {
+		t.Fatalf("unexpected projection in budget timezone: %+v", p)
+	}
+	if p = NewBudgetProgress(monthly, 4000, day(12, 1), day(12, 31), day(12, 31)); p.Projected != 4000 || p.Remaining != -900 {
+		t.Fatalf("unexpected progress for a finished range: %+v", p)
+	}
+	if p = NewBudgetProgress(monthly, 0, day(12, 1), day(12, 31), day(11, 20)); p.Projected != 0 || p.Percent != 0 {
+		t.Fatalf("unexpected progress for a future range: %+v", p)
+	}
+}
+
+func TestTimeSeries(t *testing.T) {
+	t.Parallel()
+
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+	// 2025-12-31 — среда; ISO-неделя 2026-W01 начинается 29 декабря.
+	s, err := NewTimeSeries(day(2025, 12, 24), day(2026, 1, 6), BucketWeek)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	labels := make([]string, 0, len(s.Points))
+	for _, p := range s.Points {
+		labels = append(labels, p.Label)
+	}
+	if !slices.Equal(labels, []string{"2025-W52", "2026-W01", "2026-W02"}) ||
+		!s.Points[0].From.Equal(day(2025, 12, 24)) || !s.Points[0].To.Equal(day(2025, 12, 28)) ||
+		!s.Points[2].To.Equal(day(2026, 1, 6)) {
+		t.Fatalf("unexpected weeks: %+v", s.Points)
+	}
+
+	s.SplitBy([]string{"кафе", "еда"})
+	s.Add("еда", day(2025, 12, 30), 100)
+	s.Add("кафе", day(2026, 1, 1), 50)
+	s.Add("еда", day(2026, 1, 7), 999) // вне ряда
+	if s.Points[1].Total != 150 || s.Points[1].ByCategory["еда"] != 100 || s.Points[1].ByCategory["кафе"] != 50 {
+		t.Fatalf("unexpected point: %+v", s.Points[1])
+	}
+	if p := s.Points[2]; p.Total != 0 || len(p.ByCategory) != 2 || p.ByCategory["еда"] != 0 || !slices.Equal(s.Categories, []string{"еда", "кафе"}) {
+		t.Fatalf("gaps must be zero-filled: %+v %v", p, s.Categories)
+	}
+
+	for bucket, want := range map[string][]string{
+		BucketDay:   {"2025-12-30", "2025-12-31", "2026-01-01"},
+		"":          {"2025-12", "2026-01"},
+		BucketYear:  {"2025", "2026"},
+		BucketMonth: {"2025-12", "2026-01"},
+	} {
+		s, err := NewTimeSeries(day(2025, 12, 30), day(2026, 1, 1), bucket)
+		if err != nil {
+			t.Fatalf("%s: %v", bucket, err)
+		}
+		var got []string
+		for _, p := range s.Points {
+			got = append(got, p.Label)
+		}
+		if !slices.Equal(got, want) {
+			t.Fatalf("%s: labels %v, want %v", bucket, got, want)
+		}
+	}
+
+	if _, err := NewTimeSeries(day(2025, 1, 1), day(2025, 1, 1), "hour"); err == nil || err.Error() != "invalid bucket" {
+		t.Fatalf("expected invalid bucket, got %v", err)
+	}
+	if _, err := NewTimeSeries(day(2020, 1, 1), day(2025, 1, 1), BucketDay); err == nil || err.Error() != "too many buckets" {
+		t.Fatalf("expected too many buckets, got %v", err)
+	}
+
+	// Момент переводится в дату часового пояса, дата остаётся как есть.
+	ny, _ := time.LoadLocation("America/New_York")
+	if d, err := ParseDay("2026-01-01T03:00:00Z", ny); err != nil || !d.Equal(day(2025, 12, 31)) {
+		t.Fatalf("ParseDay = %v (%v)", d, err)
+	}
+	if d, err := ParseDay("2026-01-01", ny); err != nil || !d.Equal(day(2026, 1, 1)) {
+		t.Fatalf("ParseDay = %v (%v)", d, err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	cases := []struct {
+		from, to         time.Time
+		wantFrom, wantTo time.Time
+	}{
+		{from: day(2025, 12, 1), to: day(2025, 12, 31), wantFrom: day(2025, 11, 1), wantTo: day(2025, 11, 30)},
+		{from: day(2025, 3, 1), to: day(2025, 3, 31), wantFrom: day(2025, 2, 1), wantTo: day(2025, 2, 28)},
+		{from: day(2025, 1, 1), to: day(2025, 3, 31), wantFrom: day(2024, 10, 1), wantTo: day(2024, 12, 31)},
+		{from: day(2025, 12, 8), to: day(2025, 12, 14), wantFrom: day(2025, 12, 1), wantTo: day(2025, 12, 7)},
+		{from: day(2025, 12, 1), to: day(2025, 12, 15), wantFrom: day(2025, 11, 16), wantTo: day(2025, 11, 30)},
+	}
+	for _, tc := range cases {
+		from, to, err := PreviousRange(tc.from, tc.to)
+		if err != nil || !from.Equal(tc.wantFrom) || !to.Equal(tc.wantTo) {
+			t.Fatalf("PreviousRange(%s, %s) = %s, %s (%v)", tc.from.Format(time.DateOnly), tc.to.Format(time.DateOnly),
+				from.Format(time.DateOnly), to.Format(time.DateOnly), err)
+		}
+	}
+	if _, _, err := PreviousRange(day(2025, 2, 1), day(2025, 1, 1)); err == nil {
+		t.Fatalf("expected range error")
+	}
+
+	c := Compare(CategoryTree{},
+		map[string]Money{"еда": 1000, "кафе": 300, "такси": 500},
+		map[string]Money{"еда": 800, "такси": 500, "кино": 400},
+	)
+	var order []string
+	for _, d := range c.Items {
+		order = append(order, d.Category)
+	}
+	if !slices.Equal(order, []string{"кино", "кафе", "еда", "такси"}) {
+		t.Fatalf("unexpected order: %v", order)
+	}
+	if d := c.Items[2]; d.Delta != 200 || d.DeltaPercent != 25 || d.Status != "" {
+		t.Fatalf("unexpected delta: %+v", d)
+	}
+	if d := c.Items[0]; d.Delta != -400 || d.DeltaPercent != -100 || d.Status != CompareDisappeared {
+		t.Fatalf("unexpected delta: %+v", d)
+	}
+	if d := c.Items[1]; d.DeltaPercent != 0 || d.Status != CompareNew {
+		t.Fatalf("unexpected delta: %+v", d)
+	}
+	if c.Total.Current != 1800 || c.Total.Previous != 1700 || c.Total.Delta != 100 ||
+		!slices.Equal(c.New, []string{"кафе"}) || !slices.Equal(c.Disappeared, []string{"кино"}) {
+		t.Fatalf("unexpected comparison: %+v", c)
+	}
+}
+
+func TestAnomalies(t *testing.T) {
+	t.Parallel()
+
+	if _, _, ok := RobustScore(100, []Money{100, 100, 100}); ok {
+		t.Fatalf("short history must not be scored")
+	}
+	if _, _, ok := RobustScore(500, []Money{100, 100, 100, 100, 100}); ok {
+		t.Fatalf("history without spread must not be scored")
+	}
+	// MAD = 0: разброс берётся по среднему абсолютному отклонению.
+	if score, typical, ok := RobustScore(200, []Money{100, 100, 100, 100, 100, 200}); !ok || typical != 100 || score < 4.7 || score > 4.8 {
+		t.Fatalf("unexpected score %v, typical %s, ok %v", score, typical, ok)
+	}
+
+	// 12 недель истории с понедельника 1 сентября, оцениваемая неделя — с 24 ноября.
+	week := func(i int) time.Time { return time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC).AddDate(0, 0, 7*i) }
+	food := []Money{1000, 1100, 900, 1000, 1050, 950, 1000, 1200, 800, 1000, 1000, 1100}
+	taxi := []Money{300, 320, 280, 300, 310, 290, 300, 300, 330, 270, 300, 300}
+	var txs []Transaction
+	for i := range food {
+		txs = append(txs,
+			Transaction{ID: len(txs) + 1, Kind: KindExpense, Category: "еда", Amount: food[i], Date: week(i)},
+			Transaction{ID: len(txs) + 2, Kind: KindExpense, Category: "такси", Amount: taxi[i], Date: week(i)},
+		)
+	}
+	txs = append(txs,
+		Transaction{ID: 100, Kind: KindExpense, Category: "еда", Amount: 1100, Date: week(12)},
+		Transaction{ID: 101, Kind: KindExpense, Category: "еда", Amount: 5000, Date: week(12).AddDate(0, 0, 1)},
+		// Возврат обнуляет траты такси за неделю — это тоже необычно.
+		Transaction{ID: 102, Kind: KindExpense, Category: "такси", Amount: 300, Date: week(12)},
+		Transaction{ID: 103, Kind: KindRefund, Category: "такси", Amount: 300, Date: week(12)},
+	)
+	if from := AnomalyHistoryFrom(week(12).AddDate(0, 0, 2)); !from.Equal(time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected history start %s", from)
+	}
+
+	got := FindAnomalies(txs, week(12), week(12).AddDate(0, 0, 6))
+	if len(got) != 3 {
+		t.Fatalf("expected 3 anomalies, got %+v", got)
+	}
+	if a := got[0]; a.Kind != AnomalyTransaction || a.TransactionID != 101 || a.Typical != 1000 || a.Score <= AnomalyThreshold {
+		t.Fatalf("unexpected transaction anomaly: %+v", a)
+	}
+	if a := got[1]; a.Kind != AnomalyWeekly || a.Category != "еда" || a.Amount != 6100 || a.To.Weekday() != time.Sunday {
+		t.Fatalf("unexpected weekly anomaly: %+v", a)
+	}
+	if a := got[2]; a.Kind != AnomalyWeekly || a.Category != "такси" || a.Amount != 0 || a.Typical != 300 || a.Score >= -AnomalyThreshold {
+		t.Fatalf("unexpected weekly anomaly: %+v", a)
+	}
+
+	// Неделя, не закончившаяся к to, не оценивается.
+	if got := FindAnomalies(txs, week(12), week(12).AddDate(0, 0, 3)); len(got) != 1 || got[0].Kind != AnomalyTransaction {
+		t.Fatalf("expected only the transaction anomaly, got %+v", got)
+	}
+}
@@ -0,0 +1,230 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	SortDateDesc   = "date_desc"
+	SortDateAsc    = "date_asc"
+	SortAmountDesc = "amount_desc"
+	SortAmountAsc  = "amount_asc"
+
+	// DefaultPageSize — размер страницы, если передан только PageToken; без
+	// PageSize и PageToken список не разбивается на страницы.
+	DefaultPageSize = 100
+	MaxPageSize     = 1000
+)
+
+func IsValidSort(s string) bool {
+	switch s {
+	case "", SortDateDesc, SortDateAsc, SortAmountDesc, SortAmountAsc:
+		return true
+	default:
+		return false
+	}
+}
+
+// TransactionFilter задаёт выборку транзакций пользователя.
+// Нулевые From/To и nil MinAmount/MaxAmount означают отсутствие ограничения,
+// границы включительные. Query ищется в описании без учёта регистра.
+// Пустые Kinds и AccountIDs — транзакции всех видов и со всех счетов.
+// Tags — транзакции хотя бы с одной из меток.
+type TransactionFilter struct {
+	From       time.Time
+	To         time.Time
+	AccountIDs []int
+	Kinds      []string
+	Categories []string
+	Tags       []string
+	MinAmount  *Money
+	MaxAmount  *Money
+	Query      string
+	Sort       string
+
+	PageSize  int
+	PageToken string
+}
+
+func (f TransactionFilter) Validate() error {
+	if !f.From.IsZero() && !f.To.IsZero() && f.From.After(f.To) {
+		return errors.New("from must be <= to")
+	}
+	if f.MinAmount != nil && f.MaxAmount != nil && *f.MinAmount > *f.MaxAmount {
+		return errors.New("min_amount must be <= max_amount")
+	}
+	for _, k := range f.Kinds {
+		if k == "" || !IsValidKind(k) {
+			return errors.New("invalid transaction kind")
+		}
+	}
+	if !IsValidSort(f.Sort) {
+		return errors.New("invalid sort")
+	}
+	if f.PageSize < 0 || f.PageSize > MaxPageSize {
+		return errors.New("invalid page size")
+	}
+	if _, err := f.Cursor(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Normalize подставляет значения по умолчанию и приводит категории и метки к каноническому виду.
+func (f TransactionFilter) Normalize() TransactionFilter {
+	if f.Sort == "" {
+		f.Sort = SortDateDesc
+	}
+	if f.PageSize == 0 {
+		f.PageSize = DefaultPageSize
+	}
+	cats := make([]string, 0, len(f.Categories))
+	for _, c := range f.Categories {
+		if c = NormalizeCategory(c); c != "" {
+			cats = append(cats, c)
+		}
+	}
+	f.Categories = cats
+	f.Tags = NormalizeTags(f.Tags)
+	f.Query = strings.TrimSpace(f.Query)
+	return f
+}
+
+// Matches проверяет транзакцию на соответствие условиям фильтра (без пагинации).
+func (f TransactionFilter) Matches(t Transaction) bool {
+	day := time.Date(t.Date.Year(), t.Date.Month(), t.Date.Day(), 0, 0, 0, 0, time.UTC)
+	if !f.From.IsZero() && day.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && day.After(f.To) {
+		return false
+	}
+	if len(f.AccountIDs) > 0 {
+		found := false
+		for _, id := range f.AccountIDs {
+			if id == t.AccountID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.Kinds) > 0 {
+		found := false
+		for _, k := range f.Kinds {
+			if k == t.Kind {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.Categories) > 0 {
+		found := false
+		for _, c := range f.Categories {
+			if c == t.Category {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.Tags) > 0 && !t.HasAnyTag(f.Tags) {
+		return false
+	}
+	if f.MinAmount != nil && t.Amount < *f.MinAmount {
+		return false
+	}
+	if f.MaxAmount != nil && t.Amount > *f.MaxAmount {
+		return false
+	}
+	if f.Query != "" && !strings.Contains(strings.ToLower(t.Description), strings.ToLower(f.Query)) {
+		return false
+	}
+	return true
+}
+
+// TransactionCursor — позиция последней отданной записи для keyset-пагинации.
+// Сортировка сохраняется в курсоре, чтобы токен нельзя было применить к другому порядку.
+type TransactionCursor struct {
+	Sort   string    `json:"s"`
+	Date   time.Time `json:"d"`
+	Amount Money     `json:"a"`
+	ID     int       `json:"i"`
+}
+
+func CursorAfter(sort string, t Transaction) TransactionCursor {
+	return TransactionCursor{Sort: sort, Date: t.Date, Amount: t.Amount, ID: t.ID}
+}
+
+func (c TransactionCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Cursor декодирует PageToken; для пустого токена возвращает nil.
+func (f TransactionFilter) Cursor() (*TransactionCursor, error) {
+	if f.PageToken == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(f.PageToken)
+	if err != nil {
+		return nil, errors.New("invalid page token")
+	}
+	var c TransactionCursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, errors.New("invalid page token")
+	}
+	sort := f.Sort
+	if sort == "" {
+		sort = SortDateDesc
+	}
+	if c.Sort != sort {
+		return nil, errors.New("invalid page token")
+	}
+	return &c, nil
+}
+
+// Follows сообщает, идёт ли t после позиции курсора в его порядке сортировки.
+func (c TransactionCursor) Follows(t Transaction) bool {
+	return SortLess(c.Sort, Transaction{ID: c.ID, Amount: c.Amount, Date: c.Date}, t)
+}
+
+// SortLess сообщает, идёт ли a раньше b в порядке sort; при равенстве ключа
+// порядок задаёт id в том же направлении.
+func SortLess(sort string, a, b Transaction) bool {
+	var cmp int
+	switch sort {
+	case SortAmountAsc, SortAmountDesc:
+		switch {
+		case a.Amount < b.Amount:
+			cmp = -1
+		case a.Amount > b.Amount:
+			cmp = 1
+		}
+	default:
+		cmp = a.Date.Compare(b.Date)
+	}
+	if cmp == 0 {
+		cmp = a.ID - b.ID
+	}
+	if sort == SortDateAsc || sort == SortAmountAsc {
+		return cmp < 0
+	}
+	return cmp > 0
+}
+
+type TransactionPage struct {
+	Items         []Transaction
+	NextPageToken string
+}
@@ -10,8 +10,14 @@ type ImportError struct {
 	Error string `json:"error"`
 }
 
+type ImportWarning struct {
+	Index   int           `json:"index"`
+	Warning BudgetWarning `json:"warning"`
+}
+
 type ImportSummary struct {
-	Accepted int64         `json:"accepted"`
-	Rejected int64         `json:"rejected"`
-	Errors   []ImportError `json:"errors"`
+	Accepted int64           `json:"accepted"`
+	Rejected int64           `json:"rejected"`
+	Errors   []ImportError   `json:"errors"`
+	Warnings []ImportWarning `json:"warnings"`
 }
@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Счета главной книги. Счета пользователя — активы assets:<id>, категории
+// расходов и доходов — expenses:<категория> и income:<категория>.
+const (
+	LedgerAssets   = "assets"
+	LedgerExpenses = "expenses"
+	LedgerIncome   = "income"
+	LedgerEquity   = "equity"
+
+	// EquityOpening — источник остатков на открытии счетов.
+	EquityOpening = "equity:opening"
+	// EquityTransfers — транзитный счёт переводов: половины перевода между
+	// счетами в одной валюте взаимно гасятся на нём.
+	EquityTransfers = "equity:transfers"
+)
+
+func AssetAccount(accountID int) string {
+	return LedgerAssets + ":" + strconv.Itoa(accountID)
+}
+
+// ParseLedgerAccount проверяет код счёта главной книги и возвращает его раздел.
+func ParseLedgerAccount(code string) (section, name string, err error) {
+	section, name, ok := strings.Cut(code, ":")
+	if !ok || name == "" {
+		return "", "", errors.New("invalid ledger account")
+	}
+	switch section {
+	case LedgerAssets:
+		if id, err := strconv.Atoi(name); err != nil || id <= 0 {
+			return "", "", errors.New("invalid ledger account")
+		}
+	case LedgerExpenses, LedgerIncome, LedgerEquity:
+	default:
+		return "", "", errors.New("invalid ledger account")
+	}
+	return section, name, nil
+}
+
+// Posting — строка проводки: дебет положительный, кредит отрицательный.
+type Posting struct {
+	Account  string
+	Currency string
+	Amount   Money
+}
+
+// JournalEntry — запись журнала. Журнал только дополняется: исправление
+// транзакции оформляется сторнированием прежней записи и новой записью.
+type JournalEntry struct {
+	ID     int
+	UserID string
+	// TransactionID — транзакция, породившая запись; 0 — остаток на открытии счёта.
+	TransactionID int
+	Date          time.Time
+	Description   string
+	Postings      []Posting
+	// RecordedAt — момент записи в журнал, заполняется при чтении.
+	RecordedAt time.Time
+}
+
+// Validate проверяет, что дебет равен кредиту отдельно в каждой валюте.
+func (e JournalEntry) Validate() error {
+	if len(e.Postings) < 2 {
+		return errors.New("journal entry needs at least two postings")
+	}
+	sums := map[string]Money{}
+	for _, p := range e.Postings {
+		if p.Amount == 0 || p.Account == "" || p.Currency == "" {
+			return errors.New("invalid posting")
+		}
+		sums[p.Currency] += p.Amount
+	}
+	for _, s := range sums {
+		if s != 0 {
+			return errors.New("unbalanced journal entry")
+		}
+	}
+	return nil
+}
+
+// Reversal возвращает сторнирующую запись с теми же счетами и обратными суммами.
+func (e JournalEntry) Reversal() JournalEntry {
+	r := e
+	r.ID = 0
+	r.Description = "reversal: " + e.Description
+	r.Postings = make([]Posting, len(e.Postings))
+	for i, p := range e.Postings {
+		p.Amount = -p.Amount
+		r.Postings[i] = p
+	}
+	return r
+}
+
+// EntryFor строит запись журнала для транзакции: счёт пользователя меняется
+// на BalanceEffect, встречная проводка идёт на категорию расходов (расход,
+// возврат), доходов (доход) или на транзитный счёт переводов.
+func EntryFor(t Transaction) JournalEntry {
+	counter := EquityTransfers
+	switch t.Kind {
+	case "", KindExpense, KindRefund:
+		counter = LedgerExpenses + ":" + t.Category
+	case KindIncome:
+		counter = LedgerIncome + ":" + t.Category
+	}
+	effect := t.BalanceEffect()
+	return JournalEntry{
+		UserID:        t.UserID,
+		TransactionID: t.ID,
+		Date:          t.Date,
+		Description:   t.Description,
+		Postings: []Posting{
+			{Account: AssetAccount(t.AccountID), Currency: t.Currency, Amount: effect},
+			{Account: counter, Currency: t.Currency, Amount: -effect},
+		},
+	}
+}
+
+// OpeningEntry записывает остаток на открытии счёта; для нулевого остатка ok=false.
+func OpeningEntry(a Account, on time.Time) (JournalEntry, bool) {
+	if a.OpeningBalance == 0 {
+		return JournalEntry{}, false
+	}
+	return JournalEntry{
+		UserID:      a.UserID,
+		Date:        on,
+		Description: "opening balance: " + a.Name,
+		Postings: []Posting{
+			{Account: AssetAccount(a.ID), Currency: a.Currency, Amount: a.OpeningBalance},
+			{Account: EquityOpening, Currency: a.Currency, Amount: -a.OpeningBalance},
+		},
+	}, true
+}
+
+// TrialBalanceLine — обороты счёта главной книги в одной валюте.
+type TrialBalanceLine struct {
+	Account  string
+	Currency string
+	Debit    Money
+	Credit   Money
+}
+
+// Balance — сальдо: положительное — дебетовое, отрицательное — кредитовое.
+func (l TrialBalanceLine) Balance() Money {
+	return l.Debit - l.Credit
+}
+
+type TrialBalance struct {
+	On    time.Time
+	Lines []TrialBalanceLine
+}
+
+// Balanced проверяет, что в каждой валюте сумма дебетов равна сумме кредитов.
+func (tb TrialBalance) Balanced() bool {
+	sums := map[string]Money{}
+	for _, l := range tb.Lines {
+		sums[l.Currency] += l.Balance()
+	}
+	for _, s := range sums {
+		if s != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+type StatementLine struct {
+	EntryID       int
+	TransactionID int
+	Date          time.Time
+	Description   string
+	Amount        Money
+	// Balance — сальдо счёта после строки.
+	Balance    Money
+	RecordedAt time.Time
+}
+
+// Statement — выписка по счёту главной книги в одной валюте за [From, To].
+type Statement struct {
+	Account  string
+	Currency string
+	From     time.Time
+	To       time.Time
+	Opening  Money
+	Closing  Money
+	Lines    []StatementLine
+}
+
+// ReclassEntry переносит сальдо счетов расходов и доходов категории from на
+// категорию to. Если переносить нечего, в записи нет проводок.
+func ReclassEntry(userID, from, to string, lines []TrialBalanceLine, on time.Time) JournalEntry {
+	e := JournalEntry{UserID: userID, Date: on, Description: "reclassify: " + from + " -> " + to}
+	for _, l := range lines {
+		for _, section := range []string{LedgerExpenses, LedgerIncome} {
+			if l.Account != section+":"+from || l.Balance() == 0 {
+				continue
+			}
+			e.Postings = append(e.Postings,
+				Posting{Account: section + ":" + to, Currency: l.Currency, Amount: l.Balance()},
+				Posting{Account: l.Account, Currency: l.Currency, Amount: -l.Balance()},
+			)
+		}
+	}
+	return e
+}
@@ -10,31 +10,141 @@ type Validatable interface {
 	Validate() error
 }
 
+// Виды транзакций. Сумма расхода, дохода и возврата всегда положительна,
+// направление задаёт вид; у перевода знак суммы — направление движения
+// денег (минус — списание), в доходы и расходы переводы не попадают.
+const (
+	KindExpense  = "expense"
+	KindIncome   = "income"
+	KindRefund   = "refund"
+	KindTransfer = "transfer"
+)
+
+func IsValidKind(k string) bool {
+	switch k {
+	case "", KindExpense, KindIncome, KindRefund, KindTransfer:
+		return true
+	default:
+		return false
+	}
+}
+
 type Transaction struct {
 	ID          int
-	Amount      float64
+	UserID      string
+	AccountID   int
+	Kind        string
+	Amount      Money
+	Currency    string
 	Category    string
 	Description string
 	Date        time.Time
+	// TransferID — id второй половины перевода между счетами.
+	TransferID int
+	// Tags — метки транзакции, нормализованные и без повторов.
+	Tags []string
+	// AutoCategory — категорию назначают правила пользователя; Category
+	// остаётся, если ни одно правило не подошло. Не сохраняется.
+	AutoCategory bool
+	// CheckAnomaly — сравнить расход с историей категории. Не сохраняется.
+	CheckAnomaly bool
+
+	// Warnings и Anomaly заполняются только в ответе AddTransaction.
+	Warnings []BudgetWarning
+	Anomaly  *Anomaly
 }
 
 func (t Transaction) Validate() error {
-	if t.Amount <= 0 {
+	if !IsValidKind(t.Kind) {
+		return errors.New("invalid transaction kind")
+	}
+	if t.Kind == KindTransfer {
+		if t.Amount == 0 {
+			return errors.New("amount must not be 0")
+		}
+	} else if t.Amount <= 0 {
 		return errors.New("amount must be > 0")
 	}
+	if t.Amount > MaxAmount || t.Amount < -MaxAmount {
+		return errors.New("amount is too large")
+	}
 	if strings.TrimSpace(t.Category) == "" {
 		return errors.New("transaction category is empty")
 	}
 	if t.Date.IsZero() {
 		return errors.New("date is required")
 	}
-	return nil
+	if t.Currency != "" && !IsValidCurrency(NormalizeCurrency(t.Currency)) {
+		return errors.New("invalid currency")
+	}
+	return ValidateTags(t.Tags)
+}
+
+// Spending — вклад транзакции в траты категории: расход увеличивает их,
+// возврат уменьшает, доход и перевод не влияют.
+func (t Transaction) Spending() Money {
+	switch t.Kind {
+	case "", KindExpense:
+		return t.Amount
+	case KindRefund:
+		return -t.Amount
+	default:
+		return 0
+	}
+}
+
+// TransactionPatch описывает изменение транзакции: nil-поля не меняются.
+type TransactionPatch struct {
+	AccountID   *int
+	Kind        *string
+	Amount      *Money
+	Currency    *string
+	Category    *string
+	Description *string
+	Date        *time.Time
+	// Tags заменяет все метки; пустой срез снимает их.
+	Tags *[]string
+}
+
+func (p TransactionPatch) Apply(t Transaction) Transaction {
+	if p.AccountID != nil {
+		t.AccountID = *p.AccountID
+	}
+	if p.Kind != nil {
+		t.Kind = *p.Kind
+	}
+	if p.Amount != nil {
+		t.Amount = *p.Amount
+	}
+	if p.Currency != nil {
+		t.Currency = *p.Currency
+	}
+	if p.Category != nil {
+		t.Category = *p.Category
+	}
+	if p.Description != nil {
+		t.Description = *p.Description
+	}
+	if p.Date != nil {
+		t.Date = *p.Date
+	}
+	if p.Tags != nil {
+		t.Tags = *p.Tags
+	}
+	return t
 }
 
 type Budget struct {
+	UserID   string
 	Category string
-	Limit    float64
+	Limit    Money
+	Currency string
 	Period   string
+	StartDay int
+	Timezone string
+
+	Enforcement    string
+	WarnThresholds []int
 }
 
 func (b Budget) Validate() error {
@@ -44,9 +154,34 @@ func (b Budget) Validate() error {
 	if b.Limit <= 0 {
 		return errors.New("limit must be > 0")
 	}
+	if b.Limit > MaxAmount {
+		return errors.New("limit is too large")
+	}
+	if b.Currency != "" && !IsValidCurrency(NormalizeCurrency(b.Currency)) {
+		return errors.New("invalid currency")
+	}
+	if !IsValidPeriod(b.Period) {
+		return errors.New("invalid budget period")
+	}
+	maxDay := 31
+	if b.Period == PeriodWeekly {
+		maxDay = 7
+	}
+	if b.StartDay < 0 || b.StartDay > maxDay {
+		return errors.New("invalid start day")
+	}
+	if b.Timezone != "" {
+		if _, err := time.LoadLocation(b.Timezone); err != nil {
+			return errors.New("invalid timezone")
+		}
+	}
+	if !IsValidEnforcement(b.Enforcement) {
+		return errors.New("invalid enforcement mode")
+	}
+	for _, th := range b.WarnThresholds {
+		if th <= 0 || th > 1000 {
+			return errors.New("invalid warning threshold")
+		}
+	}
 	return nil
 }
-
-func NormalizeCategory(s string) string {
-	return strings.TrimSpace(strings.ToLower(s))
-}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money — денежная сумма в минимальных единицах (копейках) с масштабом 2,
+// как NUMERIC(14,2) в схеме. Вся арифметика над суммами целочисленная.
+type Money int64
+
+// MaxAmount — наибольшая сумма, которая помещается в NUMERIC(14,2).
+const MaxAmount Money = 99_999_999_999_999
+
+var errInvalidAmount = errors.New("invalid amount")
+
+// ParseMoney разбирает десятичную строку вида "1500", "-0.5", "12.30".
+// Больше двух знаков после точки допускается только для нулей ("1.500").
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	neg := false
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	intPart, frac, hasDot := strings.Cut(s, ".")
+	if intPart == "" && (!hasDot || frac == "") {
+		return 0, errInvalidAmount
+	}
+	if hasDot && frac == "" {
+		return 0, errInvalidAmount
+	}
+	if len(frac) > 2 {
+		if strings.Trim(frac[2:], "0") != "" {
+			return 0, errInvalidAmount
+		}
+		frac = frac[:2]
+	}
+	for len(frac) < 2 {
+		frac += "0"
+	}
+	if !isDigits(intPart) || !isDigits(frac) {
+		return 0, errInvalidAmount
+	}
+
+	var units int64
+	if intPart != "" {
+		v, err := strconv.ParseInt(intPart, 10, 64)
+		if err != nil || v > math.MaxInt64/100 {
+			return 0, errInvalidAmount
+		}
+		units = v * 100
+	}
+	f, _ := strconv.ParseInt(frac, 10, 64)
+	units += f
+	if neg {
+		units = -units
+	}
+	return Money(units), nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String возвращает кратчайшую точную запись: "1500", "1500.5", "0.01".
+func (m Money) String() string {
+	sign := ""
+	u := uint64(m)
+	if m < 0 {
+		sign = "-"
+		u = uint64(-m)
+	}
+	s := sign + strconv.FormatUint(u/100, 10)
+	switch c := u % 100; {
+	case c == 0:
+		return s
+	case c%10 == 0:
+		return s + "." + strconv.FormatUint(c/10, 10)
+	case c < 10:
+		return s + ".0" + strconv.FormatUint(c, 10)
+	default:
+		return s + "." + strconv.FormatUint(c, 10)
+	}
+}
+
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON принимает как число, так и строку, не проходя через float64.
+func (m *Money) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	v, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+func (m *Money) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*m = 0
+	case int64:
+		*m = Money(v * 100)
+	case float64:
+		*m = Money(math.Round(v * 100))
+	case []byte:
+		return m.scanString(string(v))
+	case string:
+		return m.scanString(v)
+	default:
+		return errors.New("money: unsupported scan type")
+	}
+	return nil
+}
+
+func (m *Money) scanString(s string) error {
+	v, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// Percent возвращает m от base в процентах; только для отображения.
+func (m Money) Percent(base Money) float64 {
+	if base == 0 {
+		return 0
+	}
+	return float64(m) / float64(base) * 100
+}
@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeCategory приводит категорию к каноническому виду: NFKC, нижний
+// регистр, «ё» → «е», одиночные пробелы и замена в слове букв-двойников
+// другой письменности (латинская «a» в «тaкси») на буквы основной.
+func NormalizeCategory(s string) string {
+	s = strings.ToLower(norm.NFKC.String(s))
+	s = strings.ReplaceAll(s, "ё", "е")
+	words := strings.Fields(s)
+	for i, w := range words {
+		words[i] = foldConfusables(w)
+	}
+	return strings.Join(words, " ")
+}
+
+// latinToCyrillic — строчные латинские буквы, которые в тексте неотличимы от
+// кириллических (с учётом заглавных: «T» и «Т», «H» и «Н», «B» и «В»).
+var latinToCyrillic = map[rune]rune{
+	'a': 'а', 'b': 'в', 'c': 'с', 'e': 'е', 'h': 'н', 'k': 'к',
+	'm': 'м', 'o': 'о', 'p': 'р', 't': 'т', 'x': 'х', 'y': 'у',
+}
+
+var cyrillicToLatin = func() map[rune]rune {
+	out := make(map[rune]rune, len(latinToCyrillic))
+	for l, c := range latinToCyrillic {
+		out[c] = l
+	}
+	return out
+}()
+
+// foldConfusables заменяет в слове со смешанной письменностью буквы-двойники
+// на буквы той письменности, которой в слове больше (при равенстве —
+// кириллицы). Слова из одной письменности не меняются.
+func foldConfusables(w string) string {
+	var latin, cyrillic int
+	for _, r := range w {
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		}
+	}
+	if latin == 0 || cyrillic == 0 {
+		return w
+	}
+	fold := latinToCyrillic
+	if latin > cyrillic {
+		fold = cyrillicToLatin
+	}
+	return strings.Map(func(r rune) rune {
+		if f, ok := fold[r]; ok {
+			return f
+		}
+		return r
+	}, w)
+}
+
+// CategoryAlias — синоним категории пользователя: транзакции, бюджеты и
+// шаблоны с категорией Alias записываются в Category.
+type CategoryAlias struct {
+	UserID   string
+	Alias    string
+	Category string
+}
+
+const (
+	// SuggestNormalized — имя сохранено до нормализации и отличается от
+	// канонического вида.
+	SuggestNormalized = "normalized"
+	// SuggestAlias — для имени задан алиас, но старые записи остались под ним.
+	SuggestAlias = "alias"
+	// SuggestSimilar — имена отличаются одной-двумя буквами.
+	SuggestSimilar = "similar"
+)
+
+// MergeSuggestion — предложение слить категорию From в Into.
+type MergeSuggestion struct {
+	From   string
+	Into   string
+	Reason string
+	// FromCount и IntoCount — число транзакций в категориях.
+	FromCount int
+	IntoCount int
+}
+
+// SuggestMerges ищет среди используемых категорий (usage — число транзакций
+// по имени) дубликаты: имена, которые сейчас нормализуются или
+// разрешаются через алиас в другое имя, и имена с опечаткой. Каждое имя
+// встречается в From не больше одного раза.
+func SuggestMerges(usage map[string]int, aliases map[string]string) []MergeSuggestion {
+	names := make([]string, 0, len(usage))
+	for n := range usage {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	var out []MergeSuggestion
+	merged := map[string]bool{}
+	var canonical []string
+	for _, n := range names {
+		into, reason := NormalizeCategory(n), SuggestNormalized
+		if target, ok := aliases[into]; ok {
+			into, reason = target, SuggestAlias
+		}
+		if into == n {
+			canonical = append(canonical, n)
+			continue
+		}
+		merged[n] = true
+		out = append(out, MergeSuggestion{From: n, Into: into, Reason: reason, FromCount: usage[n], IntoCount: usage[into]})
+	}
+
+	for i, a := range canonical {
+		for _, b := range canonical[i+1:] {
+			if merged[a] || merged[b] || !similarCategories(a, b) {
+				continue
+			}
+			from, into := a, b
+			if usage[a] > usage[b] {
+				from, into = b, a
+			}
+			merged[from] = true
+			out = append(out, MergeSuggestion{From: from, Into: into, Reason: SuggestSimilar, FromCount: usage[from], IntoCount: usage[into]})
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].From < out[j].From })
+	return out
+}
+
+// similarCategories считает похожими имена от 5 букв, отличающиеся одной
+// правкой, и имена от 8 букв — двумя: короче слишком много разных слов
+// («кафе» и «кофе»). Имена, отличающиеся только цифрами
+// («ндфл 2024» и «ндфл 2025»), похожими не считаются.
+func similarCategories(a, b string) bool {
+	ra, rb := []rune(a), []rune(b)
+	n := min(len(ra), len(rb))
+	if n < 5 {
+		return false
+	}
+	if stripDigits(a) == stripDigits(b) {
+		return false
+	}
+	d := editDistance(ra, rb)
+	return d == 1 || (d == 2 && n >= 8)
+}
+
+func stripDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// editDistance — расстояние Левенштейна.
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"math/big"
+	"time"
+)
+
+const (
+	PeriodFixed     = "fixed"
+	PeriodWeekly    = "weekly"
+	PeriodMonthly   = "monthly"
+	PeriodQuarterly = "quarterly"
+	PeriodYearly    = "yearly"
+)
+
+func IsValidPeriod(p string) bool {
+	switch p {
+	case "", PeriodFixed, PeriodWeekly, PeriodMonthly, PeriodQuarterly, PeriodYearly:
+		return true
+	default:
+		return false
+	}
+}
+
+// PeriodRange возвращает границы (включительно, по датам) периода бюджета,
+// в который попадает дата транзакции at. Дата берётся в смещении самого at —
+// под ней транзакция хранится и учитывается в суммах трат, поэтому часовой
+// пояс бюджета здесь не применяется. Для "fixed" период не ограничен: bounded=false.
+//
+// StartDay для weekly — день недели ISO (1 = понедельник ... 7 = воскресенье),
+// для monthly/quarterly/yearly — число месяца (обрезается до длины месяца).
+// Кварталы начинаются в январе, апреле, июле и октябре, год — в январе.
+func (b Budget) PeriodRange(at time.Time) (from, to time.Time, bounded bool) {
+	return b.periodOf(time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC))
+}
+
+// Day возвращает дату момента at в часовом поясе бюджета (полночь UTC):
+// так определяется «сегодня» для прогресса бюджета.
+func (b Budget) Day(at time.Time) time.Time {
+	loc := time.UTC
+	if b.Timezone != "" {
+		if l, err := time.LoadLocation(b.Timezone); err == nil {
+			loc = l
+		}
+	}
+	local := at.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// periodOf — границы периода бюджета, содержащего дату day.
+func (b Budget) periodOf(day time.Time) (from, to time.Time, bounded bool) {
+	startDay := b.StartDay
+	if startDay <= 0 {
+		startDay = 1
+	}
+
+	var start, next time.Time
+	switch b.Period {
+	case PeriodWeekly:
+		if startDay > 7 {
+			startDay = 1
+		}
+		wd := int(day.Weekday())
+		if wd == 0 {
+			wd = 7
+		}
+		start = day.AddDate(0, 0, -((wd - startDay + 7) % 7))
+		next = start.AddDate(0, 0, 7)
+	case PeriodMonthly:
+		start, next = monthlyWindow(day, startDay, 1)
+	case PeriodQuarterly:
+		start, next = monthlyWindow(day, startDay, 3)
+	case PeriodYearly:
+		start, next = monthlyWindow(day, startDay, 12)
+	default:
+		return time.Time{}, time.Time{}, false
+	}
+
+	return start, next.AddDate(0, 0, -1), true
+}
+
+// monthlyWindow находит окно длиной months месяцев, выровненное по январю
+// и начинающееся в день startDay, которое содержит day.
+func monthlyWindow(day time.Time, startDay, months int) (time.Time, time.Time) {
+	m := int(day.Month()) - 1
+	m -= m % months
+	start := anchor(day.Year(), time.Month(m+1), startDay)
+	if day.Before(start) {
+		start = anchor(start.Year(), start.Month()-time.Month(months), startDay)
+	}
+	next := anchor(start.Year(), start.Month()+time.Month(months), startDay)
+	return start, next
+}
+
+func anchor(year int, month time.Month, startDay int) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	if startDay > last {
+		startDay = last
+	}
+	return time.Date(first.Year(), first.Month(), startDay, 0, 0, 0, 0, time.UTC)
+}
+
+// LimitInRange возвращает часть лимита, приходящуюся на даты from..to
+// включительно: лимит каждого периода делится поровну между его днями,
+// поэтому на целый период приходится ровно Limit. У fixed-бюджета периода
+// нет, и лимит не делится.
+func (b Budget) LimitInRange(from, to time.Time) Money {
+	if from.After(to) {
+		return 0
+	}
+	var total Money
+	for day := from; !day.After(to); {
+		start, end, bounded := b.periodOf(day)
+		if !bounded {
+			return b.Limit
+		}
+		last := end
+		if last.After(to) {
+			last = to
+		}
+		total += b.Limit.Convert(big.NewRat(int64(daysIn(day, last)), int64(daysIn(start, end))))
+		day = end.AddDate(0, 0, 1)
+	}
+	return total
+}
+
+// daysIn — число дней от from до to включительно.
+func daysIn(from, to time.Time) int {
+	return int(to.Sub(from).Hours()/24) + 1
+}
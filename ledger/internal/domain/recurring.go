@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Частота повторения шаблона — подмножество FREQ из RRULE (RFC 5545).
+const (
+	FreqWeekly  = "weekly"
+	FreqMonthly = "monthly"
+	FreqYearly  = "yearly"
+)
+
+// MaxRecurringInterval ограничивает INTERVAL, чтобы даты повторений не уходили за пределы календаря.
+const MaxRecurringInterval = 100
+
+// Recurring — шаблон повторяющейся транзакции. Повторения отсчитываются от
+// StartDate: weekly — в тот же день недели, monthly — в то же число месяца
+// (31-е в коротком месяце переносится на последний день), yearly — в ту же
+// дату года; Interval — каждые сколько недель, месяцев или лет.
+type Recurring struct {
+	ID          int
+	UserID      string
+	AccountID   int
+	Kind        string
+	Amount      Money
+	Currency    string
+	Category    string
+	Description string
+
+	Frequency string
+	Interval  int
+	StartDate time.Time
+	// EndDate — последняя допустимая дата повторения; нулевая — бессрочно.
+	EndDate time.Time
+	// NextDate — дата ближайшего ещё не созданного повторения; нулевая —
+	// повторения закончились.
+	NextDate time.Time
+	Paused   bool
+}
+
+func IsValidFrequency(f string) bool {
+	switch f {
+	case FreqWeekly, FreqMonthly, FreqYearly:
+		return true
+	default:
+		return false
+	}
+}
+
+func (r Recurring) Validate() error {
+	if !IsValidFrequency(r.Frequency) {
+		return errors.New("invalid frequency")
+	}
+	if r.Interval < 1 || r.Interval > MaxRecurringInterval {
+		return errors.New("invalid interval")
+	}
+	if r.StartDate.IsZero() {
+		return errors.New("start date is required")
+	}
+	if !r.EndDate.IsZero() && r.EndDate.Before(r.StartDate) {
+		return errors.New("end date must be >= start date")
+	}
+	return r.Transaction(r.StartDate).Validate()
+}
+
+// Transaction возвращает транзакцию, которую шаблон создаёт на дату on.
+func (r Recurring) Transaction(on time.Time) Transaction {
+	return Transaction{
+		UserID:      r.UserID,
+		AccountID:   r.AccountID,
+		Kind:        r.Kind,
+		Amount:      r.Amount,
+		Currency:    r.Currency,
+		Category:    r.Category,
+		Description: r.Description,
+		Date:        on,
+	}
+}
+
+// Occurrence возвращает дату n-го повторения (n = 0 — StartDate). Даты
+// считаются от StartDate, а не от предыдущего повторения, поэтому 31-е после
+// февраля снова становится 31-м.
+func (r Recurring) Occurrence(n int) time.Time {
+	start := time.Date(r.StartDate.Year(), r.StartDate.Month(), r.StartDate.Day(), 0, 0, 0, 0, time.UTC)
+	step := n * r.Interval
+	switch r.Frequency {
+	case FreqWeekly:
+		return start.AddDate(0, 0, 7*step)
+	case FreqYearly:
+		return anchor(start.Year()+step, start.Month(), start.Day())
+	default:
+		return anchor(start.Year(), start.Month()+time.Month(step), start.Day())
+	}
+}
+
+// NextOn возвращает первое повторение не раньше on; ok=false — после on
+// повторений уже нет.
+func (r Recurring) NextOn(on time.Time) (time.Time, bool) {
+	on = time.Date(on.Year(), on.Month(), on.Day(), 0, 0, 0, 0, time.UTC)
+	for n := 0; ; n++ {
+		d := r.Occurrence(n)
+		if !r.EndDate.IsZero() && d.After(r.EndDate) {
+			return time.Time{}, false
+		}
+		if !d.Before(on) {
+			return d, true
+		}
+	}
+}
+
+// Upcoming возвращает до count повторений начиная с on.
+func (r Recurring) Upcoming(on time.Time, count int) []time.Time {
+	out := make([]time.Time, 0, count)
+	for len(out) < count {
+		d, ok := r.NextOn(on)
+		if !ok {
+			break
+		}
+		out = append(out, d)
+		on = d.AddDate(0, 0, 1)
+	}
+	return out
+}
@@ -1,24 +1,54 @@
 package domain
 
-import "time"
+import (
+	"math/big"
+	"time"
+)
 
 type ReportSummary struct {
 	From   time.Time
 	To     time.Time
-	Totals map[string]float64
+	Totals map[string]Money
 }
 
+// BudgetProgressItem — исполнение бюджета за диапазон дат. Суммы в валюте
+// бюджета; Limit — часть лимита, приходящаяся на диапазон.
 type BudgetProgressItem struct {
-	Category string
-	Limit    float64
-	Spent    float64
-	Percent  float64
+	Category  string
+	Period    string
+	Currency  string
+	Limit     Money
+	Spent     Money
+	Remaining Money
+	Percent   float64
+	// Projected — ожидаемые траты к концу диапазона при нынешнем темпе.
+	Projected Money
 }
 
 type ReportWithBudgetProgress struct {
 	From     time.Time
 	To       time.Time
-	Total    float64
-	ByCat    map[string]float64
 	Progress []BudgetProgressItem
 }
+
+// NewBudgetProgress считает исполнение бюджета b за даты from..to при
+// тратах spent. Прогноз линейный: траты прошедших дней диапазона
+// переносятся на весь диапазон; «сегодня» берётся в часовом поясе бюджета.
+// Для прошедшего диапазона прогноз равен тратам.
+func NewBudgetProgress(b Budget, spent Money, from, to, now time.Time) BudgetProgressItem {
+	limit := b.LimitInRange(from, to)
+	item := BudgetProgressItem{
+		Category:  b.Category,
+		Period:    b.Period,
+		Currency:  b.Currency,
+		Limit:     limit,
+		Spent:     spent,
+		Remaining: limit - spent,
+		Percent:   spent.Percent(limit),
+		Projected: spent,
+	}
+	if today := b.Day(now); !today.Before(from) && today.Before(to) {
+		item.Projected = spent.Convert(big.NewRat(int64(daysIn(from, to)), int64(daysIn(from, today))))
+	}
+	return item
+}
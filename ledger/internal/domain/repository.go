@@ -2,20 +2,129 @@ package domain
 
 import (
 	"context"
+	"math/big"
 	"time"
 )
 
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type BudgetRepo interface {
 	Upsert(ctx context.Context, b Budget) error
-	GetLimit(ctx context.Context, category string) (float64, bool, error)
-	List(ctx context.Context) ([]Budget, error)
+	Get(ctx context.Context, userID, category string) (Budget, bool, error)
+	GetForUpdate(ctx context.Context, userID, category string) (Budget, bool, error)
+	List(ctx context.Context, userID string) ([]Budget, error)
 }
 
 type ExpenseRepo interface {
 	Insert(ctx context.Context, t Transaction) (int, error)
-	List(ctx context.Context) ([]Transaction, error)
-	SumByCategory(ctx context.Context, category string) (float64, error)
+	GetForUpdate(ctx context.Context, userID string, id int) (Transaction, bool, error)
+	Update(ctx context.Context, t Transaction) error
+	Delete(ctx context.Context, userID string, id int) error
+	// List возвращает не более limit транзакций, подходящих под фильтр,
+	// в порядке f.Sort начиная после курсора из f.PageToken.
+	List(ctx context.Context, userID string, f TransactionFilter, limit int) ([]Transaction, error)
+	// AmountsByCategory группирует траты категорий (расходы минус возвраты)
+	// по валюте и дате, чтобы каждую группу можно было пересчитать по курсу
+	// на свою дату.
+	AmountsByCategory(ctx context.Context, userID string, categories []string) ([]DatedAmount, error)
+
+	// SpendingByCategoryInRange группирует траты всех категорий по категории,
+	// валюте и дате одним запросом; категории без трат в диапазоне не попадают.
+	SpendingByCategoryInRange(ctx context.Context, userID string, from, to time.Time) (map[string][]DatedAmount, error)
+	AmountsByCategoryInRange(ctx context.Context, userID string, categories []string, from, to time.Time) ([]DatedAmount, error)
+	// AmountsByTagInRange группирует траты (расходы минус возвраты) по метке,
+	// валюте и дате; транзакция учитывается в каждой своей метке.
+	AmountsByTagInRange(ctx context.Context, userID string, from, to time.Time) (map[string][]DatedAmount, error)
+	// AmountsByKindInRange группирует доходы, расходы и возвраты по виду, валюте и дате.
+	AmountsByKindInRange(ctx context.Context, userID string, from, to time.Time) ([]DatedAmount, error)
+	// LinkTransfer связывает две половины перевода друг с другом.
+	LinkTransfer(ctx context.Context, a, b int) error
+	// BalanceAmounts группирует изменения балансов счетов (Transaction.BalanceEffect)
+	// по счёту, валюте и дате до on включительно.
+	BalanceAmounts(ctx context.Context, userID string, on time.Time) (map[int][]DatedAmount, error)
+}
+
+type AccountRepo interface {
+	// Insert сохраняет счёт; первый счёт пользователя становится счётом по умолчанию.
+	Insert(ctx context.Context, a Account) (Account, error)
+	Get(ctx context.Context, id int) (Account, bool, error)
+	List(ctx context.Context, userID string) ([]Account, error)
+	// EnsureDefault возвращает счёт по умолчанию, создавая его при первом обращении.
+	EnsureDefault(ctx context.Context, userID, currency string) (Account, error)
+}
+
+type JournalRepo interface {
+	Post(ctx context.Context, e JournalEntry) (int, error)
+	// TrialBalance возвращает обороты по счетам главной книги за все даты до on включительно.
+	TrialBalance(ctx context.Context, userID string, on time.Time) ([]TrialBalanceLine, error)
+	// Statement возвращает сумму проводок счёта в валюте currency до from и
+	// проводки за [from, to] в порядке даты и записи; Balance в строках не заполняется.
+	Statement(ctx context.Context, userID, account, currency string, from, to time.Time) (Money, []StatementLine, error)
+}
+
+type RecurringRepo interface {
+	Insert(ctx context.Context, r Recurring) (Recurring, error)
+	Get(ctx context.Context, id int) (Recurring, bool, error)
+	// GetForUpdate блокирует шаблон пользователя до конца транзакции, как и
+	// ClaimDue, поэтому правка не затрёт NextDate, сдвинутую планировщиком.
+	GetForUpdate(ctx context.Context, userID string, id int) (Recurring, bool, error)
+	List(ctx context.Context, userID string) ([]Recurring, error)
+	// Update сохраняет паузу и дату следующего повторения.
+	Update(ctx context.Context, r Recurring) error
+	// ClaimDue блокирует до конца транзакции и возвращает один активный шаблон
+	// с NextDate <= on, пропуская шаблоны, заблокированные другими процессами,
+	// и шаблоны с id из skip.
+	ClaimDue(ctx context.Context, on time.Time, skip []int) (Recurring, bool, error)
+	// ClaimOccurrence занимает повторение шаблона на дату on; false — оно уже было.
+	ClaimOccurrence(ctx context.Context, recurringID int, on time.Time) (bool, error)
+	// SetOccurrenceResult записывает созданную транзакцию или причину, по которой её нет.
+	SetOccurrenceResult(ctx context.Context, recurringID int, on time.Time, transactionID int, errMsg string) error
+}
+
+type CategoryRepo interface {
+	Insert(ctx context.Context, c Category) (Category, error)
+	Get(ctx context.Context, id int) (Category, bool, error)
+	List(ctx context.Context, userID string) ([]Category, error)
+	Update(ctx context.Context, c Category) error
+	Delete(ctx context.Context, id int) error
+	// Repoint переносит транзакции, шаблоны повторений, правила, бюджет и
+	// алиасы пользователя из категории from в to; если у to уже есть бюджет,
+	// бюджет from удаляется, алиас с именем to удаляется.
+	Repoint(ctx context.Context, userID, from, to string) error
+	// Usage возвращает все имена категорий пользователя — из транзакций,
+	// бюджетов, шаблонов, правил и дерева — с числом транзакций.
+	Usage(ctx context.Context, userID string) (map[string]int, error)
+
+	// SetAlias создаёт или меняет алиас; алиасы, указывавшие на a.Alias,
+	// перенаправляются на a.Category.
+	SetAlias(ctx context.Context, a CategoryAlias) error
+	ResolveAlias(ctx context.Context, userID, alias string) (string, bool, error)
+	ListAliases(ctx context.Context, userID string) ([]CategoryAlias, error)
+	DeleteAlias(ctx context.Context, userID, alias string) (bool, error)
+}
+
+type RuleRepo interface {
+	Insert(ctx context.Context, r Rule) (Rule, error)
+	Get(ctx context.Context, id int) (Rule, bool, error)
+	// List возвращает все правила пользователя, включая выключенные.
+	List(ctx context.Context, userID string) ([]Rule, error)
+	Update(ctx context.Context, r Rule) error
+	Delete(ctx context.Context, id int) error
+}
+
+type SettingsRepo interface {
+	Get(ctx context.Context, userID string) (UserSettings, bool, error)
+	Upsert(ctx context.Context, s UserSettings) error
+}
 
-	ListCategoriesInRange(ctx context.Context, from, to time.Time) ([]string, error)
-	SumByCategoryInRange(ctx context.Context, category string, from, to time.Time) (float64, error)
+type RateRepo interface {
+	// Rate возвращает последний известный курс base→quote на дату on или раньше.
+	Rate(ctx context.Context, base, quote string, on time.Time) (*big.Rat, bool, error)
+	// RatesInRange одним запросом возвращает курсы между валютами currencies
+	// за даты from..to и последний курс каждой такой пары до from — всё, что
+	// нужно, чтобы ответить на Rate для любой даты диапазона.
+	RatesInRange(ctx context.Context, currencies []string, from, to time.Time) ([]ExchangeRate, error)
+	Upsert(ctx context.Context, rates []ExchangeRate) error
 }
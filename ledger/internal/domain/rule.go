@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const MaxRuleRegexLen = 500
+
+// Rule — правило автокатегоризации. Транзакция подходит под правило, если
+// выполнены все заданные условия; пустое условие не проверяется.
+type Rule struct {
+	ID     int
+	UserID string
+	Name   string
+	// Priority — порядок проверки: меньше — раньше, при равенстве — по ID.
+	Priority int
+
+	// DescriptionContains — подстрока описания без учёта регистра.
+	DescriptionContains string
+	// DescriptionRegex — регулярное выражение (RE2) по описанию без учёта регистра.
+	DescriptionRegex string
+	// Merchant — слова, которые идут в описании подряд: «yandex taxi»
+	// подходит к «YANDEX*TAXI MOSCOW», но не к «yandextaxi».
+	Merchant string
+	// MinAmount и MaxAmount — границы суммы в валюте транзакции; 0 — без границы.
+	MinAmount Money
+	MaxAmount Money
+
+	Category string
+	Tags     []string
+	Disabled bool
+}
+
+func (r Rule) Validate() error {
+	if strings.TrimSpace(r.DescriptionContains) == "" && strings.TrimSpace(r.DescriptionRegex) == "" &&
+		strings.TrimSpace(r.Merchant) == "" && r.MinAmount == 0 && r.MaxAmount == 0 {
+		return errors.New("rule has no conditions")
+	}
+	if len(r.DescriptionRegex) > MaxRuleRegexLen {
+		return errors.New("regex is too long")
+	}
+	if r.DescriptionRegex != "" {
+		if _, err := regexp.Compile(r.DescriptionRegex); err != nil {
+			return errors.New("invalid regex")
+		}
+	}
+	if r.MinAmount < 0 || r.MaxAmount < 0 || (r.MaxAmount != 0 && r.MinAmount > r.MaxAmount) {
+		return errors.New("invalid amount range")
+	}
+	if strings.TrimSpace(r.Category) == "" {
+		return errors.New("rule category is empty")
+	}
+	return ValidateTags(r.Tags)
+}
+
+// RuleSet — включённые правила пользователя в порядке проверки.
+type RuleSet struct {
+	rules []compiledRule
+}
+
+type compiledRule struct {
+	Rule
+	contains string
+	re       *regexp.Regexp
+	merchant []string
+}
+
+// NewRuleSet отбрасывает выключенные правила и правила с некорректным
+// выражением (такие не проходят Validate) и сортирует остальные по приоритету.
+func NewRuleSet(rules []Rule) RuleSet {
+	var s RuleSet
+	for _, r := range rules {
+		if r.Disabled {
+			continue
+		}
+		c := compiledRule{
+			Rule:     r,
+			contains: NormalizeCategory(r.DescriptionContains),
+			merchant: words(r.Merchant),
+		}
+		if r.DescriptionRegex != "" {
+			re, err := regexp.Compile("(?i)" + r.DescriptionRegex)
+			if err != nil {
+				continue
+			}
+			c.re = re
+		}
+		s.rules = append(s.rules, c)
+	}
+	sort.SliceStable(s.rules, func(i, j int) bool {
+		if s.rules[i].Priority != s.rules[j].Priority {
+			return s.rules[i].Priority < s.rules[j].Priority
+		}
+		return s.rules[i].ID < s.rules[j].ID
+	})
+	return s
+}
+
+// Match возвращает первое подходящее правило — то, которое сработает.
+func (s RuleSet) Match(t Transaction) (Rule, bool) {
+	desc := NormalizeCategory(t.Description)
+	for _, r := range s.rules {
+		if r.matches(t, desc) {
+			return r.Rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Matching возвращает все подходящие правила в порядке проверки.
+func (s RuleSet) Matching(t Transaction) []Rule {
+	desc := NormalizeCategory(t.Description)
+	var out []Rule
+	for _, r := range s.rules {
+		if r.matches(t, desc) {
+			out = append(out, r.Rule)
+		}
+	}
+	return out
+}
+
+func (r compiledRule) matches(t Transaction, desc string) bool {
+	if r.contains != "" && !strings.Contains(desc, r.contains) {
+		return false
+	}
+	if r.re != nil && !r.re.MatchString(t.Description) {
+		return false
+	}
+	if len(r.merchant) > 0 && !containsWords(words(desc), r.merchant) {
+		return false
+	}
+	if r.MinAmount != 0 && t.Amount < r.MinAmount {
+		return false
+	}
+	if r.MaxAmount != 0 && t.Amount > r.MaxAmount {
+		return false
+	}
+	return true
+}
+
+// words разбивает нормализованный текст на слова из букв и цифр.
+func words(s string) []string {
+	return strings.FieldsFunc(NormalizeCategory(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func containsWords(text, seq []string) bool {
+	for i := 0; i+len(seq) <= len(text); i++ {
+		if slices.Equal(text[i:i+len(seq)], seq) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+)
+
+const (
+	MaxTags   = 20
+	MaxTagLen = 64
+)
+
+func ValidateTags(tags []string) error {
+	if len(tags) > MaxTags {
+		return errors.New("too many tags")
+	}
+	for _, t := range tags {
+		if NormalizeCategory(t) == "" {
+			return errors.New("tag is empty")
+		}
+		if len(t) > MaxTagLen {
+			return errors.New("tag is too long")
+		}
+	}
+	return nil
+}
+
+// NormalizeTags нормализует метки так же, как категории, и убирает повторы.
+// Для пустого списка возвращает nil.
+func NormalizeTags(tags []string) []string {
+	var out []string
+	for _, t := range tags {
+		if t = NormalizeCategory(t); t != "" && !slices.Contains(out, t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// HasAnyTag сообщает, что у транзакции есть хотя бы одна из меток.
+func (t Transaction) HasAnyTag(tags []string) bool {
+	for _, tag := range t.Tags {
+		if slices.Contains(tags, tag) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	BucketDay   = "day"
+	BucketWeek  = "week"
+	BucketMonth = "month"
+	BucketYear  = "year"
+)
+
+// MaxSeriesPoints ограничивает длину ряда: два с половиной года по дням.
+const MaxSeriesPoints = 1000
+
+func IsValidBucket(b string) bool {
+	switch b {
+	case "", BucketDay, BucketWeek, BucketMonth, BucketYear:
+		return true
+	default:
+		return false
+	}
+}
+
+// SeriesQuery — параметры ряда трат. From и To — даты (полночь UTC);
+// пустой To — сегодня в часовом поясе Timezone.
+type SeriesQuery struct {
+	From       time.Time
+	To         time.Time
+	Bucket     string
+	Timezone   string
+	ByCategory bool
+}
+
+// Location возвращает часовой пояс запроса; пустой — UTC.
+func (q SeriesQuery) Location() (*time.Location, error) {
+	if q.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(q.Timezone)
+	if err != nil {
+		return nil, errors.New("invalid timezone")
+	}
+	return loc, nil
+}
+
+// ParseDay разбирает дату YYYY-MM-DD или момент RFC3339; момент переводится
+// в дату часового пояса loc.
+func ParseDay(s string, loc *time.Location) (time.Time, error) {
+	if d, err := time.Parse(time.DateOnly, s); err == nil {
+		return d, nil
+	}
+	at, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	local := at.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// SeriesPoint — траты корзины [From, To]; крайние корзины обрезаются по
+// диапазону ряда. Label — начало корзины: 2025-12-01, 2025-W49, 2025-12, 2025.
+type SeriesPoint struct {
+	From  time.Time
+	To    time.Time
+	Label string
+	Total Money
+	// ByCategory заполняется при разбивке по категориям; в каждой точке есть
+	// все категории ряда, пропуски — нули.
+	ByCategory map[string]Money
+}
+
+type TimeSeries struct {
+	Bucket   string
+	Currency string
+	// Categories — категории разбивки по алфавиту; nil без разбивки.
+	Categories []string
+	Points     []SeriesPoint
+}
+
+// NewTimeSeries размечает [from, to] на корзины bucket (по умолчанию month)
+// с нулевыми суммами. Недели — ISO, с понедельника.
+func NewTimeSeries(from, to time.Time, bucket string) (TimeSeries, error) {
+	if bucket == "" {
+		bucket = BucketMonth
+	}
+	if !IsValidBucket(bucket) {
+		return TimeSeries{}, errors.New("invalid bucket")
+	}
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	if from.After(to) {
+		return TimeSeries{}, errors.New("from must be <= to")
+	}
+
+	s := TimeSeries{Bucket: bucket}
+	for day := from; !day.After(to); {
+		if len(s.Points) == MaxSeriesPoints {
+			return TimeSeries{}, errors.New("too many buckets")
+		}
+		start, end := bucketRange(day, bucket)
+		p := SeriesPoint{From: day, To: end, Label: bucketLabel(start, bucket)}
+		if p.To.After(to) {
+			p.To = to
+		}
+		s.Points = append(s.Points, p)
+		day = end.AddDate(0, 0, 1)
+	}
+	return s, nil
+}
+
+// SplitBy включает разбивку по категориям с нулями во всех точках.
+func (s *TimeSeries) SplitBy(categories []string) {
+	s.Categories = append([]string{}, categories...)
+	sort.Strings(s.Categories)
+	for i := range s.Points {
+		s.Points[i].ByCategory = make(map[string]Money, len(s.Categories))
+		for _, c := range s.Categories {
+			s.Points[i].ByCategory[c] = 0
+		}
+	}
+}
+
+// Add относит сумму категории к корзине её даты; даты вне ряда пропускаются.
+func (s *TimeSeries) Add(category string, date time.Time, m Money) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	i := sort.Search(len(s.Points), func(i int) bool { return !s.Points[i].To.Before(day) })
+	if i == len(s.Points) || day.Before(s.Points[i].From) {
+		return
+	}
+	s.Points[i].Total += m
+	if s.Points[i].ByCategory != nil {
+		s.Points[i].ByCategory[category] += m
+	}
+}
+
+func bucketRange(day time.Time, bucket string) (time.Time, time.Time) {
+	grid := Budget{}
+	switch bucket {
+	case BucketDay:
+		return day, day
+	case BucketWeek:
+		grid.Period = PeriodWeekly
+	case BucketYear:
+		grid.Period = PeriodYearly
+	default:
+		grid.Period = PeriodMonthly
+	}
+	start, end, _ := grid.periodOf(day)
+	return start, end
+}
+
+func bucketLabel(start time.Time, bucket string) string {
+	switch bucket {
+	case BucketDay:
+		return start.Format(time.DateOnly)
+	case BucketWeek:
+		year, week := start.ISOWeek()
+		if week < 10 {
+			return strconv.Itoa(year) + "-W0" + strconv.Itoa(week)
+		}
+		return strconv.Itoa(year) + "-W" + strconv.Itoa(week)
+	case BucketYear:
+		return start.Format("2006")
+	default:
+		return start.Format("2006-01")
+	}
+}
@@ -0,0 +1,85 @@
+package domain
+
+import "sort"
+
+const (
+	EnforcementHard = "hard"
+	EnforcementSoft = "soft"
+	EnforcementOff  = "off"
+)
+
+func IsValidEnforcement(m string) bool {
+	switch m {
+	case "", EnforcementHard, EnforcementSoft, EnforcementOff:
+		return true
+	default:
+		return false
+	}
+}
+
+type BudgetWarning struct {
+	Category  string
+	Threshold int
+	Limit     Money
+	Spent     Money
+	Currency  string
+	Percent   float64
+	Exceeded  bool
+}
+
+// Evaluate проверяет трату amount при уже потраченных spent за период;
+// обе суммы должны быть в валюте бюджета.
+// Возвращает пороги, которые пересекает именно эта трата, и признак того,
+// что трату нужно отклонить (только в режиме hard).
+func (b Budget) Evaluate(spent, amount Money) (warnings []BudgetWarning, reject bool) {
+	if b.Enforcement == EnforcementOff || b.Limit <= 0 {
+		return nil, false
+	}
+
+	after := spent + amount
+	exceeded := after > b.Limit
+	if exceeded && b.Enforcement != EnforcementSoft {
+		return nil, true
+	}
+
+	percent := after.Percent(b.Limit)
+
+	thresholds := append([]int(nil), b.WarnThresholds...)
+	sort.Ints(thresholds)
+
+	crossedLimit := false
+	for _, th := range thresholds {
+		// Сравнение в целых: spent/limit < th% <= after/limit.
+		mark := b.Limit * Money(th)
+		if spent*100 < mark && after*100 >= mark {
+			warnings = append(warnings, BudgetWarning{
+				Category:  b.Category,
+				Threshold: th,
+				Limit:     b.Limit,
+				Spent:     after,
+				Currency:  b.Currency,
+				Percent:   percent,
+				Exceeded:  exceeded,
+			})
+			if th >= 100 {
+				crossedLimit = true
+			}
+		}
+	}
+
+	// В мягком режиме о превышении лимита сообщаем на каждую трату сверх
+	// лимита, даже если порог 100% не настроен.
+	if exceeded && !crossedLimit {
+		warnings = append(warnings, BudgetWarning{
+			Category:  b.Category,
+			Threshold: 100,
+			Limit:     b.Limit,
+			Spent:     after,
+			Currency:  b.Currency,
+			Percent:   percent,
+			Exceeded:  true,
+		})
+	}
+
+	return warnings, false
+}
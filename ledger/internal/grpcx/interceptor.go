@@ -9,13 +9,32 @@ import (
 
 func UserIDUnaryInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-		md, ok := metadata.FromIncomingContext(ctx)
-		if ok {
-			vals := md.Get("x-user-id")
-			if len(vals) > 0 && vals[0] != "" {
-				ctx = WithUserID(ctx, vals[0])
-			}
+		return handler(userIDFromMetadata(ctx), req)
+	}
+}
+
+func UserIDStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &userStream{ServerStream: ss, ctx: userIDFromMetadata(ss.Context())})
+	}
+}
+
+type userStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *userStream) Context() context.Context {
+	return s.ctx
+}
+
+func userIDFromMetadata(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		vals := md.Get("x-user-id")
+		if len(vals) > 0 && vals[0] != "" {
+			ctx = WithUserID(ctx, vals[0])
 		}
-		return handler(ctx, req)
 	}
+	return ctx
 }
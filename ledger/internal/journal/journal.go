@@ -0,0 +1,128 @@
+// Package journal ведёт журнал двойной записи поверх хранилищ транзакций и
+// счетов: каждое изменение транзакции отражается сбалансированной записью,
+// исправления и удаления — сторнированием.
+package journal
+
+import (
+	"context"
+	"time"
+
+	"final/ledger/internal/domain"
+)
+
+// ExpenseRepo дописывает журнал при изменении транзакций; чтение идёт
+// напрямую во внутреннее хранилище.
+type ExpenseRepo struct {
+	domain.ExpenseRepo
+	journal domain.JournalRepo
+	tx      domain.Transactor
+}
+
+func NewExpenseRepo(inner domain.ExpenseRepo, j domain.JournalRepo, tx domain.Transactor) *ExpenseRepo {
+	return &ExpenseRepo{ExpenseRepo: inner, journal: j, tx: tx}
+}
+
+func (r *ExpenseRepo) Insert(ctx context.Context, t domain.Transaction) (int, error) {
+	var id int
+	err := r.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if id, err = r.ExpenseRepo.Insert(ctx, t); err != nil {
+			return err
+		}
+		t.ID = id
+		_, err = r.journal.Post(ctx, domain.EntryFor(t))
+		return err
+	})
+	return id, err
+}
+
+// Update сторнирует запись прежней версии транзакции и записывает новую.
+func (r *ExpenseRepo) Update(ctx context.Context, t domain.Transaction) error {
+	return r.tx.WithinTx(ctx, func(ctx context.Context) error {
+		old, ok, err := r.ExpenseRepo.GetForUpdate(ctx, t.UserID, t.ID)
+		if err != nil || !ok {
+			return err
+		}
+		if err := r.ExpenseRepo.Update(ctx, t); err != nil {
+			return err
+		}
+		if _, err := r.journal.Post(ctx, domain.EntryFor(old).Reversal()); err != nil {
+			return err
+		}
+		_, err = r.journal.Post(ctx, domain.EntryFor(t))
+		return err
+	})
+}
+
+func (r *ExpenseRepo) Delete(ctx context.Context, userID string, id int) error {
+	return r.tx.WithinTx(ctx, func(ctx context.Context) error {
+		old, ok, err := r.ExpenseRepo.GetForUpdate(ctx, userID, id)
+		if err != nil || !ok {
+			return err
+		}
+		if _, err := r.journal.Post(ctx, domain.EntryFor(old).Reversal()); err != nil {
+			return err
+		}
+		return r.ExpenseRepo.Delete(ctx, userID, id)
+	})
+}
+
+// AccountRepo записывает остаток на открытии нового счёта.
+type AccountRepo struct {
+	domain.AccountRepo
+	journal domain.JournalRepo
+	tx      domain.Transactor
+}
+
+func NewAccountRepo(inner domain.AccountRepo, j domain.JournalRepo, tx domain.Transactor) *AccountRepo {
+	return &AccountRepo{AccountRepo: inner, journal: j, tx: tx}
+}
+
+func (r *AccountRepo) Insert(ctx context.Context, a domain.Account) (domain.Account, error) {
+	var out domain.Account
+	err := r.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if out, err = r.AccountRepo.Insert(ctx, a); err != nil {
+			return err
+		}
+		if e, ok := domain.OpeningEntry(out, time.Now().UTC()); ok {
+			_, err = r.journal.Post(ctx, e)
+		}
+		return err
+	})
+	return out, err
+}
+
+// CategoryRepo переносит в журнале накопленные обороты категории при её
+// переименовании или слиянии: записи прошлых периодов не меняются, вместо
+// этого делается запись реклассификации текущей датой.
+type CategoryRepo struct {
+	domain.CategoryRepo
+	journal domain.JournalRepo
+	tx      domain.Transactor
+}
+
+func NewCategoryRepo(inner domain.CategoryRepo, j domain.JournalRepo, tx domain.Transactor) *CategoryRepo {
+	return &CategoryRepo{CategoryRepo: inner, journal: j, tx: tx}
+}
+
+// allTime — дата, не раньше которой нет ни одной записи журнала.
+var allTime = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+func (r *CategoryRepo) Repoint(ctx context.Context, userID, from, to string) error {
+	return r.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := r.CategoryRepo.Repoint(ctx, userID, from, to); err != nil {
+			return err
+		}
+		lines, err := r.journal.TrialBalance(ctx, userID, allTime)
+		if err != nil {
+			return err
+		}
+		e := domain.ReclassEntry(userID, from, to, lines, time.Now().UTC())
+		if len(e.Postings) == 0 {
+			return nil
+		}
+		_, err = r.journal.Post(ctx, e)
+		return err
+	})
+}
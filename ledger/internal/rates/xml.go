@@ -0,0 +1,144 @@
+// Package rates разбирает выгрузки курсов валют ЦБ РФ и ЕЦБ.
+package rates
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/charmap"
+
+	"final/ledger/internal/domain"
+)
+
+// Parse читает XML с курсами и определяет формат по корневому элементу:
+//   - ValCurs — ежедневные курсы ЦБ РФ (XML_daily.asp): рубли за Nominal единиц валюты;
+//   - Envelope — курсы ЕЦБ (eurofxref-daily.xml, eurofxref-hist.xml): валюта за 1 евро.
+func Parse(r io.Reader) ([]domain.ExchangeRate, error) {
+	dec := xml.NewDecoder(r)
+	dec.CharsetReader = charsetReader
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, errors.New("rates: empty document")
+			}
+			return nil, err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "ValCurs":
+			return parseCBR(dec, start)
+		case "Envelope":
+			return parseECB(dec, start)
+		default:
+			return nil, fmt.Errorf("rates: unknown format %q", start.Name.Local)
+		}
+	}
+}
+
+func charsetReader(label string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(label) {
+	case "windows-1251", "cp1251":
+		return charmap.Windows1251.NewDecoder().Reader(input), nil
+	case "utf-8", "utf8":
+		return input, nil
+	default:
+		return nil, fmt.Errorf("rates: unsupported charset %q", label)
+	}
+}
+
+type cbrValCurs struct {
+	Date    string `xml:"Date,attr"`
+	Valutes []struct {
+		CharCode string `xml:"CharCode"`
+		Nominal  string `xml:"Nominal"`
+		Value    string `xml:"Value"`
+	} `xml:"Valute"`
+}
+
+func parseCBR(dec *xml.Decoder, start xml.StartElement) ([]domain.ExchangeRate, error) {
+	var doc cbrValCurs
+	if err := dec.DecodeElement(&doc, &start); err != nil {
+		return nil, err
+	}
+	date, err := time.Parse("02.01.2006", doc.Date)
+	if err != nil {
+		return nil, fmt.Errorf("rates: invalid CBR date %q", doc.Date)
+	}
+
+	out := make([]domain.ExchangeRate, 0, len(doc.Valutes))
+	for _, v := range doc.Valutes {
+		value, err := parseDecimal(v.Value)
+		if err != nil {
+			return nil, fmt.Errorf("rates: %s: %w", v.CharCode, err)
+		}
+		nominal, err := parseDecimal(v.Nominal)
+		if err != nil || nominal.Sign() <= 0 {
+			return nil, fmt.Errorf("rates: %s: invalid nominal %q", v.CharCode, v.Nominal)
+		}
+		out = append(out, domain.ExchangeRate{
+			Base:  domain.NormalizeCurrency(v.CharCode),
+			Quote: "RUB",
+			Date:  date,
+			Rate:  value.Quo(value, nominal),
+		})
+	}
+	return out, nil
+}
+
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+func parseECB(dec *xml.Decoder, start xml.StartElement) ([]domain.ExchangeRate, error) {
+	var doc ecbEnvelope
+	if err := dec.DecodeElement(&doc, &start); err != nil {
+		return nil, err
+	}
+
+	var out []domain.ExchangeRate
+	for _, d := range doc.Days {
+		date, err := time.Parse("2006-01-02", d.Time)
+		if err != nil {
+			return nil, fmt.Errorf("rates: invalid ECB date %q", d.Time)
+		}
+		for _, r := range d.Rates {
+			rate, err := parseDecimal(r.Rate)
+			if err != nil {
+				return nil, fmt.Errorf("rates: %s: %w", r.Currency, err)
+			}
+			out = append(out, domain.ExchangeRate{
+				Base:  "EUR",
+				Quote: domain.NormalizeCurrency(r.Currency),
+				Date:  date,
+				Rate:  rate,
+			})
+		}
+	}
+	return out, nil
+}
+
+// parseDecimal понимает и запятую (ЦБ), и точку (ЕЦБ) как разделитель.
+func parseDecimal(s string) (*big.Rat, error) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", ".")
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || strings.ContainsAny(s, "/eE") {
+		return nil, fmt.Errorf("invalid number %q", s)
+	}
+	return r, nil
+}
@@ -0,0 +1,75 @@
+package rates
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+func TestParseCBR(t *testing.T) {
+	doc := `<?xml version="1.0" encoding="windows-1251"?>
+<ValCurs Date="19.12.2025" name="Foreign Currency Market">
+<Valute ID="R01235"><NumCode>840</NumCode><CharCode>USD</CharCode><Nominal>1</Nominal><Name>Доллар США</Name><Value>79,7296</Value></Valute>
+<Valute ID="R01820"><NumCode>392</NumCode><CharCode>JPY</CharCode><Nominal>100</Nominal><Name>Японских иен</Name><Value>51,2345</Value></Valute>
+</ValCurs>`
+	encoded, err := charmap.Windows1251.NewEncoder().String(doc)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	got, err := Parse(bytes.NewReader([]byte(encoded)))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 rates, got %d", len(got))
+	}
+	usd := got[0]
+	if usd.Base != "USD" || usd.Quote != "RUB" || !usd.Date.Equal(time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected rate: %+v", usd)
+	}
+	if usd.Rate.Cmp(big.NewRat(797296, 10000)) != 0 {
+		t.Fatalf("unexpected USD rate %s", usd.Rate.FloatString(4))
+	}
+	if got[1].Rate.Cmp(big.NewRat(512345, 100*10000)) != 0 {
+		t.Fatalf("nominal must be applied, got %s", got[1].Rate.FloatString(6))
+	}
+}
+
+func TestParseECB(t *testing.T) {
+	doc := `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time="2025-12-19">
+			<Cube currency="USD" rate="1.0412"/>
+			<Cube currency="GBP" rate="0.8301"/>
+		</Cube>
+		<Cube time="2025-12-18">
+			<Cube currency="USD" rate="1.0398"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+	got, err := Parse(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 rates, got %d", len(got))
+	}
+	last := got[2]
+	if last.Base != "EUR" || last.Quote != "USD" || last.Date.Day() != 18 || last.Rate.Cmp(big.NewRat(10398, 10000)) != 0 {
+		t.Fatalf("unexpected rate: %+v", last)
+	}
+}
+
+func TestParseUnknown(t *testing.T) {
+	if _, err := Parse(strings.NewReader(`<rates/>`)); err == nil {
+		t.Fatalf("expected error for unknown format")
+	}
+}
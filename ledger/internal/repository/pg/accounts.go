@@ -0,0 +1,89 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+
+	"final/ledger/internal/domain"
+)
+
+type AccountRepo struct {
+	db *sql.DB
+}
+
+func NewAccountRepo(db *sql.DB) *AccountRepo {
+	return &AccountRepo{db: db}
+}
+
+const accountColumns = `id, user_id, name, currency, opening_balance, is_default`
+
+func scanAccount(row interface{ Scan(...any) error }) (domain.Account, error) {
+	var a domain.Account
+	err := row.Scan(&a.ID, &a.UserID, &a.Name, &a.Currency, &a.OpeningBalance, &a.IsDefault)
+	return a, err
+}
+
+func (r *AccountRepo) Insert(ctx context.Context, a domain.Account) (domain.Account, error) {
+	return scanAccount(conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO accounts(user_id, name, currency, opening_balance, is_default)
+		 VALUES($1,$2,$3,$4, NOT EXISTS (SELECT 1 FROM accounts WHERE user_id=$1 AND is_default))
+		 RETURNING `+accountColumns,
+		a.UserID, a.Name, a.Currency, a.OpeningBalance,
+	))
+}
+
+func (r *AccountRepo) Get(ctx context.Context, id int) (domain.Account, bool, error) {
+	a, err := scanAccount(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+accountColumns+` FROM accounts WHERE id=$1`,
+		id,
+	))
+	if err == sql.ErrNoRows {
+		return domain.Account{}, false, nil
+	}
+	if err != nil {
+		return domain.Account{}, false, err
+	}
+	return a, true, nil
+}
+
+func (r *AccountRepo) List(ctx context.Context, userID string) ([]domain.Account, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+accountColumns+` FROM accounts WHERE user_id=$1 ORDER BY id`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]domain.Account, 0)
+	for rows.Next() {
+		a, err := scanAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *AccountRepo) EnsureDefault(ctx context.Context, userID, currency string) (domain.Account, error) {
+	// Частичный уникальный индекс по is_default не даёт параллельным
+	// запросам создать два счёта по умолчанию.
+	if _, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO accounts(user_id, name, currency, is_default)
+		 SELECT $1, $2, $3, true
+		 WHERE NOT EXISTS (SELECT 1 FROM accounts WHERE user_id=$1 AND is_default)
+		 ON CONFLICT DO NOTHING`,
+		userID, domain.DefaultAccountName, currency,
+	); err != nil {
+		return domain.Account{}, err
+	}
+	return scanAccount(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+accountColumns+` FROM accounts WHERE user_id=$1 AND is_default`,
+		userID,
+	))
+}
@@ -3,6 +3,7 @@ package pg
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 
 	"final/ledger/internal/domain"
 )
@@ -16,29 +17,67 @@ func NewBudgetRepo(db *sql.DB) *BudgetRepo {
 }
 
 func (r *BudgetRepo) Upsert(ctx context.Context, b domain.Budget) error {
-	_, err := r.db.ExecContext(ctx,
-		`INSERT INTO budgets(category, limit_amount)
-		 VALUES($1,$2)
-		 ON CONFLICT(category) DO UPDATE SET limit_amount=EXCLUDED.limit_amount`,
-		b.Category, b.Limit,
+	thresholds := b.WarnThresholds
+	if thresholds == nil {
+		thresholds = []int{}
+	}
+	th, err := json.Marshal(thresholds)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO budgets(user_id, category, limit_amount, currency, period, start_day, timezone, enforcement, warn_thresholds)
+		 VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9)
+		 ON CONFLICT(user_id, category) DO UPDATE SET
+		   limit_amount=EXCLUDED.limit_amount,
+		   currency=EXCLUDED.currency,
+		   period=EXCLUDED.period,
+		   start_day=EXCLUDED.start_day,
+		   timezone=EXCLUDED.timezone,
+		   enforcement=EXCLUDED.enforcement,
+		   warn_thresholds=EXCLUDED.warn_thresholds`,
+		b.UserID, b.Category, b.Limit, b.Currency, b.Period, b.StartDay, b.Timezone, b.Enforcement, string(th),
 	)
 	return err
 }
 
-func (r *BudgetRepo) GetLimit(ctx context.Context, category string) (float64, bool, error) {
-	var lim float64
-	err := r.db.QueryRowContext(ctx, `SELECT limit_amount FROM budgets WHERE category=$1`, category).Scan(&lim)
+func (r *BudgetRepo) Get(ctx context.Context, userID, category string) (domain.Budget, bool, error) {
+	return r.get(ctx, userID, category, "")
+}
+
+// GetForUpdate блокирует строку бюджета до конца транзакции, чтобы
+// параллельные вставки в ту же категорию проверяли лимит по очереди.
+func (r *BudgetRepo) GetForUpdate(ctx context.Context, userID, category string) (domain.Budget, bool, error) {
+	return r.get(ctx, userID, category, " FOR UPDATE")
+}
+
+func (r *BudgetRepo) get(ctx context.Context, userID, category, lock string) (domain.Budget, bool, error) {
+	b := domain.Budget{UserID: userID, Category: category}
+	var th []byte
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT limit_amount, currency, period, start_day, timezone, enforcement, warn_thresholds
+		 FROM budgets WHERE user_id=$1 AND category=$2`+lock,
+		userID, category,
+	).Scan(&b.Limit, &b.Currency, &b.Period, &b.StartDay, &b.Timezone, &b.Enforcement, &th)
 	if err == sql.ErrNoRows {
-		return 0, false, nil
+		return domain.Budget{}, false, nil
 	}
 	if err != nil {
-		return 0, false, err
+		return domain.Budget{}, false, err
+	}
+	if err := json.Unmarshal(th, &b.WarnThresholds); err != nil {
+		return domain.Budget{}, false, err
 	}
-	return lim, true, nil
+	return b, true, nil
 }
 
-func (r *BudgetRepo) List(ctx context.Context) ([]domain.Budget, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT category, limit_amount FROM budgets ORDER BY category`)
+func (r *BudgetRepo) List(ctx context.Context, userID string) ([]domain.Budget, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT category, limit_amount, currency, period, start_day, timezone, enforcement, warn_thresholds
+		 FROM budgets WHERE user_id=$1 ORDER BY category`,
+		userID,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -46,12 +85,15 @@ func (r *BudgetRepo) List(ctx context.Context) ([]domain.Budget, error) {
 
 	out := make([]domain.Budget, 0)
 	for rows.Next() {
-		var cat string
-		var lim float64
-		if err := rows.Scan(&cat, &lim); err != nil {
+		b := domain.Budget{UserID: userID}
+		var th []byte
+		if err := rows.Scan(&b.Category, &b.Limit, &b.Currency, &b.Period, &b.StartDay, &b.Timezone, &b.Enforcement, &th); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(th, &b.WarnThresholds); err != nil {
 			return nil, err
 		}
-		out = append(out, domain.Budget{Category: cat, Limit: lim, Period: "fixed"})
+		out = append(out, b)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
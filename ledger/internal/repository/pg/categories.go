@@ -0,0 +1,202 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+
+	"final/ledger/internal/domain"
+)
+
+type CategoryRepo struct {
+	db *sql.DB
+}
+
+func NewCategoryRepo(db *sql.DB) *CategoryRepo {
+	return &CategoryRepo{db: db}
+}
+
+const categoryColumns = `id, user_id, name, COALESCE(parent_id, 0), icon, archived`
+
+func scanCategory(row interface{ Scan(...any) error }) (domain.Category, error) {
+	var c domain.Category
+	err := row.Scan(&c.ID, &c.UserID, &c.Name, &c.ParentID, &c.Icon, &c.Archived)
+	return c, err
+}
+
+func (r *CategoryRepo) Insert(ctx context.Context, c domain.Category) (domain.Category, error) {
+	return scanCategory(conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO categories(user_id, name, parent_id, icon, archived)
+		 VALUES($1, $2, NULLIF($3, 0), $4, $5)
+		 RETURNING `+categoryColumns,
+		c.UserID, c.Name, c.ParentID, c.Icon, c.Archived,
+	))
+}
+
+func (r *CategoryRepo) Get(ctx context.Context, id int) (domain.Category, bool, error) {
+	c, err := scanCategory(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+categoryColumns+` FROM categories WHERE id=$1`,
+		id,
+	))
+	if err == sql.ErrNoRows {
+		return domain.Category{}, false, nil
+	}
+	if err != nil {
+		return domain.Category{}, false, err
+	}
+	return c, true, nil
+}
+
+func (r *CategoryRepo) List(ctx context.Context, userID string) ([]domain.Category, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+categoryColumns+` FROM categories WHERE user_id=$1 ORDER BY name`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]domain.Category, 0)
+	for rows.Next() {
+		c, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *CategoryRepo) Update(ctx context.Context, c domain.Category) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE categories SET name=$2, parent_id=NULLIF($3, 0), icon=$4, archived=$5 WHERE id=$1`,
+		c.ID, c.Name, c.ParentID, c.Icon, c.Archived,
+	)
+	return err
+}
+
+func (r *CategoryRepo) Delete(ctx context.Context, id int) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM categories WHERE id=$1`, id)
+	return err
+}
+
+func (r *CategoryRepo) Repoint(ctx context.Context, userID, from, to string) error {
+	q := conn(ctx, r.db)
+	for _, stmt := range []string{
+		`UPDATE expenses SET category=$3 WHERE user_id=$1 AND category=$2`,
+		`UPDATE recurring_transactions SET category=$3 WHERE user_id=$1 AND category=$2`,
+		`UPDATE category_rules SET category=$3 WHERE user_id=$1 AND category=$2`,
+		`DELETE FROM budgets WHERE user_id=$1 AND category=$2
+		   AND EXISTS (SELECT 1 FROM budgets WHERE user_id=$1 AND category=$3)`,
+		`UPDATE budgets SET category=$3 WHERE user_id=$1 AND category=$2`,
+		`DELETE FROM category_aliases WHERE user_id=$1 AND alias=$3`,
+		`UPDATE category_aliases SET category=$3 WHERE user_id=$1 AND category=$2`,
+	} {
+		if _, err := q.ExecContext(ctx, stmt, userID, from, to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *CategoryRepo) Usage(ctx context.Context, userID string) (map[string]int, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT category, SUM(n) FROM (
+		     SELECT category, COUNT(*) AS n FROM expenses WHERE user_id=$1 GROUP BY category
+		     UNION ALL SELECT category, 0 FROM budgets WHERE user_id=$1
+		     UNION ALL SELECT category, 0 FROM recurring_transactions WHERE user_id=$1
+		     UNION ALL SELECT category, 0 FROM category_rules WHERE user_id=$1
+		     UNION ALL SELECT name, 0 FROM categories WHERE user_id=$1
+		 ) u
+		 GROUP BY category`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := map[string]int{}
+	for rows.Next() {
+		var name string
+		var n int
+		if err := rows.Scan(&name, &n); err != nil {
+			return nil, err
+		}
+		out[name] = n
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *CategoryRepo) SetAlias(ctx context.Context, a domain.CategoryAlias) error {
+	q := conn(ctx, r.db)
+	if _, err := q.ExecContext(ctx,
+		`INSERT INTO category_aliases(user_id, alias, category) VALUES($1, $2, $3)
+		 ON CONFLICT (user_id, alias) DO UPDATE SET category=EXCLUDED.category`,
+		a.UserID, a.Alias, a.Category,
+	); err != nil {
+		return err
+	}
+	_, err := q.ExecContext(ctx,
+		`UPDATE category_aliases SET category=$3 WHERE user_id=$1 AND category=$2`,
+		a.UserID, a.Alias, a.Category,
+	)
+	return err
+}
+
+func (r *CategoryRepo) ResolveAlias(ctx context.Context, userID, alias string) (string, bool, error) {
+	var category string
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT category FROM category_aliases WHERE user_id=$1 AND alias=$2`,
+		userID, alias,
+	).Scan(&category)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return category, true, nil
+}
+
+func (r *CategoryRepo) ListAliases(ctx context.Context, userID string) ([]domain.CategoryAlias, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT user_id, alias, category FROM category_aliases WHERE user_id=$1 ORDER BY alias`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]domain.CategoryAlias, 0)
+	for rows.Next() {
+		var a domain.CategoryAlias
+		if err := rows.Scan(&a.UserID, &a.Alias, &a.Category); err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *CategoryRepo) DeleteAlias(ctx context.Context, userID, alias string) (bool, error) {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM category_aliases WHERE user_id=$1 AND alias=$2`,
+		userID, alias,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
@@ -3,6 +3,9 @@ package pg
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"strconv"
+	"strings"
 	"time"
 
 	"final/ledger/internal/domain"
@@ -20,24 +23,170 @@ func (r *ExpenseRepo) Insert(ctx context.Context, t domain.Transaction) (int, er
 	dateOnly := time.Date(t.Date.Year(), t.Date.Month(), t.Date.Day(), 0, 0, 0, 0, time.UTC)
 
 	var id int
-	err := r.db.QueryRowContext(ctx,
-		`INSERT INTO expenses(amount, category, description, date)
-		 VALUES($1,$2,$3,$4)
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO expenses(user_id, account_id, kind, amount, currency, category, description, date)
+		 VALUES($1,$2,$3,$4,$5,$6,$7,$8)
 		 RETURNING id`,
-		t.Amount, t.Category, t.Description, dateOnly,
+		t.UserID, t.AccountID, t.Kind, t.Amount, t.Currency, t.Category, t.Description, dateOnly,
 	).Scan(&id)
 	if err != nil {
 		return 0, err
 	}
+	if err := r.setTags(ctx, t.UserID, id, t.Tags); err != nil {
+		return 0, err
+	}
 	return id, nil
 }
 
-func (r *ExpenseRepo) List(ctx context.Context) ([]domain.Transaction, error) {
-	rows, err := r.db.QueryContext(ctx,
-		`SELECT id, amount, category, description, date
+// setTags заменяет метки транзакции, создавая недостающие метки пользователя.
+// Вызывается внутри транзакции БД вместе с записью строки expenses.
+func (r *ExpenseRepo) setTags(ctx context.Context, userID string, id int, tags []string) error {
+	q := conn(ctx, r.db)
+	if _, err := q.ExecContext(ctx, `DELETE FROM transaction_tags WHERE transaction_id=$1`, id); err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	if _, err := q.ExecContext(ctx,
+		`INSERT INTO tags(user_id, name) SELECT $1, unnest($2::text[]) ON CONFLICT (user_id, name) DO NOTHING`,
+		userID, tags,
+	); err != nil {
+		return err
+	}
+	_, err := q.ExecContext(ctx,
+		`INSERT INTO transaction_tags(transaction_id, tag_id)
+		 SELECT $1, id FROM tags WHERE user_id=$2 AND name = ANY($3)`,
+		id, userID, tags,
+	)
+	return err
+}
+
+// tagsExpr — метки строки expenses JSON-массивом по алфавиту.
+const tagsExpr = `COALESCE((SELECT json_agg(g.name ORDER BY g.name)
+	FROM transaction_tags tt JOIN tags g ON g.id = tt.tag_id
+	WHERE tt.transaction_id = expenses.id), '[]')`
+
+func scanTags(raw []byte, t *domain.Transaction) error {
+	if err := json.Unmarshal(raw, &t.Tags); err != nil {
+		return err
+	}
+	if len(t.Tags) == 0 {
+		t.Tags = nil
+	}
+	return nil
+}
+
+func (r *ExpenseRepo) GetForUpdate(ctx context.Context, userID string, id int) (domain.Transaction, bool, error) {
+	var (
+		t    domain.Transaction
+		tags []byte
+	)
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT id, user_id, account_id, kind, amount, currency, category, description, date, COALESCE(transfer_id, 0), `+tagsExpr+`
 		 FROM expenses
-		 ORDER BY date DESC, id DESC`,
+		 WHERE id=$1 AND user_id=$2
+		 FOR UPDATE`,
+		id, userID,
+	).Scan(&t.ID, &t.UserID, &t.AccountID, &t.Kind, &t.Amount, &t.Currency, &t.Category, &t.Description, &t.Date, &t.TransferID, &tags)
+	if err == sql.ErrNoRows {
+		return domain.Transaction{}, false, nil
+	}
+	if err != nil {
+		return domain.Transaction{}, false, err
+	}
+	if err := scanTags(tags, &t); err != nil {
+		return domain.Transaction{}, false, err
+	}
+	return t, true, nil
+}
+
+func (r *ExpenseRepo) Update(ctx context.Context, t domain.Transaction) error {
+	dateOnly := time.Date(t.Date.Year(), t.Date.Month(), t.Date.Day(), 0, 0, 0, 0, time.UTC)
+
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE expenses
+		 SET account_id=$3, kind=$4, amount=$5, currency=$6, category=$7, description=$8, date=$9
+		 WHERE id=$1 AND user_id=$2`,
+		t.ID, t.UserID, t.AccountID, t.Kind, t.Amount, t.Currency, t.Category, t.Description, dateOnly,
 	)
+	if err != nil {
+		return err
+	}
+	return r.setTags(ctx, t.UserID, t.ID, t.Tags)
+}
+
+func (r *ExpenseRepo) Delete(ctx context.Context, userID string, id int) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM expenses WHERE id=$1 AND user_id=$2`, id, userID)
+	return err
+}
+
+func (r *ExpenseRepo) List(ctx context.Context, userID string, f domain.TransactionFilter, limit int) ([]domain.Transaction, error) {
+	cursor, err := f.Cursor()
+	if err != nil {
+		return nil, err
+	}
+
+	where := []string{"user_id=$1"}
+	args := []any{userID}
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if !f.From.IsZero() {
+		where = append(where, "date >= "+arg(f.From))
+	}
+	if !f.To.IsZero() {
+		where = append(where, "date <= "+arg(f.To))
+	}
+	if len(f.AccountIDs) > 0 {
+		where = append(where, "account_id = ANY("+arg(f.AccountIDs)+")")
+	}
+	if len(f.Kinds) > 0 {
+		where = append(where, "kind = ANY("+arg(f.Kinds)+")")
+	}
+	if len(f.Categories) > 0 {
+		where = append(where, "category = ANY("+arg(f.Categories)+")")
+	}
+	if len(f.Tags) > 0 {
+		where = append(where, `EXISTS (SELECT 1 FROM transaction_tags tt JOIN tags g ON g.id = tt.tag_id
+			WHERE tt.transaction_id = expenses.id AND g.name = ANY(`+arg(f.Tags)+`))`)
+	}
+	if f.MinAmount != nil {
+		where = append(where, "amount >= "+arg(*f.MinAmount))
+	}
+	if f.MaxAmount != nil {
+		where = append(where, "amount <= "+arg(*f.MaxAmount))
+	}
+	if f.Query != "" {
+		where = append(where, "description ILIKE "+arg("%"+likeEscaper.Replace(f.Query)+"%"))
+	}
+
+	key, dir, cmp := "date", "DESC", "<"
+	switch f.Sort {
+	case domain.SortDateAsc:
+		dir, cmp = "ASC", ">"
+	case domain.SortAmountDesc:
+		key = "amount"
+	case domain.SortAmountAsc:
+		key, dir, cmp = "amount", "ASC", ">"
+	}
+	if cursor != nil {
+		var v any = cursor.Date
+		if key == "amount" {
+			v = cursor.Amount
+		}
+		where = append(where, "("+key+", id) "+cmp+" ("+arg(v)+", "+arg(cursor.ID)+")")
+	}
+
+	query := `SELECT id, account_id, kind, amount, currency, category, description, date, COALESCE(transfer_id, 0), ` + tagsExpr + `
+		 FROM expenses
+		 WHERE ` + strings.Join(where, " AND ") + `
+		 ORDER BY ` + key + " " + dir + ", id " + dir + `
+		 LIMIT ` + arg(limit)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -45,8 +194,12 @@ func (r *ExpenseRepo) List(ctx context.Context) ([]domain.Transaction, error) {
 
 	out := make([]domain.Transaction, 0)
 	for rows.Next() {
-		var t domain.Transaction
-		if err := rows.Scan(&t.ID, &t.Amount, &t.Category, &t.Description, &t.Date); err != nil {
+		t := domain.Transaction{UserID: userID}
+		var tags []byte
+		if err := rows.Scan(&t.ID, &t.AccountID, &t.Kind, &t.Amount, &t.Currency, &t.Category, &t.Description, &t.Date, &t.TransferID, &tags); err != nil {
+			return nil, err
+		}
+		if err := scanTags(tags, &t); err != nil {
 			return nil, err
 		}
 		out = append(out, t)
@@ -57,40 +210,85 @@ func (r *ExpenseRepo) List(ctx context.Context) ([]domain.Transaction, error) {
 	return out, nil
 }
 
-func (r *ExpenseRepo) SumByCategory(ctx context.Context, category string) (float64, error) {
-	var sum float64
-	if err := r.db.QueryRowContext(ctx,
-		`SELECT COALESCE(SUM(amount),0) FROM expenses WHERE category=$1`,
-		category,
-	).Scan(&sum); err != nil {
-		return 0, err
-	}
-	return sum, nil
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (r *ExpenseRepo) AmountsByCategory(ctx context.Context, userID string, categories []string) ([]domain.DatedAmount, error) {
+	return r.amounts(ctx,
+		`SELECT currency, date, SUM(`+spendingExpr+`)
+		 FROM expenses
+		 WHERE user_id=$1 AND category = ANY($2) AND kind IN ('expense', 'refund')
+		 GROUP BY currency, date`,
+		userID, categories,
+	)
+}
+
+func (r *ExpenseRepo) SpendingByCategoryInRange(ctx context.Context, userID string, from, to time.Time) (map[string][]domain.DatedAmount, error) {
+	fromD := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toD := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	return r.groupedAmounts(ctx,
+		`SELECT category, currency, date, SUM(`+spendingExpr+`)
+		 FROM expenses
+		 WHERE user_id=$1 AND date >= $2 AND date <= $3 AND kind IN ('expense', 'refund')
+		 GROUP BY category, currency, date`,
+		userID, fromD, toD,
+	)
 }
 
-func (r *ExpenseRepo) ListCategoriesInRange(ctx context.Context, from, to time.Time) ([]string, error) {
+func (r *ExpenseRepo) AmountsByCategoryInRange(ctx context.Context, userID string, categories []string, from, to time.Time) ([]domain.DatedAmount, error) {
 	fromD := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
 	toD := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
 
-	rows, err := r.db.QueryContext(ctx,
-		`SELECT DISTINCT category
+	return r.amounts(ctx,
+		`SELECT currency, date, SUM(`+spendingExpr+`)
 		 FROM expenses
-		 WHERE date >= $1 AND date <= $2
-		 ORDER BY category`,
-		fromD, toD,
+		 WHERE user_id=$1 AND category = ANY($2) AND date >= $3 AND date <= $4 AND kind IN ('expense', 'refund')
+		 GROUP BY currency, date`,
+		userID, categories, fromD, toD,
+	)
+}
+
+// spendingExpr — вклад строки в траты категории, как Transaction.Spending.
+const spendingExpr = `CASE WHEN kind = 'refund' THEN -amount ELSE amount END`
+
+func (r *ExpenseRepo) AmountsByTagInRange(ctx context.Context, userID string, from, to time.Time) (map[string][]domain.DatedAmount, error) {
+	fromD := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toD := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	return r.groupedAmounts(ctx,
+		`SELECT g.name, e.currency, e.date, SUM(CASE WHEN e.kind = 'refund' THEN -e.amount ELSE e.amount END)
+		 FROM expenses e
+		 JOIN transaction_tags tt ON tt.transaction_id = e.id
+		 JOIN tags g ON g.id = tt.tag_id
+		 WHERE e.user_id=$1 AND e.date >= $2 AND e.date <= $3 AND e.kind IN ('expense', 'refund')
+		 GROUP BY g.name, e.currency, e.date`,
+		userID, fromD, toD,
+	)
+}
+
+func (r *ExpenseRepo) AmountsByKindInRange(ctx context.Context, userID string, from, to time.Time) ([]domain.DatedAmount, error) {
+	fromD := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toD := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT kind, currency, date, SUM(amount)
+		 FROM expenses
+		 WHERE user_id=$1 AND date >= $2 AND date <= $3 AND kind <> 'transfer'
+		 GROUP BY kind, currency, date`,
+		userID, fromD, toD,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	out := make([]string, 0)
+	out := make([]domain.DatedAmount, 0)
 	for rows.Next() {
-		var c string
-		if err := rows.Scan(&c); err != nil {
+		var a domain.DatedAmount
+		if err := rows.Scan(&a.Kind, &a.Currency, &a.Date, &a.Amount); err != nil {
 			return nil, err
 		}
-		out = append(out, c)
+		out = append(out, a)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -98,18 +296,93 @@ func (r *ExpenseRepo) ListCategoriesInRange(ctx context.Context, from, to time.T
 	return out, nil
 }
 
-func (r *ExpenseRepo) SumByCategoryInRange(ctx context.Context, category string, from, to time.Time) (float64, error) {
-	fromD := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
-	toD := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+func (r *ExpenseRepo) amounts(ctx context.Context, query string, args ...any) ([]domain.DatedAmount, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]domain.DatedAmount, 0)
+	for rows.Next() {
+		var a domain.DatedAmount
+		if err := rows.Scan(&a.Currency, &a.Date, &a.Amount); err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// groupedAmounts читает строки (ключ, валюта, дата, сумма) в карту по ключу.
+func (r *ExpenseRepo) groupedAmounts(ctx context.Context, query string, args ...any) (map[string][]domain.DatedAmount, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make(map[string][]domain.DatedAmount)
+	for rows.Next() {
+		var (
+			key string
+			a   domain.DatedAmount
+		)
+		if err := rows.Scan(&key, &a.Currency, &a.Date, &a.Amount); err != nil {
+			return nil, err
+		}
+		out[key] = append(out[key], a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *ExpenseRepo) LinkTransfer(ctx context.Context, a, b int) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE expenses
+		 SET transfer_id = CASE WHEN id=$1 THEN $2 ELSE $1 END
+		 WHERE id IN ($1, $2)`,
+		a, b,
+	)
+	return err
+}
 
-	var sum float64
-	if err := r.db.QueryRowContext(ctx,
-		`SELECT COALESCE(SUM(amount),0)
+// balanceExpr — изменение баланса счёта от строки, как Transaction.BalanceEffect.
+const balanceExpr = `CASE WHEN kind = 'expense' THEN -amount ELSE amount END`
+
+func (r *ExpenseRepo) BalanceAmounts(ctx context.Context, userID string, on time.Time) (map[int][]domain.DatedAmount, error) {
+	onD := time.Date(on.Year(), on.Month(), on.Day(), 0, 0, 0, 0, time.UTC)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT account_id, currency, date, SUM(`+balanceExpr+`)
 		 FROM expenses
-		 WHERE category=$1 AND date >= $2 AND date <= $3`,
-		category, fromD, toD,
-	).Scan(&sum); err != nil {
-		return 0, err
+		 WHERE user_id=$1 AND date <= $2
+		 GROUP BY account_id, currency, date`,
+		userID, onD,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make(map[int][]domain.DatedAmount)
+	for rows.Next() {
+		var (
+			accountID int
+			a         domain.DatedAmount
+		)
+		if err := rows.Scan(&accountID, &a.Currency, &a.Date, &a.Amount); err != nil {
+			return nil, err
+		}
+		out[accountID] = append(out[accountID], a)
 	}
-	return sum, nil
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
 }
@@ -0,0 +1,119 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"final/ledger/internal/domain"
+)
+
+type JournalRepo struct {
+	db *sql.DB
+}
+
+func NewJournalRepo(db *sql.DB) *JournalRepo {
+	return &JournalRepo{db: db}
+}
+
+// Post записывает запись со всеми проводками. Баланс проверяет ещё и
+// отложенный триггер в БД, поэтому вызывать Post нужно внутри WithinTx.
+func (r *JournalRepo) Post(ctx context.Context, e domain.JournalEntry) (int, error) {
+	if err := e.Validate(); err != nil {
+		return 0, err
+	}
+	dateOnly := time.Date(e.Date.Year(), e.Date.Month(), e.Date.Day(), 0, 0, 0, 0, time.UTC)
+
+	q := conn(ctx, r.db)
+	var id int
+	err := q.QueryRowContext(ctx,
+		`INSERT INTO journal_entries(user_id, transaction_id, date, description)
+		 VALUES($1, NULLIF($2, 0), $3, $4)
+		 RETURNING id`,
+		e.UserID, e.TransactionID, dateOnly, e.Description,
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	for _, p := range e.Postings {
+		if _, err := q.ExecContext(ctx,
+			`INSERT INTO postings(entry_id, account, currency, amount) VALUES($1,$2,$3,$4)`,
+			id, p.Account, p.Currency, p.Amount,
+		); err != nil {
+			return 0, err
+		}
+	}
+	return id, nil
+}
+
+func (r *JournalRepo) TrialBalance(ctx context.Context, userID string, on time.Time) ([]domain.TrialBalanceLine, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT p.account, p.currency,
+		        COALESCE(SUM(p.amount) FILTER (WHERE p.amount > 0), 0),
+		        COALESCE(-SUM(p.amount) FILTER (WHERE p.amount < 0), 0)
+		 FROM postings p
+		 JOIN journal_entries e ON e.id = p.entry_id
+		 WHERE e.user_id=$1 AND e.date <= $2
+		 GROUP BY p.account, p.currency
+		 ORDER BY p.account, p.currency`,
+		userID, on,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]domain.TrialBalanceLine, 0)
+	for rows.Next() {
+		var l domain.TrialBalanceLine
+		if err := rows.Scan(&l.Account, &l.Currency, &l.Debit, &l.Credit); err != nil {
+			return nil, err
+		}
+		out = append(out, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *JournalRepo) Statement(ctx context.Context, userID, account, currency string, from, to time.Time) (domain.Money, []domain.StatementLine, error) {
+	q := conn(ctx, r.db)
+
+	var opening domain.Money
+	if err := q.QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(p.amount), 0)
+		 FROM postings p
+		 JOIN journal_entries e ON e.id = p.entry_id
+		 WHERE e.user_id=$1 AND p.account=$2 AND p.currency=$3 AND e.date < $4`,
+		userID, account, currency, from,
+	).Scan(&opening); err != nil {
+		return 0, nil, err
+	}
+
+	rows, err := q.QueryContext(ctx,
+		`SELECT e.id, COALESCE(e.transaction_id, 0), e.date, e.description, e.recorded_at, p.amount
+		 FROM postings p
+		 JOIN journal_entries e ON e.id = p.entry_id
+		 WHERE e.user_id=$1 AND p.account=$2 AND p.currency=$3 AND e.date BETWEEN $4 AND $5
+		 ORDER BY e.date, e.id, p.id`,
+		userID, account, currency, from, to,
+	)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer rows.Close()
+
+	lines := make([]domain.StatementLine, 0)
+	for rows.Next() {
+		var l domain.StatementLine
+		if err := rows.Scan(&l.EntryID, &l.TransactionID, &l.Date, &l.Description, &l.RecordedAt, &l.Amount); err != nil {
+			return 0, nil, err
+		}
+		lines = append(lines, l)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, nil, err
+	}
+	return opening, lines, nil
+}
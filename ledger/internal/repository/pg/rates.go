@@ -0,0 +1,97 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"time"
+
+	"final/ledger/internal/domain"
+)
+
+type RateRepo struct {
+	db *sql.DB
+}
+
+func NewRateRepo(db *sql.DB) *RateRepo {
+	return &RateRepo{db: db}
+}
+
+func (r *RateRepo) Rate(ctx context.Context, base, quote string, on time.Time) (*big.Rat, bool, error) {
+	day := time.Date(on.Year(), on.Month(), on.Day(), 0, 0, 0, 0, time.UTC)
+
+	var s string
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT rate::text
+		 FROM exchange_rates
+		 WHERE base=$1 AND quote=$2 AND date <= $3
+		 ORDER BY date DESC
+		 LIMIT 1`,
+		base, quote, day,
+	).Scan(&s)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	rate, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, false, errors.New("invalid rate in db: " + s)
+	}
+	return rate, true, nil
+}
+
+func (r *RateRepo) RatesInRange(ctx context.Context, currencies []string, from, to time.Time) ([]domain.ExchangeRate, error) {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`(SELECT base, quote, date, rate::text
+		  FROM exchange_rates
+		  WHERE base = ANY($1) AND quote = ANY($1) AND date BETWEEN $2 AND $3)
+		 UNION ALL
+		 (SELECT DISTINCT ON (base, quote) base, quote, date, rate::text
+		  FROM exchange_rates
+		  WHERE base = ANY($1) AND quote = ANY($1) AND date < $2
+		  ORDER BY base, quote, date DESC)`,
+		currencies, from, to,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []domain.ExchangeRate
+	for rows.Next() {
+		var (
+			rt domain.ExchangeRate
+			s  string
+		)
+		if err := rows.Scan(&rt.Base, &rt.Quote, &rt.Date, &s); err != nil {
+			return nil, err
+		}
+		var ok bool
+		if rt.Rate, ok = new(big.Rat).SetString(s); !ok {
+			return nil, errors.New("invalid rate in db: " + s)
+		}
+		out = append(out, rt)
+	}
+	return out, rows.Err()
+}
+
+func (r *RateRepo) Upsert(ctx context.Context, rates []domain.ExchangeRate) error {
+	for _, rt := range rates {
+		day := time.Date(rt.Date.Year(), rt.Date.Month(), rt.Date.Day(), 0, 0, 0, 0, time.UTC)
+		if _, err := conn(ctx, r.db).ExecContext(ctx,
+			`INSERT INTO exchange_rates(base, quote, date, rate)
+			 VALUES($1,$2,$3,$4)
+			 ON CONFLICT(base, quote, date) DO UPDATE SET rate=EXCLUDED.rate`,
+			rt.Base, rt.Quote, day, rt.Rate.FloatString(10),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"final/ledger/internal/domain"
+)
+
+type RecurringRepo struct {
+	db *sql.DB
+}
+
+func NewRecurringRepo(db *sql.DB) *RecurringRepo {
+	return &RecurringRepo{db: db}
+}
+
+const recurringColumns = `id, user_id, account_id, kind, amount, currency, category, description,
+	frequency, interval, start_date, end_date, next_date, paused`
+
+func scanRecurring(row interface{ Scan(...any) error }) (domain.Recurring, error) {
+	var (
+		r             domain.Recurring
+		end, nextDate sql.NullTime
+	)
+	err := row.Scan(&r.ID, &r.UserID, &r.AccountID, &r.Kind, &r.Amount, &r.Currency, &r.Category, &r.Description,
+		&r.Frequency, &r.Interval, &r.StartDate, &end, &nextDate, &r.Paused)
+	r.EndDate, r.NextDate = end.Time, nextDate.Time
+	return r, err
+}
+
+// nullDate переводит нулевую дату в NULL.
+func nullDate(t time.Time) sql.NullTime {
+	if t.IsZero() {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), Valid: true}
+}
+
+func (r *RecurringRepo) Insert(ctx context.Context, rec domain.Recurring) (domain.Recurring, error) {
+	return scanRecurring(conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO recurring_transactions(user_id, account_id, kind, amount, currency, category, description,
+		                                    frequency, interval, start_date, end_date, next_date, paused)
+		 VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)
+		 RETURNING `+recurringColumns,
+		rec.UserID, rec.AccountID, rec.Kind, rec.Amount, rec.Currency, rec.Category, rec.Description,
+		rec.Frequency, rec.Interval, nullDate(rec.StartDate), nullDate(rec.EndDate), nullDate(rec.NextDate), rec.Paused,
+	))
+}
+
+func (r *RecurringRepo) Get(ctx context.Context, id int) (domain.Recurring, bool, error) {
+	rec, err := scanRecurring(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+recurringColumns+` FROM recurring_transactions WHERE id=$1`,
+		id,
+	))
+	if err == sql.ErrNoRows {
+		return domain.Recurring{}, false, nil
+	}
+	if err != nil {
+		return domain.Recurring{}, false, err
+	}
+	return rec, true, nil
+}
+
+func (r *RecurringRepo) GetForUpdate(ctx context.Context, userID string, id int) (domain.Recurring, bool, error) {
+	rec, err := scanRecurring(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+recurringColumns+` FROM recurring_transactions WHERE id=$1 AND user_id=$2 FOR UPDATE`,
+		id, userID,
+	))
+	if err == sql.ErrNoRows {
+		return domain.Recurring{}, false, nil
+	}
+	if err != nil {
+		return domain.Recurring{}, false, err
+	}
+	return rec, true, nil
+}
+
+func (r *RecurringRepo) List(ctx context.Context, userID string) ([]domain.Recurring, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+recurringColumns+` FROM recurring_transactions WHERE user_id=$1 ORDER BY id`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]domain.Recurring, 0)
+	for rows.Next() {
+		rec, err := scanRecurring(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *RecurringRepo) Update(ctx context.Context, rec domain.Recurring) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE recurring_transactions SET paused=$2, next_date=$3 WHERE id=$1`,
+		rec.ID, rec.Paused, nullDate(rec.NextDate),
+	)
+	return err
+}
+
+func (r *RecurringRepo) ClaimDue(ctx context.Context, on time.Time, skip []int) (domain.Recurring, bool, error) {
+	if skip == nil {
+		skip = []int{}
+	}
+	rec, err := scanRecurring(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+recurringColumns+`
+		 FROM recurring_transactions
+		 WHERE NOT paused AND next_date <= $1 AND id <> ALL($2)
+		 ORDER BY next_date, id
+		 LIMIT 1
+		 FOR UPDATE SKIP LOCKED`,
+		nullDate(on), skip,
+	))
+	if err == sql.ErrNoRows {
+		return domain.Recurring{}, false, nil
+	}
+	if err != nil {
+		return domain.Recurring{}, false, err
+	}
+	return rec, true, nil
+}
+
+func (r *RecurringRepo) ClaimOccurrence(ctx context.Context, recurringID int, on time.Time) (bool, error) {
+	res, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO recurring_occurrences(recurring_id, date) VALUES($1, $2) ON CONFLICT DO NOTHING`,
+		recurringID, nullDate(on),
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (r *RecurringRepo) SetOccurrenceResult(ctx context.Context, recurringID int, on time.Time, transactionID int, errMsg string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE recurring_occurrences SET transaction_id=NULLIF($3, 0), error=$4 WHERE recurring_id=$1 AND date=$2`,
+		recurringID, nullDate(on), transactionID, errMsg,
+	)
+	return err
+}
@@ -0,0 +1,117 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"final/ledger/internal/domain"
+)
+
+type RuleRepo struct {
+	db *sql.DB
+}
+
+func NewRuleRepo(db *sql.DB) *RuleRepo {
+	return &RuleRepo{db: db}
+}
+
+const ruleColumns = `id, user_id, name, priority, description_contains, description_regex, merchant,
+	min_amount, max_amount, category, tags, disabled`
+
+func scanRule(row interface{ Scan(...any) error }) (domain.Rule, error) {
+	var (
+		r    domain.Rule
+		tags []byte
+	)
+	err := row.Scan(&r.ID, &r.UserID, &r.Name, &r.Priority, &r.DescriptionContains, &r.DescriptionRegex, &r.Merchant,
+		&r.MinAmount, &r.MaxAmount, &r.Category, &tags, &r.Disabled)
+	if err != nil {
+		return domain.Rule{}, err
+	}
+	if err := json.Unmarshal(tags, &r.Tags); err != nil {
+		return domain.Rule{}, err
+	}
+	return r, nil
+}
+
+func marshalTags(tags []string) (string, error) {
+	if tags == nil {
+		tags = []string{}
+	}
+	b, err := json.Marshal(tags)
+	return string(b), err
+}
+
+func (r *RuleRepo) Insert(ctx context.Context, rule domain.Rule) (domain.Rule, error) {
+	tags, err := marshalTags(rule.Tags)
+	if err != nil {
+		return domain.Rule{}, err
+	}
+	return scanRule(conn(ctx, r.db).QueryRowContext(ctx,
+		`INSERT INTO category_rules(user_id, name, priority, description_contains, description_regex, merchant,
+		                            min_amount, max_amount, category, tags, disabled)
+		 VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)
+		 RETURNING `+ruleColumns,
+		rule.UserID, rule.Name, rule.Priority, rule.DescriptionContains, rule.DescriptionRegex, rule.Merchant,
+		rule.MinAmount, rule.MaxAmount, rule.Category, tags, rule.Disabled,
+	))
+}
+
+func (r *RuleRepo) Get(ctx context.Context, id int) (domain.Rule, bool, error) {
+	rule, err := scanRule(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+ruleColumns+` FROM category_rules WHERE id=$1`,
+		id,
+	))
+	if err == sql.ErrNoRows {
+		return domain.Rule{}, false, nil
+	}
+	if err != nil {
+		return domain.Rule{}, false, err
+	}
+	return rule, true, nil
+}
+
+func (r *RuleRepo) List(ctx context.Context, userID string) ([]domain.Rule, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+ruleColumns+` FROM category_rules WHERE user_id=$1 ORDER BY priority, id`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]domain.Rule, 0)
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *RuleRepo) Update(ctx context.Context, rule domain.Rule) error {
+	tags, err := marshalTags(rule.Tags)
+	if err != nil {
+		return err
+	}
+	_, err = conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE category_rules SET name=$2, priority=$3, description_contains=$4, description_regex=$5, merchant=$6,
+		                           min_amount=$7, max_amount=$8, category=$9, tags=$10, disabled=$11
+		 WHERE id=$1`,
+		rule.ID, rule.Name, rule.Priority, rule.DescriptionContains, rule.DescriptionRegex, rule.Merchant,
+		rule.MinAmount, rule.MaxAmount, rule.Category, tags, rule.Disabled,
+	)
+	return err
+}
+
+func (r *RuleRepo) Delete(ctx context.Context, id int) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM category_rules WHERE id=$1`, id)
+	return err
+}
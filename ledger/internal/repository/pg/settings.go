@@ -0,0 +1,41 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+
+	"final/ledger/internal/domain"
+)
+
+type SettingsRepo struct {
+	db *sql.DB
+}
+
+func NewSettingsRepo(db *sql.DB) *SettingsRepo {
+	return &SettingsRepo{db: db}
+}
+
+func (r *SettingsRepo) Get(ctx context.Context, userID string) (domain.UserSettings, bool, error) {
+	s := domain.UserSettings{UserID: userID}
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT base_currency FROM user_settings WHERE user_id=$1`,
+		userID,
+	).Scan(&s.BaseCurrency)
+	if err == sql.ErrNoRows {
+		return domain.UserSettings{}, false, nil
+	}
+	if err != nil {
+		return domain.UserSettings{}, false, err
+	}
+	return s, true, nil
+}
+
+func (r *SettingsRepo) Upsert(ctx context.Context, s domain.UserSettings) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO user_settings(user_id, base_currency)
+		 VALUES($1,$2)
+		 ON CONFLICT(user_id) DO UPDATE SET base_currency=EXCLUDED.base_currency`,
+		s.UserID, s.BaseCurrency,
+	)
+	return err
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"final/ledger/internal/domain"
+)
+
+func (a *App) CreateAccount(ctx context.Context, acc domain.Account) (domain.Account, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.Account{}, err
+	}
+	if err := acc.Validate(); err != nil {
+		return domain.Account{}, err
+	}
+	acc.UserID = uid
+	acc.Name = strings.TrimSpace(acc.Name)
+	acc.Currency = domain.NormalizeCurrency(acc.Currency)
+	if acc.Currency == "" {
+		if acc.Currency, err = a.baseCurrency(ctx, uid); err != nil {
+			return domain.Account{}, err
+		}
+	}
+	return a.accounts.Insert(ctx, acc)
+}
+
+func (a *App) ListAccounts(ctx context.Context) ([]domain.Account, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return a.accounts.List(ctx, uid)
+}
+
+// resolveAccount возвращает счёт пользователя по id; нулевой id — счёт по
+// умолчанию, который создаётся в базовой валюте при первой транзакции.
+func (a *App) resolveAccount(ctx context.Context, uid string, id int) (domain.Account, error) {
+	if id == 0 {
+		base, err := a.baseCurrency(ctx, uid)
+		if err != nil {
+			return domain.Account{}, err
+		}
+		return a.accounts.EnsureDefault(ctx, uid, base)
+	}
+	acc, ok, err := a.accounts.Get(ctx, id)
+	if err != nil {
+		return domain.Account{}, err
+	}
+	// Чужой счёт неотличим от несуществующего.
+	if !ok || acc.UserID != uid {
+		return domain.Account{}, ErrAccountNotFound
+	}
+	return acc, nil
+}
+
+// Transfer записывает перевод двумя связанными транзакциями вида transfer:
+// списание со счёта-источника и зачисление на счёт-получатель, каждая в
+// валюте своего счёта. В траты и движение денег переводы не попадают.
+func (a *App) Transfer(ctx context.Context, tr domain.Transfer) (domain.Transaction, domain.Transaction, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.Transaction{}, domain.Transaction{}, err
+	}
+	if err := tr.Validate(); err != nil {
+		return domain.Transaction{}, domain.Transaction{}, err
+	}
+
+	var out, in domain.Transaction
+	err = a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		from, err := a.resolveAccount(ctx, uid, tr.FromAccountID)
+		if err != nil {
+			return err
+		}
+		to, err := a.resolveAccount(ctx, uid, tr.ToAccountID)
+		if err != nil {
+			return err
+		}
+		if from.ID == to.ID {
+			return errors.New("transfer accounts must differ")
+		}
+
+		toAmount := tr.Amount
+		if tr.ToAmount != nil {
+			toAmount = *tr.ToAmount
+		} else if toAmount, err = a.convert(ctx, tr.Amount, from.Currency, to.Currency, tr.Date); err != nil {
+			return err
+		}
+
+		leg := func(acc domain.Account, amount domain.Money) (domain.Transaction, error) {
+			t := domain.Transaction{
+				UserID:      uid,
+				AccountID:   acc.ID,
+				Kind:        domain.KindTransfer,
+				Amount:      amount,
+				Currency:    acc.Currency,
+				Category:    domain.KindTransfer,
+				Description: tr.Description,
+				Date:        tr.Date,
+			}
+			id, err := a.expenses.Insert(ctx, t)
+			t.ID = id
+			return t, err
+		}
+		if out, err = leg(from, -tr.Amount); err != nil {
+			return err
+		}
+		if in, err = leg(to, toAmount); err != nil {
+			return err
+		}
+		out.TransferID, in.TransferID = in.ID, out.ID
+		return a.expenses.LinkTransfer(ctx, out.ID, in.ID)
+	})
+	if err != nil {
+		return domain.Transaction{}, domain.Transaction{}, err
+	}
+	return out, in, nil
+}
+
+// GetBalances считает баланс каждого счёта на дату on (нулевая — сегодня):
+// остаток на открытии плюс все транзакции счёта, пересчитанные в валюту
+// счёта по курсу на их даты. Total — сумма балансов в базовой валюте по
+// курсу на on.
+func (a *App) GetBalances(ctx context.Context, on time.Time) (domain.Balances, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.Balances{}, err
+	}
+	if on.IsZero() {
+		on = time.Now().UTC()
+	}
+
+	base, err := a.baseCurrency(ctx, uid)
+	if err != nil {
+		return domain.Balances{}, err
+	}
+	accounts, err := a.accounts.List(ctx, uid)
+	if err != nil {
+		return domain.Balances{}, err
+	}
+	amounts, err := a.expenses.BalanceAmounts(ctx, uid, on)
+	if err != nil {
+		return domain.Balances{}, err
+	}
+
+	// Курсы для всех счетов читаются одним запросом: на даты движений и на
+	// дату on, по которой остатки пересчитываются в базовую валюту.
+	var (
+		all        []domain.DatedAmount
+		currencies = []string{base}
+	)
+	for _, acc := range accounts {
+		all = append(all, amounts[acc.ID]...)
+		all = append(all, domain.DatedAmount{Currency: acc.Currency, Date: on, Amount: 1})
+		currencies = append(currencies, acc.Currency)
+	}
+	rates, err := a.loadRates(ctx, all, currencies...)
+	if err != nil {
+		return domain.Balances{}, err
+	}
+
+	out := domain.Balances{Items: make([]domain.AccountBalance, 0, len(accounts)), Currency: base}
+	for _, acc := range accounts {
+		moved, err := sumWith(rates, amounts[acc.ID], acc.Currency)
+		if err != nil {
+			return domain.Balances{}, err
+		}
+		balance := acc.OpeningBalance + moved
+		inBase, err := convertWith(rates, balance, acc.Currency, base, on)
+		if err != nil {
+			return domain.Balances{}, err
+		}
+		out.Items = append(out.Items, domain.AccountBalance{Account: acc, Balance: balance})
+		out.Total += inBase
+	}
+	return out, nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"final/ledger/internal/domain"
+	"final/ledger/internal/grpcx"
+)
+
+func TestAccountsTransfersBalances(t *testing.T) {
+	app, store := newMemApp()
+	ctx := grpcx.WithUserID(context.Background(), "u1")
+	other := grpcx.WithUserID(context.Background(), "u2")
+
+	day := func(d int) time.Time { return time.Date(2025, 12, d, 0, 0, 0, 0, time.UTC) }
+	_ = memRates{store}.Upsert(ctx, []domain.ExchangeRate{
+		{Base: "EUR", Quote: "RUB", Date: day(1), Rate: big.NewRat(100, 1)},
+	})
+
+	// Без счёта транзакция попадает на счёт по умолчанию в базовой валюте.
+	salary, err := app.AddTransaction(ctx, domain.Transaction{Kind: domain.KindIncome, Amount: 500000, Category: "зарплата", Date: day(1)})
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if _, err := app.AddTransaction(ctx, domain.Transaction{Amount: 30000, Category: "еда", Date: day(2)}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	cash, err := app.CreateAccount(ctx, domain.Account{Name: " наличные ", Currency: "eur", OpeningBalance: 10000})
+	if err != nil {
+		t.Fatalf("create account: %v", err)
+	}
+	if cash.Name != "наличные" || cash.Currency != "EUR" || cash.IsDefault {
+		t.Fatalf("unexpected account: %+v", cash)
+	}
+
+	if _, err := app.AddTransaction(other, domain.Transaction{AccountID: cash.ID, Amount: 1, Category: "еда", Date: day(2)}); err != ErrAccountNotFound {
+		t.Fatalf("expected ErrAccountNotFound, got %v", err)
+	}
+
+	out, in, err := app.Transfer(ctx, domain.Transfer{FromAccountID: salary.AccountID, ToAccountID: cash.ID, Amount: 100000, Date: day(3)})
+	if err != nil {
+		t.Fatalf("transfer: %v", err)
+	}
+	if out.Amount != -100000 || out.Currency != "RUB" || in.Amount != 1000 || in.Currency != "EUR" || out.TransferID != in.ID || in.TransferID != out.ID {
+		t.Fatalf("unexpected legs: %+v %+v", out, in)
+	}
+
+	balances, err := app.GetBalances(ctx, day(31))
+	if err != nil {
+		t.Fatalf("balances: %v", err)
+	}
+	if len(balances.Items) != 2 || balances.Items[0].Balance != 370000 || balances.Items[1].Balance != 11000 {
+		t.Fatalf("unexpected balances: %+v", balances.Items)
+	}
+	if balances.Currency != "RUB" || balances.Total != 1470000 {
+		t.Fatalf("unexpected total: %s %s", balances.Total, balances.Currency)
+	}
+
+	cf, err := app.CashFlow(ctx, day(1), day(31), domain.PeriodFixed)
+	if err != nil {
+		t.Fatalf("cash flow: %v", err)
+	}
+	if cf.Total.Income != 500000 || cf.Total.Expense != 30000 {
+		t.Fatalf("transfers must not count as income or spending: %+v", cf.Total)
+	}
+
+	t.Run("transfer_is_read_only", func(t *testing.T) {
+		amount := domain.Money(1)
+		if _, err := app.UpdateTransaction(ctx, in.ID, domain.TransactionPatch{Amount: &amount}); err == nil {
+			t.Fatalf("expected error")
+		}
+	})
+
+	t.Run("transfer_kind_only_via_transfer", func(t *testing.T) {
+		_, err := app.AddTransaction(ctx, domain.Transaction{Kind: domain.KindTransfer, Amount: -100, Category: "еда", Date: day(3)})
+		if err == nil || err.Error() != "invalid transaction kind" {
+			t.Fatalf("expected invalid transaction kind on add, got %v", err)
+		}
+		plain, err := app.AddTransaction(ctx, domain.Transaction{Amount: 100, Category: "еда", Date: day(3)})
+		if err != nil {
+			t.Fatalf("add: %v", err)
+		}
+		kind := domain.KindTransfer
+		if _, err := app.UpdateTransaction(ctx, plain.ID, domain.TransactionPatch{Kind: &kind}); err == nil || err.Error() != "invalid transaction kind" {
+			t.Fatalf("expected invalid transaction kind on patch, got %v", err)
+		}
+		if err := app.DeleteTransaction(ctx, plain.ID); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+	})
+
+	t.Run("delete_removes_both_legs", func(t *testing.T) {
+		if err := app.DeleteTransaction(ctx, out.ID); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+		page, err := app.ListTransactions(ctx, domain.TransactionFilter{Kinds: []string{domain.KindTransfer}})
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		if len(page.Items) != 0 {
+			t.Fatalf("expected no transfer legs, got %+v", page.Items)
+		}
+	})
+
+	t.Run("same_account", func(t *testing.T) {
+		_, _, err := app.Transfer(ctx, domain.Transfer{ToAccountID: salary.AccountID, Amount: 1, Date: day(3)})
+		if err == nil || err.Error() != "transfer accounts must differ" {
+			t.Fatalf("expected error, got %v", err)
+		}
+	})
+}
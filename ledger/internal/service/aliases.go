@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"final/ledger/internal/domain"
+)
+
+// resolveCategory нормализует категорию и заменяет алиас категорией, на
+// которую он указывает.
+func (a *App) resolveCategory(ctx context.Context, uid, category string) (string, error) {
+	category = domain.NormalizeCategory(category)
+	target, ok, err := a.categories.ResolveAlias(ctx, uid, category)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return target, nil
+	}
+	return category, nil
+}
+
+// SetCategoryAlias создаёт алиас: новые транзакции, бюджеты и шаблоны с
+// категорией alias записываются в category. Уже сохранённые записи не
+// меняются — их переносит MergeCategoryNames.
+func (a *App) SetCategoryAlias(ctx context.Context, alias, category string) (domain.CategoryAlias, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.CategoryAlias{}, err
+	}
+	out := domain.CategoryAlias{UserID: uid, Alias: domain.NormalizeCategory(alias)}
+	if out.Alias == "" {
+		return domain.CategoryAlias{}, errors.New("alias is empty")
+	}
+	if domain.NormalizeCategory(category) == "" {
+		return domain.CategoryAlias{}, errors.New("category name is empty")
+	}
+
+	err = a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if out.Category, err = a.resolveCategory(ctx, uid, category); err != nil {
+			return err
+		}
+		if out.Category == out.Alias {
+			return errors.New("alias must differ from category")
+		}
+		tree, err := a.categoryTree(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if _, exists := tree.Get(out.Alias); exists {
+			return errors.New("alias conflicts with category")
+		}
+		return a.categories.SetAlias(ctx, out)
+	})
+	if err != nil {
+		return domain.CategoryAlias{}, err
+	}
+	return out, nil
+}
+
+func (a *App) ListCategoryAliases(ctx context.Context) ([]domain.CategoryAlias, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return a.categories.ListAliases(ctx, uid)
+}
+
+func (a *App) DeleteCategoryAlias(ctx context.Context, alias string) error {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return err
+	}
+	ok, err := a.categories.DeleteAlias(ctx, uid, domain.NormalizeCategory(alias))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// SuggestCategoryMerges предлагает слияния для дубликатов среди
+// используемых категорий.
+func (a *App) SuggestCategoryMerges(ctx context.Context) ([]domain.MergeSuggestion, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := a.categories.Usage(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	aliases, err := a.categories.ListAliases(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	byAlias := make(map[string]string, len(aliases))
+	for _, al := range aliases {
+		byAlias[al.Alias] = al.Category
+	}
+	return domain.SuggestMerges(usage, byAlias), nil
+}
+
+// MergeCategoryNames сливает категории по имени, в том числе отсутствующие в
+// дереве. from берётся как есть: старые записи могли сохраниться до
+// нормализации. Если обе категории есть в дереве, это MergeCategory; если
+// только from — её переименование.
+func (a *App) MergeCategoryNames(ctx context.Context, from, into string) (domain.Category, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.Category{}, err
+	}
+	from = strings.TrimSpace(from)
+	if from == "" || domain.NormalizeCategory(into) == "" {
+		return domain.Category{}, errors.New("category name is empty")
+	}
+
+	var out domain.Category
+	err = a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if into, err = a.resolveCategory(ctx, uid, into); err != nil {
+			return err
+		}
+		if from == into {
+			return errors.New("cannot merge category into itself")
+		}
+		tree, err := a.categoryTree(ctx, uid)
+		if err != nil {
+			return err
+		}
+		fc, fromInTree := tree.Get(from)
+		ic, intoInTree := tree.Get(into)
+		switch {
+		case fromInTree && intoInTree:
+			out, err = a.MergeCategory(ctx, fc.ID, ic.ID)
+			return err
+		case fromInTree:
+			out, err = a.UpdateCategory(ctx, fc.ID, domain.CategoryPatch{Name: &into})
+			return err
+		}
+		if err := a.categories.Repoint(ctx, uid, from, into); err != nil {
+			return err
+		}
+		out = ic
+		if !intoInTree {
+			out = domain.Category{UserID: uid, Name: into}
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.Category{}, err
+	}
+	return out, nil
+}
@@ -10,6 +10,15 @@ import (
 )
 
 func (a *App) BulkImportTransactions(ctx context.Context, items []domain.ImportItem, workers int) (domain.ImportSummary, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.ImportSummary{}, err
+	}
+	// Правила загружаются один раз на весь импорт.
+	rules, err := a.ruleSet(ctx, uid)
+	if err != nil {
+		return domain.ImportSummary{}, err
+	}
 	if workers <= 0 {
 		workers = 4
 	}
@@ -22,8 +31,9 @@ func (a *App) BulkImportTransactions(ctx context.Context, items []domain.ImportI
 	}
 
 	type result struct {
-		index int
-		err   error
+		index    int
+		err      error
+		warnings []domain.BudgetWarning
 	}
 
 	jobs := make(chan job)
@@ -44,12 +54,13 @@ func (a *App) BulkImportTransactions(ctx context.Context, items []domain.ImportI
 						return
 					}
 					tx := j.item.Tx
+					autoCategorize(rules, &tx)
 					tx.Category = domain.NormalizeCategory(tx.Category)
-					_, err := a.AddTransaction(ctx, tx)
+					created, err := a.AddTransaction(ctx, tx)
 					select {
 					case <-ctx.Done():
 						return
-					case results <- result{index: j.item.Index, err: err}:
+					case results <- result{index: j.item.Index, err: err, warnings: created.Warnings}:
 					}
 				}
 			}
@@ -75,6 +86,7 @@ func (a *App) BulkImportTransactions(ctx context.Context, items []domain.ImportI
 	var accepted int64
 	var rejected int64
 	errs := make([]domain.ImportError, 0)
+	warns := make([]domain.ImportWarning, 0)
 
 	done := make(chan struct{})
 	go func() {
@@ -82,6 +94,9 @@ func (a *App) BulkImportTransactions(ctx context.Context, items []domain.ImportI
 		for r := range results {
 			if r.err == nil {
 				atomic.AddInt64(&accepted, 1)
+				for _, w := range r.warnings {
+					warns = append(warns, domain.ImportWarning{Index: r.index, Warning: w})
+				}
 				continue
 			}
 			atomic.AddInt64(&rejected, 1)
@@ -96,6 +111,7 @@ func (a *App) BulkImportTransactions(ctx context.Context, items []domain.ImportI
 			Accepted: accepted,
 			Rejected: rejected,
 			Errors:   errs,
+			Warnings: warns,
 		}, ctx.Err()
 	case <-done:
 	}
@@ -104,6 +120,7 @@ func (a *App) BulkImportTransactions(ctx context.Context, items []domain.ImportI
 		Accepted: accepted,
 		Rejected: rejected,
 		Errors:   errs,
+		Warnings: warns,
 	}
 
 	if ctx.Err() != nil {
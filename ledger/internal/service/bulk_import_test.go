@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"final/ledger/internal/domain"
+	"final/ledger/internal/grpcx"
+)
+
+func TestBulkImportRespectsBudgetUnderConcurrency(t *testing.T) {
+	app, store := newMemApp()
+	ctx := grpcx.WithUserID(context.Background(), "u1")
+
+	if _, err := app.SetBudget(ctx, domain.Budget{Category: "еда", Limit: 100, Period: "monthly"}); err != nil {
+		t.Fatalf("set budget: %v", err)
+	}
+
+	date := time.Date(2025, 12, 19, 12, 0, 0, 0, time.UTC)
+	items := make([]domain.ImportItem, 0, 500)
+	for i := 0; i < 500; i++ {
+		items = append(items, domain.ImportItem{
+			Index: i,
+			Tx:    domain.Transaction{Amount: 1, Category: "Еда", Description: "x", Date: date},
+		})
+	}
+
+	summary, err := app.BulkImportTransactions(ctx, items, 64)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if summary.Accepted != 100 || summary.Rejected != 400 {
+		t.Fatalf("expected 100 accepted / 400 rejected, got %d / %d", summary.Accepted, summary.Rejected)
+	}
+
+	var total domain.Money
+	for _, e := range store.expenses {
+		total += e.Amount
+	}
+	if total > 100 {
+		t.Fatalf("budget overshot: spent %d of 100", total)
+	}
+}
+
+// TestPGConcurrentBudget проверяет блокировку строки бюджета на настоящем
+// Postgres: memStore её только имитирует.
+func TestPGConcurrentBudget(t *testing.T) {
+	app, _ := newPGApp(t)
+	ctx := grpcx.WithUserID(context.Background(), pgUserID)
+
+	if _, err := app.SetBudget(ctx, domain.Budget{Category: "еда", Limit: 100, Period: "monthly"}); err != nil {
+		t.Fatalf("set budget: %v", err)
+	}
+
+	date := time.Date(2025, 12, 19, 12, 0, 0, 0, time.UTC)
+	var (
+		wg                 sync.WaitGroup
+		accepted, rejected atomic.Int32
+	)
+	for i := 0; i < 300; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := app.AddTransaction(ctx, domain.Transaction{Amount: 1, Category: "еда", Date: date})
+			switch {
+			case err == nil:
+				accepted.Add(1)
+			case errors.Is(err, ErrBudgetExceeded):
+				rejected.Add(1)
+			default:
+				t.Errorf("add: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if accepted.Load() != 100 || rejected.Load() != 200 {
+		t.Fatalf("expected 100 accepted / 200 rejected, got %d / %d", accepted.Load(), rejected.Load())
+	}
+	totals, err := app.ReportSummary(ctx, time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if totals["еда"] != 100 {
+		t.Fatalf("expected 100 spent, got %s", totals["еда"])
+	}
+}
+
+func TestAddTransactionRequiresUser(t *testing.T) {
+	app, _ := newMemApp()
+
+	_, err := app.AddTransaction(context.Background(), domain.Transaction{
+		Amount: 1, Category: "еда", Date: time.Now(),
+	})
+	if err != ErrUnauthenticated {
+		t.Fatalf("expected ErrUnauthenticated, got %v", err)
+	}
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	"final/ledger/internal/domain"
+	"final/ledger/internal/grpcx"
+)
+
+func TestCashFlowAndKinds(t *testing.T) {
+	app, _ := newMemApp()
+	ctx := grpcx.WithUserID(context.Background(), "u1")
+
+	day := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
+	if _, err := app.SetBudget(ctx, domain.Budget{Category: "еда", Limit: 1000}); err != nil {
+		t.Fatalf("set budget: %v", err)
+	}
+
+	add := func(tx domain.Transaction) (domain.Transaction, error) {
+		return app.AddTransaction(ctx, tx)
+	}
+	if _, err := add(domain.Transaction{Kind: domain.KindIncome, Amount: 5000, Category: "зарплата", Date: day(11, 5)}); err != nil {
+		t.Fatalf("add income: %v", err)
+	}
+	if _, err := add(domain.Transaction{Amount: 900, Category: "еда", Date: day(11, 6)}); err != nil {
+		t.Fatalf("add expense: %v", err)
+	}
+	if _, err := add(domain.Transaction{Amount: 200, Category: "еда", Date: day(11, 7)}); err != ErrBudgetExceeded {
+		t.Fatalf("expected ErrBudgetExceeded, got %v", err)
+	}
+	// Возврат уменьшает траты категории и освобождает лимит.
+	refund, err := add(domain.Transaction{Kind: domain.KindRefund, Amount: 300, Category: "еда", Date: day(11, 8)})
+	if err != nil {
+		t.Fatalf("add refund: %v", err)
+	}
+	if _, err := add(domain.Transaction{Amount: 200, Category: "еда", Date: day(12, 1)}); err != nil {
+		t.Fatalf("add expense after refund: %v", err)
+	}
+	savings, err := app.CreateAccount(ctx, domain.Account{Name: "накопления"})
+	if err != nil {
+		t.Fatalf("create account: %v", err)
+	}
+	if _, _, err := app.Transfer(ctx, domain.Transfer{ToAccountID: savings.ID, Amount: 1000, Date: day(12, 2)}); err != nil {
+		t.Fatalf("transfer: %v", err)
+	}
+
+	cf, err := app.CashFlow(ctx, day(11, 1), day(12, 31), domain.PeriodMonthly)
+	if err != nil {
+		t.Fatalf("cash flow: %v", err)
+	}
+	if cf.Currency != domain.DefaultCurrency || len(cf.Periods) != 2 {
+		t.Fatalf("unexpected cash flow: %+v", cf)
+	}
+	nov, dec := cf.Periods[0], cf.Periods[1]
+	if nov.Income != 5000 || nov.Expense != 600 || dec.Income != 0 || dec.Expense != 200 {
+		t.Fatalf("unexpected periods: %+v", cf.Periods)
+	}
+	if cf.Total.Net() != 4200 || cf.Total.SavingsRate() != 84 {
+		t.Fatalf("unexpected total: %+v", cf.Total)
+	}
+
+	totals, err := app.ReportSummary(ctx, day(11, 1), day(12, 31))
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if len(totals) != 1 || totals["еда"] != 800 {
+		t.Fatalf("summary must count only spending, got %v", totals)
+	}
+
+	t.Run("refund_to_expense_rechecks_limit", func(t *testing.T) {
+		kind := domain.KindExpense
+		if _, err := app.UpdateTransaction(ctx, refund.ID, domain.TransactionPatch{Kind: &kind}); err != ErrBudgetExceeded {
+			t.Fatalf("expected ErrBudgetExceeded, got %v", err)
+		}
+	})
+
+	t.Run("list_by_kind", func(t *testing.T) {
+		page, err := app.ListTransactions(ctx, domain.TransactionFilter{Kinds: []string{domain.KindIncome, domain.KindTransfer}})
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		// Доход и обе половины перевода.
+		if len(page.Items) != 3 {
+			t.Fatalf("expected 3 transactions, got %+v", page.Items)
+		}
+	})
+}
+
+func TestBudgetProgress(t *testing.T) {
+	app, _ := newMemApp()
+	ctx := grpcx.WithUserID(context.Background(), "u1")
+
+	day := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
+	food, err := app.CreateCategory(ctx, domain.Category{Name: "еда"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := app.CreateCategory(ctx, domain.Category{Name: "кафе", ParentID: food.ID}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	for _, b := range []domain.Budget{
+		{Category: "еда", Limit: 3100, Period: domain.PeriodMonthly, Enforcement: domain.EnforcementSoft},
+		{Category: "такси", Limit: 700, Period: domain.PeriodWeekly},
+	} {
+		if _, err := app.SetBudget(ctx, b); err != nil {
+			t.Fatalf("set budget: %v", err)
+		}
+	}
+	for _, tx := range []domain.Transaction{
+		{Amount: 2000, Category: "еда", Date: day(12, 1)},
+		{Amount: 1500, Category: "кафе", Date: day(12, 20)},
+		{Kind: domain.KindRefund, Amount: 100, Category: "кафе", Date: day(12, 21)},
+		{Amount: 999, Category: "еда", Date: day(11, 30)},
+	} {
+		if _, err := app.AddTransaction(ctx, tx); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+
+	report, err := app.BudgetProgress(ctx, day(12, 1), day(12, 31))
+	if err != nil || len(report.Progress) != 2 {
+		t.Fatalf("unexpected report: %+v (%v)", report, err)
+	}
+	byCat := map[string]domain.BudgetProgressItem{}
+	for _, p := range report.Progress {
+		byCat[p.Category] = p
+	}
+	// Подкатегории входят в бюджет родителя, возврат уменьшает траты.
+	if p := byCat["еда"]; p.Limit != 3100 || p.Spent != 3400 || p.Remaining != -300 || p.Projected != 3400 || p.Currency != "RUB" {
+		t.Fatalf("unexpected food progress: %+v", p)
+	}
+	// На 31 день недельного бюджета приходится 31/7 лимита.
+	if p := byCat["такси"]; p.Limit != 3100 || p.Spent != 0 || p.Remaining != 3100 || p.Percent != 0 {
+		t.Fatalf("unexpected taxi progress: %+v", p)
+	}
+	if _, err := app.BudgetProgress(ctx, day(12, 31), day(12, 1)); err == nil {
+		t.Fatalf("expected range error")
+	}
+}
+
+func TestSpendingTimeSeries(t *testing.T) {
+	app, store := newMemApp()
+	ctx := grpcx.WithUserID(context.Background(), "u1")
+
+	day := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
+	_ = memRates{store}.Upsert(ctx, []domain.ExchangeRate{{Base: "EUR", Quote: "RUB", Date: day(11, 1), Rate: big.NewRat(100, 1)}})
+	for _, tx := range []domain.Transaction{
+		{Amount: 300, Category: "еда", Date: day(12, 1)},
+		{Amount: 200, Category: "кафе", Date: day(12, 3)},
+		{Amount: 5, Currency: "EUR", Category: "кафе", Date: day(12, 16)},
+		{Kind: domain.KindRefund, Amount: 100, Category: "еда", Date: day(12, 17)},
+		{Kind: domain.KindIncome, Amount: 9000, Category: "зарплата", Date: day(12, 5)},
+		{Amount: 999, Category: "еда", Date: day(11, 30)},
+	} {
+		if _, err := app.AddTransaction(ctx, tx); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+
+	s, err := app.SpendingTimeSeries(ctx, domain.SeriesQuery{From: day(12, 1), To: day(12, 21), Bucket: domain.BucketWeek})
+	if err != nil {
+		t.Fatalf("series: %v", err)
+	}
+	var totals []domain.Money
+	for _, p := range s.Points {
+		totals = append(totals, p.Total)
+	}
+	// Вторая неделя пустая, в третьей 5 EUR и возврат 100.
+	if s.Currency != "RUB" || s.Categories != nil || !slices.Equal(totals, []domain.Money{500, 0, 400}) {
+		t.Fatalf("unexpected series: %+v", s)
+	}
+
+	s, err = app.SpendingTimeSeries(ctx, domain.SeriesQuery{From: day(12, 1), To: day(12, 21), Bucket: domain.BucketWeek, ByCategory: true})
+	if err != nil || !slices.Equal(s.Categories, []string{"еда", "кафе"}) {
+		t.Fatalf("unexpected split: %+v (%v)", s, err)
+	}
+	if p := s.Points[2]; p.ByCategory["еда"] != -100 || p.ByCategory["кафе"] != 500 || s.Points[1].ByCategory["кафе"] != 0 {
+		t.Fatalf("unexpected split point: %+v", p)
+	}
+
+	// Без To ряд идёт до сегодняшней даты.
+	if s, err = app.SpendingTimeSeries(ctx, domain.SeriesQuery{From: time.Now().AddDate(0, 0, -2), Bucket: domain.BucketDay}); err != nil || len(s.Points) < 2 {
+		t.Fatalf("unexpected open series: %+v (%v)", s, err)
+	}
+	if _, err := app.SpendingTimeSeries(ctx, domain.SeriesQuery{From: day(12, 1), Timezone: "Mars/Olympus"}); err == nil || err.Error() != "invalid timezone" {
+		t.Fatalf("expected invalid timezone, got %v", err)
+	}
+}
+
+func TestComparePeriods(t *testing.T) {
+	app, _ := newMemApp()
+	ctx := grpcx.WithUserID(context.Background(), "u1")
+
+	day := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
+	food, err := app.CreateCategory(ctx, domain.Category{Name: "еда"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := app.CreateCategory(ctx, domain.Category{Name: "кафе", ParentID: food.ID}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	for _, tx := range []domain.Transaction{
+		{Amount: 800, Category: "еда", Date: day(11, 10)},
+		{Amount: 400, Category: "кино", Date: day(11, 12)},
+		{Amount: 700, Category: "еда", Date: day(12, 2)},
+		{Amount: 300, Category: "кафе", Date: day(12, 5)},
+		{Amount: 100, Category: "еда", Date: day(10, 31)},
+	} {
+		if _, err := app.AddTransaction(ctx, tx); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+
+	// Декабрь сравнивается с ноябрём; кафе входит в еду, как в сводном отчёте.
+	c, err := app.ComparePeriods(ctx, day(12, 1), day(12, 31), time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("compare: %v", err)
+	}
+	if !c.PreviousFrom.Equal(day(11, 1)) || !c.PreviousTo.Equal(day(11, 30)) || c.Currency != "RUB" {
+		t.Fatalf("unexpected ranges: %+v", c)
+	}
+	byCat := map[string]domain.CategoryDelta{}
+	for _, d := range c.Items {
+		byCat[d.Category] = d
+	}
+	if d := byCat["еда"]; d.Current != 1000 || d.Previous != 800 || d.DeltaPercent != 25 {
+		t.Fatalf("unexpected food delta: %+v", d)
+	}
+	// Трата кафе входит в итог один раз, хотя видна и в кафе, и в еде.
+	if c.Total.Current != 1000 || c.Total.Previous != 1200 || c.Total.Delta != -200 {
+		t.Fatalf("unexpected total: %+v", c.Total)
+	}
+	if !slices.Equal(c.New, []string{"кафе"}) || !slices.Equal(c.Disappeared, []string{"кино"}) {
+		t.Fatalf("unexpected comparison: %+v", c)
+	}
+
+	c, err = app.ComparePeriods(ctx, day(12, 1), day(12, 31), day(10, 1), day(10, 31))
+	if err != nil || c.Total.Current != 1000 || c.Total.Previous != 100 || len(c.Disappeared) != 0 {
+		t.Fatalf("unexpected explicit comparison: %+v (%v)", c, err)
+	}
+	if _, err := app.ComparePeriods(ctx, day(12, 1), day(12, 31), day(10, 1), time.Time{}); err == nil || err.Error() != "compare range is incomplete" {
+		t.Fatalf("expected compare range is incomplete, got %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"final/ledger/internal/domain"
+)
+
+func (a *App) CreateCategory(ctx context.Context, c domain.Category) (domain.Category, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.Category{}, err
+	}
+	if err := c.Validate(); err != nil {
+		return domain.Category{}, err
+	}
+	c.UserID = uid
+	c.Name = domain.NormalizeCategory(c.Name)
+	c.Icon = strings.TrimSpace(c.Icon)
+
+	var out domain.Category
+	err = a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		tree, err := a.categoryTree(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if _, exists := tree.Get(c.Name); exists {
+			return errors.New("category already exists")
+		}
+		if _, isAlias, err := a.categories.ResolveAlias(ctx, uid, c.Name); err != nil {
+			return err
+		} else if isAlias {
+			return errors.New("category name is an alias")
+		}
+		if c.ParentID != 0 {
+			if _, ok := tree.ByID(c.ParentID); !ok {
+				return ErrCategoryNotFound
+			}
+		}
+		out, err = a.categories.Insert(ctx, c)
+		return err
+	})
+	if err != nil {
+		return domain.Category{}, err
+	}
+	return out, nil
+}
+
+func (a *App) ListCategories(ctx context.Context) ([]domain.Category, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return a.categories.List(ctx, uid)
+}
+
+// UpdateCategory переименовывает, переносит, архивирует категорию или меняет
+// её иконку. При переименовании транзакции, шаблоны и бюджет категории
+// переходят на новое имя.
+func (a *App) UpdateCategory(ctx context.Context, id int, p domain.CategoryPatch) (domain.Category, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.Category{}, err
+	}
+
+	var out domain.Category
+	err = a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		tree, err := a.categoryTree(ctx, uid)
+		if err != nil {
+			return err
+		}
+		old, ok := tree.ByID(id)
+		if !ok {
+			return ErrCategoryNotFound
+		}
+
+		c := old
+		if p.Name != nil {
+			c.Name = domain.NormalizeCategory(*p.Name)
+		}
+		if p.Icon != nil {
+			c.Icon = strings.TrimSpace(*p.Icon)
+		}
+		if p.Archived != nil {
+			c.Archived = *p.Archived
+		}
+		if p.ParentID != nil {
+			c.ParentID = *p.ParentID
+		}
+		if err := c.Validate(); err != nil {
+			return err
+		}
+		if c.ParentID != old.ParentID && c.ParentID != 0 {
+			if _, ok := tree.ByID(c.ParentID); !ok {
+				return ErrCategoryNotFound
+			}
+			if tree.IsDescendant(c.ParentID, c.ID) {
+				return errors.New("category cannot be moved under itself")
+			}
+		}
+		if c.Name != old.Name {
+			if _, exists := tree.Get(c.Name); exists {
+				return errors.New("category already exists")
+			}
+		}
+
+		if err := a.categories.Update(ctx, c); err != nil {
+			return err
+		}
+		if c.Name != old.Name {
+			if err := a.categories.Repoint(ctx, uid, old.Name, c.Name); err != nil {
+				return err
+			}
+		}
+		out = c
+		return nil
+	})
+	if err != nil {
+		return domain.Category{}, err
+	}
+	return out, nil
+}
+
+// MergeCategory сливает категорию from в into: транзакции, шаблоны и
+// подкатегории переходят в into, бюджет — если у into своего нет; сама
+// категория from удаляется.
+func (a *App) MergeCategory(ctx context.Context, fromID, intoID int) (domain.Category, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.Category{}, err
+	}
+
+	var into domain.Category
+	err = a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		tree, err := a.categoryTree(ctx, uid)
+		if err != nil {
+			return err
+		}
+		from, ok := tree.ByID(fromID)
+		if !ok {
+			return ErrCategoryNotFound
+		}
+		if into, ok = tree.ByID(intoID); !ok {
+			return ErrCategoryNotFound
+		}
+		if from.ID == into.ID {
+			return errors.New("cannot merge category into itself")
+		}
+		if tree.IsDescendant(into.ID, from.ID) {
+			return errors.New("cannot merge category into its subcategory")
+		}
+
+		for _, name := range tree.Subtree(from.Name)[1:] {
+			child, _ := tree.Get(name)
+			if child.ParentID != from.ID {
+				continue
+			}
+			child.ParentID = into.ID
+			if err := a.categories.Update(ctx, child); err != nil {
+				return err
+			}
+		}
+		if err := a.categories.Repoint(ctx, uid, from.Name, into.Name); err != nil {
+			return err
+		}
+		return a.categories.Delete(ctx, from.ID)
+	})
+	if err != nil {
+		return domain.Category{}, err
+	}
+	return into, nil
+}
+
+func (a *App) categoryTree(ctx context.Context, uid string) (domain.CategoryTree, error) {
+	cats, err := a.categories.List(ctx, uid)
+	if err != nil {
+		return domain.CategoryTree{}, err
+	}
+	return domain.NewCategoryTree(cats), nil
+}
+
+// checkCategoryActive не даёт выбрать архивную категорию.
+func checkCategoryActive(tree domain.CategoryTree, name string) error {
+	if c, ok := tree.Get(name); ok && c.Archived {
+		return ErrCategoryArchived
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"final/ledger/internal/domain"
+	"final/ledger/internal/grpcx"
+)
+
+func TestCategories(t *testing.T) {
+	app, store := newMemApp()
+	ctx := grpcx.WithUserID(context.Background(), "u1")
+	other := grpcx.WithUserID(context.Background(), "u2")
+
+	day := func(d int) time.Time { return time.Date(2025, 12, d, 0, 0, 0, 0, time.UTC) }
+
+	food, err := app.CreateCategory(ctx, domain.Category{Name: " Еда ", Icon: "🍽"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	cafe, err := app.CreateCategory(ctx, domain.Category{Name: "кафе", ParentID: food.ID})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if food.Name != "еда" || cafe.ParentID != food.ID {
+		t.Fatalf("unexpected categories: %+v %+v", food, cafe)
+	}
+	if _, err := app.CreateCategory(ctx, domain.Category{Name: "ЕДА"}); err == nil || err.Error() != "category already exists" {
+		t.Fatalf("expected category already exists, got %v", err)
+	}
+	if _, err := app.CreateCategory(other, domain.Category{Name: "x", ParentID: food.ID}); err != ErrCategoryNotFound {
+		t.Fatalf("expected ErrCategoryNotFound, got %v", err)
+	}
+	parent := cafe.ID
+	if _, err := app.UpdateCategory(ctx, food.ID, domain.CategoryPatch{ParentID: &parent}); err == nil {
+		t.Fatalf("expected cycle to be rejected")
+	}
+
+	// Бюджет родителя учитывает траты подкатегорий.
+	if _, err := app.SetBudget(ctx, domain.Budget{Category: "еда", Limit: 1000, Period: domain.PeriodMonthly}); err != nil {
+		t.Fatalf("set budget: %v", err)
+	}
+	if _, err := app.AddTransaction(ctx, domain.Transaction{Amount: 600, Category: "еда", Date: day(1)}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	coffee, err := app.AddTransaction(ctx, domain.Transaction{Amount: 300, Category: "Кафе", Date: day(2)})
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if _, err := app.AddTransaction(ctx, domain.Transaction{Amount: 200, Category: "кафе", Date: day(3)}); err != ErrBudgetExceeded {
+		t.Fatalf("expected ErrBudgetExceeded from parent budget, got %v", err)
+	}
+	amount := domain.Money(500)
+	if _, err := app.UpdateTransaction(ctx, coffee.ID, domain.TransactionPatch{Amount: &amount}); err != ErrBudgetExceeded {
+		t.Fatalf("expected ErrBudgetExceeded on update, got %v", err)
+	}
+
+	report, err := app.ReportSummary(ctx, day(1), day(31))
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if report["еда"] != 900 || report["кафе"] != 300 {
+		t.Fatalf("unexpected report: %v", report)
+	}
+
+	// Переименование переносит историю и сальдо журнала.
+	name := "Рестораны"
+	cafe, err = app.UpdateCategory(ctx, cafe.ID, domain.CategoryPatch{Name: &name})
+	if err != nil || cafe.Name != "рестораны" {
+		t.Fatalf("rename: %+v (%v)", cafe, err)
+	}
+	page, err := app.ListTransactions(ctx, domain.TransactionFilter{Categories: []string{"рестораны"}})
+	if err != nil || len(page.Items) != 1 {
+		t.Fatalf("expected renamed history, got %+v (%v)", page.Items, err)
+	}
+	tb, err := app.TrialBalance(ctx, time.Time{})
+	if err != nil || !tb.Balanced() {
+		t.Fatalf("trial balance: %+v (%v)", tb, err)
+	}
+	for _, l := range tb.Lines {
+		if (l.Account == "expenses:кафе" && l.Balance() != 0) || (l.Account == "expenses:рестораны" && l.Balance() != 300) {
+			t.Fatalf("unexpected journal line: %+v", l)
+		}
+	}
+
+	// Слияние: транзакции и бюджет переходят в целевую категорию.
+	taxi, err := app.CreateCategory(ctx, domain.Category{Name: "такси"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := app.SetBudget(ctx, domain.Budget{Category: "такси", Limit: 5000}); err != nil {
+		t.Fatalf("set budget: %v", err)
+	}
+	if _, err := app.AddTransaction(ctx, domain.Transaction{Amount: 100, Category: "такси", Date: day(4)}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	transport, err := app.CreateCategory(ctx, domain.Category{Name: "транспорт"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := app.MergeCategory(ctx, taxi.ID, transport.ID); err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if _, ok := store.budgets[budgetKey("u1", "транспорт")]; !ok {
+		t.Fatalf("budget must move to the merge target")
+	}
+	cats, _ := app.ListCategories(ctx)
+	if len(cats) != 3 {
+		t.Fatalf("merged category must be removed, got %+v", cats)
+	}
+	if _, err := app.MergeCategory(ctx, food.ID, cafe.ID); err == nil {
+		t.Fatalf("expected merge into subcategory to be rejected")
+	}
+
+	archived := true
+	if _, err := app.UpdateCategory(ctx, transport.ID, domain.CategoryPatch{Archived: &archived}); err != nil {
+		t.Fatalf("archive: %v", err)
+	}
+	if _, err := app.AddTransaction(ctx, domain.Transaction{Amount: 1, Category: "транспорт", Date: day(5)}); err != ErrCategoryArchived {
+		t.Fatalf("expected ErrCategoryArchived, got %v", err)
+	}
+}
+
+func TestCategoryAliases(t *testing.T) {
+	app, store := newMemApp()
+	ctx := grpcx.WithUserID(context.Background(), "u1")
+	day := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	// Записи, сохранённые до нормализации и до алиаса.
+	store.expenses = append(store.expenses,
+		domain.Transaction{ID: 100, UserID: "u1", Kind: domain.KindExpense, Amount: 50, Category: "taxi", Date: day},
+		domain.Transaction{ID: 101, UserID: "u1", Kind: domain.KindExpense, Amount: 70, Category: "тaкси", Date: day},
+	)
+
+	if _, err := app.AddTransaction(ctx, domain.Transaction{Amount: 100, Category: "Такси ", Date: day}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	al, err := app.SetCategoryAlias(ctx, " TAXI", "такси")
+	if err != nil || al.Alias != "taxi" || al.Category != "такси" {
+		t.Fatalf("set alias: %+v (%v)", al, err)
+	}
+	if _, err := app.SetCategoryAlias(ctx, "такси", "Такси"); err == nil || err.Error() != "alias must differ from category" {
+		t.Fatalf("expected alias must differ from category, got %v", err)
+	}
+	if _, err := app.SetCategoryAlias(ctx, "cab", "taxi"); err != nil {
+		t.Fatalf("set alias: %v", err)
+	}
+	aliases, _ := app.ListCategoryAliases(ctx)
+	if len(aliases) != 2 || aliases[0].Category != "такси" || aliases[1].Category != "такси" {
+		t.Fatalf("alias chain must resolve to the category, got %+v", aliases)
+	}
+
+	created, err := app.AddTransaction(ctx, domain.Transaction{Amount: 10, Category: "Cab", Date: day})
+	if err != nil || created.Category != "такси" {
+		t.Fatalf("alias must resolve on add: %+v (%v)", created, err)
+	}
+	b, err := app.SetBudget(ctx, domain.Budget{Category: "taxi", Limit: 1000})
+	if err != nil || b.Category != "такси" {
+		t.Fatalf("alias must resolve on budget: %+v (%v)", b, err)
+	}
+	summary, err := app.BulkImportTransactions(ctx, []domain.ImportItem{{Index: 0, Tx: domain.Transaction{Amount: 5, Category: "тaкси", Date: day}}}, 1)
+	if err != nil || summary.Accepted != 1 {
+		t.Fatalf("bulk import: %+v (%v)", summary, err)
+	}
+
+	suggestions, err := app.SuggestCategoryMerges(ctx)
+	if err != nil || len(suggestions) != 2 ||
+		suggestions[0] != (domain.MergeSuggestion{From: "taxi", Into: "такси", Reason: domain.SuggestAlias, FromCount: 1, IntoCount: 3}) ||
+		suggestions[1] != (domain.MergeSuggestion{From: "тaкси", Into: "такси", Reason: domain.SuggestNormalized, FromCount: 1, IntoCount: 3}) {
+		t.Fatalf("unexpected suggestions: %+v (%v)", suggestions, err)
+	}
+	for _, s := range suggestions {
+		if _, err := app.MergeCategoryNames(ctx, s.From, s.Into); err != nil {
+			t.Fatalf("merge %q: %v", s.From, err)
+		}
+	}
+	if suggestions, _ = app.SuggestCategoryMerges(ctx); len(suggestions) != 0 {
+		t.Fatalf("expected no suggestions after merge, got %+v", suggestions)
+	}
+	page, err := app.ListTransactions(ctx, domain.TransactionFilter{Categories: []string{"такси"}})
+	if err != nil || len(page.Items) != 5 {
+		t.Fatalf("expected merged history, got %d items (%v)", len(page.Items), err)
+	}
+
+	if err := app.DeleteCategoryAlias(ctx, "cab"); err != nil {
+		t.Fatalf("delete alias: %v", err)
+	}
+	if err := app.DeleteCategoryAlias(ctx, "cab"); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := app.CreateCategory(ctx, domain.Category{Name: "taxi"}); err == nil || err.Error() != "category name is an alias" {
+		t.Fatalf("expected category name is an alias, got %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"slices"
+	"time"
+
+	"final/ledger/internal/domain"
+)
+
+func (a *App) GetSettings(ctx context.Context) (domain.UserSettings, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.UserSettings{}, err
+	}
+	base, err := a.baseCurrency(ctx, uid)
+	if err != nil {
+		return domain.UserSettings{}, err
+	}
+	return domain.UserSettings{UserID: uid, BaseCurrency: base}, nil
+}
+
+func (a *App) UpdateSettings(ctx context.Context, s domain.UserSettings) (domain.UserSettings, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.UserSettings{}, err
+	}
+	if err := s.Validate(); err != nil {
+		return domain.UserSettings{}, err
+	}
+	s.UserID = uid
+	s.BaseCurrency = domain.NormalizeCurrency(s.BaseCurrency)
+	if err := a.settings.Upsert(ctx, s); err != nil {
+		return domain.UserSettings{}, err
+	}
+	return s, nil
+}
+
+func (a *App) baseCurrency(ctx context.Context, uid string) (string, error) {
+	s, ok, err := a.settings.Get(ctx, uid)
+	if err != nil {
+		return "", err
+	}
+	if !ok || s.BaseCurrency == "" {
+		return domain.DefaultCurrency, nil
+	}
+	return s.BaseCurrency, nil
+}
+
+// convert пересчитывает сумму из валюты from в to по курсу на дату on.
+func (a *App) convert(ctx context.Context, m domain.Money, from, to string, on time.Time) (domain.Money, error) {
+	if from == to || m == 0 {
+		return m, nil
+	}
+	rate, ok, err := domain.FindRate(from, to, func(base, quote string) (*big.Rat, bool, error) {
+		return a.rates.Rate(ctx, base, quote, on)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, ErrNoRate
+	}
+	return m.Convert(rate), nil
+}
+
+// convertAll суммирует траты в валюте to, пересчитывая каждую группу по курсу
+// на её дату; курсы читаются одним запросом на все группы.
+func (a *App) convertAll(ctx context.Context, amounts []domain.DatedAmount, to string) (domain.Money, error) {
+	rates, err := a.loadRates(ctx, amounts, to)
+	if err != nil {
+		return 0, err
+	}
+	return sumWith(rates, amounts, to)
+}
+
+// loadRates одним запросом загружает курсы, нужные для пересчёта amounts в
+// любую из валют targets по курсу на дату каждой суммы. Если все суммы уже
+// в единственной валюте targets, запроса нет.
+func (a *App) loadRates(ctx context.Context, amounts []domain.DatedAmount, targets ...string) (domain.RateTable, error) {
+	var (
+		currencies = append([]string{}, targets...)
+		from, to   time.Time
+	)
+	for _, am := range amounts {
+		if am.Amount == 0 {
+			continue
+		}
+		if !slices.Contains(currencies, am.Currency) {
+			currencies = append(currencies, am.Currency)
+		}
+		if from.IsZero() || am.Date.Before(from) {
+			from = am.Date
+		}
+		if am.Date.After(to) {
+			to = am.Date
+		}
+	}
+	if len(currencies) < 2 || from.IsZero() {
+		return nil, nil
+	}
+	rates, err := a.rates.RatesInRange(ctx, domain.WithPivots(currencies), from, to)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewRateTable(rates), nil
+}
+
+// convertWith — convert по курсам, загруженным loadRates.
+func convertWith(rates domain.RateTable, m domain.Money, from, to string, on time.Time) (domain.Money, error) {
+	if from == to || m == 0 {
+		return m, nil
+	}
+	rate, ok, _ := domain.FindRate(from, to, func(base, quote string) (*big.Rat, bool, error) {
+		r, ok := rates.Rate(base, quote, on)
+		return r, ok, nil
+	})
+	if !ok {
+		return 0, ErrNoRate
+	}
+	return m.Convert(rate), nil
+}
+
+// sumWith — convertAll по курсам, загруженным loadRates.
+func sumWith(rates domain.RateTable, amounts []domain.DatedAmount, to string) (domain.Money, error) {
+	var total domain.Money
+	for _, am := range amounts {
+		v, err := convertWith(rates, am.Amount, am.Currency, to, am.Date)
+		if err != nil {
+			return 0, err
+		}
+		total += v
+	}
+	return total, nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"final/ledger/internal/domain"
+	"final/ledger/internal/grpcx"
+)
+
+func TestMultiCurrency(t *testing.T) {
+	app, store := newMemApp()
+	ctx := grpcx.WithUserID(context.Background(), "u1")
+
+	day := func(d int) time.Time { return time.Date(2025, 12, d, 12, 0, 0, 0, time.UTC) }
+	_ = memRates{store}.Upsert(ctx, []domain.ExchangeRate{
+		{Base: "EUR", Quote: "RUB", Date: day(1), Rate: big.NewRat(100, 1)},
+		{Base: "EUR", Quote: "RUB", Date: day(15), Rate: big.NewRat(90, 1)},
+	})
+
+	if _, err := app.SetBudget(ctx, domain.Budget{Category: "еда", Limit: 100000}); err != nil {
+		t.Fatalf("set budget: %v", err)
+	}
+
+	// 5 EUR по курсу 100 на 2 декабря — 500 руб.
+	tx, err := app.AddTransaction(ctx, domain.Transaction{Amount: 500, Currency: "eur", Category: "еда", Date: day(2)})
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if tx.Currency != "EUR" {
+		t.Fatalf("currency must be normalized, got %q", tx.Currency)
+	}
+
+	// 5.50 EUR по курсу 90 — 495 руб., итого 995; 6 EUR было бы уже 1040.
+	if _, err := app.AddTransaction(ctx, domain.Transaction{Amount: 600, Currency: "EUR", Category: "еда", Date: day(16)}); err != ErrBudgetExceeded {
+		t.Fatalf("expected ErrBudgetExceeded, got %v", err)
+	}
+	if _, err := app.AddTransaction(ctx, domain.Transaction{Amount: 550, Currency: "EUR", Category: "еда", Date: day(16)}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	totals, err := app.ReportSummary(ctx, day(1), day(31))
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if totals["еда"] != 99500 {
+		t.Fatalf("expected 995 RUB, got %s", totals["еда"])
+	}
+
+	t.Run("rates_prefetched", func(t *testing.T) {
+		// Траты за два дня с разными курсами — курсы читаются одним запросом.
+		store.rateQueries = 0
+		if _, err := app.ReportSummary(ctx, day(1), day(31)); err != nil {
+			t.Fatalf("report: %v", err)
+		}
+		if store.rateQueries != 1 {
+			t.Fatalf("expected 1 rate query, got %d", store.rateQueries)
+		}
+	})
+
+	t.Run("base_currency", func(t *testing.T) {
+		if _, err := app.UpdateSettings(ctx, domain.UserSettings{BaseCurrency: "eur"}); err != nil {
+			t.Fatalf("update settings: %v", err)
+		}
+		totals, err := app.ReportSummary(ctx, day(1), day(31))
+		if err != nil {
+			t.Fatalf("report: %v", err)
+		}
+		if totals["еда"] != 1050 {
+			t.Fatalf("expected 10.50 EUR, got %s", totals["еда"])
+		}
+		if _, err := app.UpdateSettings(ctx, domain.UserSettings{BaseCurrency: "euro"}); err == nil || err.Error() != "invalid currency" {
+			t.Fatalf("expected invalid currency, got %v", err)
+		}
+	})
+
+	t.Run("no_rate", func(t *testing.T) {
+		if _, err := app.AddTransaction(ctx, domain.Transaction{Amount: 100, Currency: "USD", Category: "еда", Date: day(3)}); err != ErrNoRate {
+			t.Fatalf("expected ErrNoRate, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,802 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"final/ledger/internal/domain"
+	"final/ledger/internal/journal"
+)
+
+// memStore — in-memory реализация репозиториев и Transactor для тестов.
+// GetForUpdate держит блокировку строки бюджета до конца WithinTx,
+// как SELECT ... FOR UPDATE в Postgres.
+type memStore struct {
+	mu       sync.Mutex
+	budgets  map[string]domain.Budget
+	expenses []domain.Transaction
+	nextID   int
+	rowLocks map[string]*sync.Mutex
+	settings map[string]domain.UserSettings
+	rates    []domain.ExchangeRate
+	// rateQueries — сколько раз читались курсы (Rate и RatesInRange),
+	// spendingQueries — траты по категориям для отчётов и бюджетов.
+	rateQueries     int
+	spendingQueries int
+	accounts        []domain.Account
+	journal         []domain.JournalEntry
+	// recurring индексируется id-1; occurrences — ключ "id|дата" → id транзакции.
+	recurring   []domain.Recurring
+	occurrences map[string]int
+	categories  map[int]domain.Category
+	nextCatID   int
+	// aliases — алиасы категорий по budgetKey(userID, alias).
+	aliases    map[string]string
+	rules      map[int]domain.Rule
+	nextRuleID int
+}
+
+func newMemStore() *memStore {
+	return &memStore{
+		budgets:     map[string]domain.Budget{},
+		rowLocks:    map[string]*sync.Mutex{},
+		settings:    map[string]domain.UserSettings{},
+		occurrences: map[string]int{},
+		categories:  map[int]domain.Category{},
+		aliases:     map[string]string{},
+		rules:       map[int]domain.Rule{},
+	}
+}
+
+func budgetKey(userID, category string) string {
+	return userID + "|" + category
+}
+
+type heldLocksKey struct{}
+
+type heldLocks struct {
+	locks []*sync.Mutex
+}
+
+// has сообщает, что блокировка уже взята в этой транзакции: как и в
+// Postgres, повторный FOR UPDATE той же строки не ждёт.
+func (h *heldLocks) has(l *sync.Mutex) bool {
+	for _, x := range h.locks {
+		if x == l {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *memStore) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(heldLocksKey{}).(*heldLocks); ok {
+		return fn(ctx)
+	}
+	held := &heldLocks{}
+	err := fn(context.WithValue(ctx, heldLocksKey{}, held))
+	for i := len(held.locks) - 1; i >= 0; i-- {
+		held.locks[i].Unlock()
+	}
+	return err
+}
+
+func (s *memStore) Upsert(ctx context.Context, b domain.Budget) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.budgets[budgetKey(b.UserID, b.Category)] = b
+	return nil
+}
+
+func (s *memStore) Get(ctx context.Context, userID, category string) (domain.Budget, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.budgets[budgetKey(userID, category)]
+	return b, ok, nil
+}
+
+func (s *memStore) GetForUpdate(ctx context.Context, userID, category string) (domain.Budget, bool, error) {
+	key := budgetKey(userID, category)
+
+	s.mu.Lock()
+	b, ok := s.budgets[key]
+	l, exists := s.rowLocks[key]
+	if !exists {
+		l = &sync.Mutex{}
+		s.rowLocks[key] = l
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		return domain.Budget{}, false, nil
+	}
+	if held, inTx := ctx.Value(heldLocksKey{}).(*heldLocks); inTx && !held.has(l) {
+		l.Lock()
+		held.locks = append(held.locks, l)
+	}
+	return b, true, nil
+}
+
+func (s *memStore) List(ctx context.Context, userID string) ([]domain.Budget, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]domain.Budget, 0)
+	for _, b := range s.budgets {
+		if b.UserID == userID {
+			out = append(out, b)
+		}
+	}
+	return out, nil
+}
+
+type memExpenses struct {
+	*memStore
+}
+
+func (e memExpenses) Insert(ctx context.Context, t domain.Transaction) (int, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.nextID++
+	t.ID = e.nextID
+	t.Tags = slices.Clone(t.Tags)
+	e.expenses = append(e.expenses, t)
+	return t.ID, nil
+}
+
+func (e memExpenses) GetForUpdate(ctx context.Context, userID string, id int) (domain.Transaction, bool, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, t := range e.expenses {
+		if t.ID == id && t.UserID == userID {
+			return t, true, nil
+		}
+	}
+	return domain.Transaction{}, false, nil
+}
+
+func (e memExpenses) Update(ctx context.Context, t domain.Transaction) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i := range e.expenses {
+		if e.expenses[i].ID == t.ID {
+			t.Warnings = nil
+			t.Tags = slices.Clone(t.Tags)
+			e.expenses[i] = t
+		}
+	}
+	return nil
+}
+
+func (e memExpenses) Delete(ctx context.Context, userID string, id int) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	out := e.expenses[:0]
+	for _, t := range e.expenses {
+		if t.ID != id || t.UserID != userID {
+			out = append(out, t)
+		}
+	}
+	e.expenses = out
+	return nil
+}
+
+func (e memExpenses) List(ctx context.Context, userID string, f domain.TransactionFilter, limit int) ([]domain.Transaction, error) {
+	cursor, err := f.Cursor()
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	out := make([]domain.Transaction, 0)
+	for _, t := range e.expenses {
+		if t.UserID != userID || !f.Matches(t) {
+			continue
+		}
+		if cursor != nil && !cursor.Follows(t) {
+			continue
+		}
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return domain.SortLess(f.Sort, out[i], out[j]) })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (e memExpenses) amounts(userID string, categories []string, from, to time.Time, bounded bool) []domain.DatedAmount {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var out []domain.DatedAmount
+	for _, t := range e.expenses {
+		if t.UserID != userID || !slices.Contains(categories, t.Category) || t.Spending() == 0 {
+			continue
+		}
+		d := time.Date(t.Date.Year(), t.Date.Month(), t.Date.Day(), 0, 0, 0, 0, time.UTC)
+		if bounded && (d.Before(from) || d.After(to)) {
+			continue
+		}
+		out = append(out, domain.DatedAmount{Currency: t.Currency, Date: d, Amount: t.Spending()})
+	}
+	// Даём другим горутинам вклиниться между чтением суммы и вставкой.
+	runtime.Gosched()
+	return out
+}
+
+func (e memExpenses) AmountsByCategory(ctx context.Context, userID string, categories []string) ([]domain.DatedAmount, error) {
+	return e.amounts(userID, categories, time.Time{}, time.Time{}, false), nil
+}
+
+func (e memExpenses) AmountsByCategoryInRange(ctx context.Context, userID string, categories []string, from, to time.Time) ([]domain.DatedAmount, error) {
+	e.mu.Lock()
+	e.spendingQueries++
+	e.mu.Unlock()
+	return e.amounts(userID, categories, from, to, true), nil
+}
+
+func (e memExpenses) SpendingByCategoryInRange(ctx context.Context, userID string, from, to time.Time) (map[string][]domain.DatedAmount, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spendingQueries++
+	out := map[string][]domain.DatedAmount{}
+	for _, t := range e.expenses {
+		d := time.Date(t.Date.Year(), t.Date.Month(), t.Date.Day(), 0, 0, 0, 0, time.UTC)
+		if t.UserID != userID || d.Before(from) || d.After(to) || t.Spending() == 0 {
+			continue
+		}
+		out[t.Category] = append(out[t.Category], domain.DatedAmount{Currency: t.Currency, Date: d, Amount: t.Spending()})
+	}
+	return out, nil
+}
+
+func (e memExpenses) AmountsByKindInRange(ctx context.Context, userID string, from, to time.Time) ([]domain.DatedAmount, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var out []domain.DatedAmount
+	for _, t := range e.expenses {
+		d := time.Date(t.Date.Year(), t.Date.Month(), t.Date.Day(), 0, 0, 0, 0, time.UTC)
+		if t.UserID != userID || d.Before(from) || d.After(to) || t.Kind == domain.KindTransfer {
+			continue
+		}
+		out = append(out, domain.DatedAmount{Kind: t.Kind, Currency: t.Currency, Date: d, Amount: t.Amount})
+	}
+	return out, nil
+}
+
+func (e memExpenses) AmountsByTagInRange(ctx context.Context, userID string, from, to time.Time) (map[string][]domain.DatedAmount, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	out := map[string][]domain.DatedAmount{}
+	for _, t := range e.expenses {
+		d := time.Date(t.Date.Year(), t.Date.Month(), t.Date.Day(), 0, 0, 0, 0, time.UTC)
+		if t.UserID != userID || d.Before(from) || d.After(to) || t.Spending() == 0 {
+			continue
+		}
+		for _, tag := range t.Tags {
+			out[tag] = append(out[tag], domain.DatedAmount{Currency: t.Currency, Date: d, Amount: t.Spending()})
+		}
+	}
+	return out, nil
+}
+
+func (e memExpenses) LinkTransfer(ctx context.Context, a, b int) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for i := range e.expenses {
+		switch e.expenses[i].ID {
+		case a:
+			e.expenses[i].TransferID = b
+		case b:
+			e.expenses[i].TransferID = a
+		}
+	}
+	return nil
+}
+
+func (e memExpenses) BalanceAmounts(ctx context.Context, userID string, on time.Time) (map[int][]domain.DatedAmount, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	out := map[int][]domain.DatedAmount{}
+	for _, t := range e.expenses {
+		d := time.Date(t.Date.Year(), t.Date.Month(), t.Date.Day(), 0, 0, 0, 0, time.UTC)
+		if t.UserID != userID || d.After(on) {
+			continue
+		}
+		out[t.AccountID] = append(out[t.AccountID], domain.DatedAmount{Currency: t.Currency, Date: d, Amount: t.BalanceEffect()})
+	}
+	return out, nil
+}
+
+type memAccounts struct {
+	*memStore
+}
+
+func (m memAccounts) Insert(ctx context.Context, a domain.Account) (domain.Account, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.insertAccount(a), nil
+}
+
+// insertAccount вызывается под m.mu.
+func (m memAccounts) insertAccount(a domain.Account) domain.Account {
+	a.ID = len(m.accounts) + 1
+	a.IsDefault = true
+	for _, acc := range m.accounts {
+		if acc.UserID == a.UserID && acc.IsDefault {
+			a.IsDefault = false
+		}
+	}
+	m.accounts = append(m.accounts, a)
+	return a
+}
+
+func (m memAccounts) Get(ctx context.Context, id int) (domain.Account, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if id <= 0 || id > len(m.accounts) {
+		return domain.Account{}, false, nil
+	}
+	return m.accounts[id-1], true, nil
+}
+
+func (m memAccounts) List(ctx context.Context, userID string) ([]domain.Account, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]domain.Account, 0)
+	for _, a := range m.accounts {
+		if a.UserID == userID {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (m memAccounts) EnsureDefault(ctx context.Context, userID, currency string) (domain.Account, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, a := range m.accounts {
+		if a.UserID == userID && a.IsDefault {
+			return a, nil
+		}
+	}
+	return m.insertAccount(domain.Account{UserID: userID, Name: domain.DefaultAccountName, Currency: currency}), nil
+}
+
+type memSettings struct {
+	*memStore
+}
+
+func (m memSettings) Get(ctx context.Context, userID string) (domain.UserSettings, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.settings[userID]
+	return s, ok, nil
+}
+
+func (m memSettings) Upsert(ctx context.Context, s domain.UserSettings) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.settings[s.UserID] = s
+	return nil
+}
+
+type memRates struct {
+	*memStore
+}
+
+func (m memRates) Rate(ctx context.Context, base, quote string, on time.Time) (*big.Rat, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rateQueries++
+	var best *domain.ExchangeRate
+	for i, r := range m.rates {
+		if r.Base != base || r.Quote != quote || r.Date.After(on) {
+			continue
+		}
+		if best == nil || r.Date.After(best.Date) {
+			best = &m.rates[i]
+		}
+	}
+	if best == nil {
+		return nil, false, nil
+	}
+	return best.Rate, true, nil
+}
+
+func (m memRates) RatesInRange(ctx context.Context, currencies []string, from, to time.Time) ([]domain.ExchangeRate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rateQueries++
+	var out []domain.ExchangeRate
+	for _, r := range m.rates {
+		if slices.Contains(currencies, r.Base) && slices.Contains(currencies, r.Quote) && !r.Date.After(to) {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (m memRates) Upsert(ctx context.Context, rates []domain.ExchangeRate) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rates = append(m.rates, rates...)
+	return nil
+}
+
+type memJournal struct {
+	*memStore
+}
+
+func (m memJournal) Post(ctx context.Context, e domain.JournalEntry) (int, error) {
+	if err := e.Validate(); err != nil {
+		return 0, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e.ID = len(m.journal) + 1
+	e.RecordedAt = time.Now()
+	m.journal = append(m.journal, e)
+	return e.ID, nil
+}
+
+func (m memJournal) TrialBalance(ctx context.Context, userID string, on time.Time) ([]domain.TrialBalanceLine, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	lines := map[[2]string]*domain.TrialBalanceLine{}
+	for _, e := range m.journal {
+		if e.UserID != userID || e.Date.After(on) {
+			continue
+		}
+		for _, p := range e.Postings {
+			key := [2]string{p.Account, p.Currency}
+			l, ok := lines[key]
+			if !ok {
+				l = &domain.TrialBalanceLine{Account: p.Account, Currency: p.Currency}
+				lines[key] = l
+			}
+			if p.Amount > 0 {
+				l.Debit += p.Amount
+			} else {
+				l.Credit -= p.Amount
+			}
+		}
+	}
+	out := make([]domain.TrialBalanceLine, 0, len(lines))
+	for _, l := range lines {
+		out = append(out, *l)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Account != out[j].Account {
+			return out[i].Account < out[j].Account
+		}
+		return out[i].Currency < out[j].Currency
+	})
+	return out, nil
+}
+
+func (m memJournal) Statement(ctx context.Context, userID, account, currency string, from, to time.Time) (domain.Money, []domain.StatementLine, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var opening domain.Money
+	lines := make([]domain.StatementLine, 0)
+	for _, e := range m.journal {
+		if e.UserID != userID || e.Date.After(to) {
+			continue
+		}
+		for _, p := range e.Postings {
+			if p.Account != account || p.Currency != currency {
+				continue
+			}
+			if e.Date.Before(from) {
+				opening += p.Amount
+				continue
+			}
+			lines = append(lines, domain.StatementLine{
+				EntryID: e.ID, TransactionID: e.TransactionID, Date: e.Date,
+				Description: e.Description, Amount: p.Amount, RecordedAt: e.RecordedAt,
+			})
+		}
+	}
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].Date.Before(lines[j].Date) })
+	return opening, lines, nil
+}
+
+type memRecurring struct {
+	*memStore
+}
+
+func (m memRecurring) Insert(ctx context.Context, r domain.Recurring) (domain.Recurring, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r.ID = len(m.recurring) + 1
+	m.recurring = append(m.recurring, r)
+	return r, nil
+}
+
+func (m memRecurring) Get(ctx context.Context, id int) (domain.Recurring, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if id <= 0 || id > len(m.recurring) {
+		return domain.Recurring{}, false, nil
+	}
+	return m.recurring[id-1], true, nil
+}
+
+func (m memRecurring) GetForUpdate(ctx context.Context, userID string, id int) (domain.Recurring, bool, error) {
+	r, ok, err := m.Get(ctx, id)
+	if !ok || r.UserID != userID {
+		return domain.Recurring{}, false, err
+	}
+	return r, true, nil
+}
+
+func (m memRecurring) List(ctx context.Context, userID string) ([]domain.Recurring, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]domain.Recurring, 0)
+	for _, r := range m.recurring {
+		if r.UserID == userID {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (m memRecurring) Update(ctx context.Context, r domain.Recurring) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.recurring[r.ID-1].Paused = r.Paused
+	m.recurring[r.ID-1].NextDate = r.NextDate
+	return nil
+}
+
+// ClaimDue не блокирует шаблон: тесты вызывают MaterializeDue последовательно.
+func (m memRecurring) ClaimDue(ctx context.Context, on time.Time, skip []int) (domain.Recurring, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, r := range m.recurring {
+		if !r.Paused && !r.NextDate.IsZero() && !r.NextDate.After(on) && !slices.Contains(skip, r.ID) {
+			return r, true, nil
+		}
+	}
+	return domain.Recurring{}, false, nil
+}
+
+func occurrenceKey(id int, on time.Time) string {
+	return strconv.Itoa(id) + "|" + on.Format("2006-01-02")
+}
+
+func (m memRecurring) ClaimOccurrence(ctx context.Context, recurringID int, on time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := occurrenceKey(recurringID, on)
+	if _, ok := m.occurrences[key]; ok {
+		return false, nil
+	}
+	m.occurrences[key] = 0
+	return true, nil
+}
+
+func (m memRecurring) SetOccurrenceResult(ctx context.Context, recurringID int, on time.Time, transactionID int, errMsg string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.occurrences[occurrenceKey(recurringID, on)] = transactionID
+	return nil
+}
+
+type memCategories struct {
+	*memStore
+}
+
+func (m memCategories) Insert(ctx context.Context, c domain.Category) (domain.Category, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextCatID++
+	c.ID = m.nextCatID
+	m.categories[c.ID] = c
+	return c, nil
+}
+
+func (m memCategories) Get(ctx context.Context, id int) (domain.Category, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.categories[id]
+	return c, ok, nil
+}
+
+func (m memCategories) List(ctx context.Context, userID string) ([]domain.Category, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]domain.Category, 0)
+	for _, c := range m.categories {
+		if c.UserID == userID {
+			out = append(out, c)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+func (m memCategories) Update(ctx context.Context, c domain.Category) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.categories[c.ID] = c
+	return nil
+}
+
+func (m memCategories) Delete(ctx context.Context, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.categories, id)
+	return nil
+}
+
+func (m memCategories) Repoint(ctx context.Context, userID, from, to string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, t := range m.expenses {
+		if t.UserID == userID && t.Category == from {
+			m.expenses[i].Category = to
+		}
+	}
+	for i, r := range m.recurring {
+		if r.UserID == userID && r.Category == from {
+			m.recurring[i].Category = to
+		}
+	}
+	for id, r := range m.rules {
+		if r.UserID == userID && r.Category == from {
+			r.Category = to
+			m.rules[id] = r
+		}
+	}
+	if b, ok := m.budgets[budgetKey(userID, from)]; ok {
+		delete(m.budgets, budgetKey(userID, from))
+		if _, exists := m.budgets[budgetKey(userID, to)]; !exists {
+			b.Category = to
+			m.budgets[budgetKey(userID, to)] = b
+		}
+	}
+	delete(m.aliases, budgetKey(userID, to))
+	for k, c := range m.aliases {
+		if c == from && strings.HasPrefix(k, userID+"|") {
+			m.aliases[k] = to
+		}
+	}
+	return nil
+}
+
+func (m memCategories) Usage(ctx context.Context, userID string) (map[string]int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := map[string]int{}
+	for _, t := range m.expenses {
+		if t.UserID == userID {
+			out[t.Category]++
+		}
+	}
+	for _, b := range m.budgets {
+		if b.UserID == userID {
+			out[b.Category] += 0
+		}
+	}
+	for _, r := range m.recurring {
+		if r.UserID == userID {
+			out[r.Category] += 0
+		}
+	}
+	for _, c := range m.categories {
+		if c.UserID == userID {
+			out[c.Name] += 0
+		}
+	}
+	for _, r := range m.rules {
+		if r.UserID == userID {
+			out[r.Category] += 0
+		}
+	}
+	return out, nil
+}
+
+func (m memCategories) SetAlias(ctx context.Context, a domain.CategoryAlias) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.aliases[budgetKey(a.UserID, a.Alias)] = a.Category
+	for k, c := range m.aliases {
+		if c == a.Alias && strings.HasPrefix(k, a.UserID+"|") {
+			m.aliases[k] = a.Category
+		}
+	}
+	return nil
+}
+
+func (m memCategories) ResolveAlias(ctx context.Context, userID, alias string) (string, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.aliases[budgetKey(userID, alias)]
+	return c, ok, nil
+}
+
+func (m memCategories) ListAliases(ctx context.Context, userID string) ([]domain.CategoryAlias, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]domain.CategoryAlias, 0)
+	for k, c := range m.aliases {
+		if alias, ok := strings.CutPrefix(k, userID+"|"); ok {
+			out = append(out, domain.CategoryAlias{UserID: userID, Alias: alias, Category: c})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Alias < out[j].Alias })
+	return out, nil
+}
+
+func (m memCategories) DeleteAlias(ctx context.Context, userID, alias string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.aliases[budgetKey(userID, alias)]
+	delete(m.aliases, budgetKey(userID, alias))
+	return ok, nil
+}
+
+type memRules struct {
+	*memStore
+}
+
+func (m memRules) Insert(ctx context.Context, r domain.Rule) (domain.Rule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextRuleID++
+	r.ID = m.nextRuleID
+	m.rules[r.ID] = r
+	return r, nil
+}
+
+func (m memRules) Get(ctx context.Context, id int) (domain.Rule, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.rules[id]
+	return r, ok, nil
+}
+
+func (m memRules) List(ctx context.Context, userID string) ([]domain.Rule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]domain.Rule, 0)
+	for _, r := range m.rules {
+		if r.UserID == userID {
+			out = append(out, r)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func (m memRules) Update(ctx context.Context, r domain.Rule) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rules[r.ID] = r
+	return nil
+}
+
+func (m memRules) Delete(ctx context.Context, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.rules, id)
+	return nil
+}
+
+// newMemApp собирает App так же, как в проде: запись транзакций и счетов
+// идёт через журнал.
+func newMemApp() (*App, *memStore) {
+	s := newMemStore()
+	j := memJournal{s}
+	return New(s, journal.NewExpenseRepo(memExpenses{s}, j, s), journal.NewAccountRepo(memAccounts{s}, j, s), j, memRecurring{s}, journal.NewCategoryRepo(memCategories{s}, j, s), memRules{s}, memSettings{s}, memRates{s}, s), s
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"final/ledger/internal/domain"
+)
+
+// TrialBalance возвращает обороты и сальдо всех счетов главной книги на дату
+// on (нулевая — сегодня). Суммы не пересчитываются: каждая строка в своей валюте.
+func (a *App) TrialBalance(ctx context.Context, on time.Time) (domain.TrialBalance, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.TrialBalance{}, err
+	}
+	if on.IsZero() {
+		on = time.Now().UTC()
+	}
+	lines, err := a.journal.TrialBalance(ctx, uid, on)
+	if err != nil {
+		return domain.TrialBalance{}, err
+	}
+	return domain.TrialBalance{On: on, Lines: lines}, nil
+}
+
+// AccountStatement строит выписку по счёту главной книги за [from, to]:
+// сальдо на начало, проводки с нарастающим сальдо и сальдо на конец.
+// Без валюты выписка строится в валюте счёта пользователя, для остальных
+// счетов — в базовой валюте.
+func (a *App) AccountStatement(ctx context.Context, account, currency string, from, to time.Time) (domain.Statement, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.Statement{}, err
+	}
+	if from.After(to) {
+		return domain.Statement{}, errors.New("from must be <= to")
+	}
+	section, name, err := domain.ParseLedgerAccount(account)
+	if err != nil {
+		return domain.Statement{}, err
+	}
+	if section == domain.LedgerExpenses || section == domain.LedgerIncome {
+		account = section + ":" + domain.NormalizeCategory(name)
+	}
+
+	currency = domain.NormalizeCurrency(currency)
+	if currency != "" && !domain.IsValidCurrency(currency) {
+		return domain.Statement{}, errors.New("invalid currency")
+	}
+	if section == domain.LedgerAssets {
+		id, _ := strconv.Atoi(name)
+		acc, err := a.resolveAccount(ctx, uid, id)
+		if err != nil {
+			return domain.Statement{}, err
+		}
+		if currency == "" {
+			currency = acc.Currency
+		}
+	}
+	if currency == "" {
+		if currency, err = a.baseCurrency(ctx, uid); err != nil {
+			return domain.Statement{}, err
+		}
+	}
+
+	opening, lines, err := a.journal.Statement(ctx, uid, account, currency, from, to)
+	if err != nil {
+		return domain.Statement{}, err
+	}
+	st := domain.Statement{Account: account, Currency: currency, From: from, To: to, Opening: opening, Closing: opening, Lines: lines}
+	for i := range st.Lines {
+		st.Closing += st.Lines[i].Amount
+		st.Lines[i].Balance = st.Closing
+	}
+	return st, nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"final/ledger/internal/domain"
+	"final/ledger/internal/grpcx"
+)
+
+func TestJournal(t *testing.T) {
+	app, _ := newMemApp()
+	ctx := grpcx.WithUserID(context.Background(), "u1")
+	other := grpcx.WithUserID(context.Background(), "u2")
+
+	day := func(d int) time.Time { return time.Date(2025, 12, d, 0, 0, 0, 0, time.UTC) }
+
+	salary, err := app.AddTransaction(ctx, domain.Transaction{Kind: domain.KindIncome, Amount: 100000, Category: "зарплата", Date: day(1)})
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	food, err := app.AddTransaction(ctx, domain.Transaction{Amount: 3000, Category: "еда", Date: day(2)})
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	cash, err := app.CreateAccount(ctx, domain.Account{Name: "наличные", OpeningBalance: 5000})
+	if err != nil {
+		t.Fatalf("create account: %v", err)
+	}
+	if _, _, err := app.Transfer(ctx, domain.Transfer{FromAccountID: salary.AccountID, ToAccountID: cash.ID, Amount: 20000, Date: day(3)}); err != nil {
+		t.Fatalf("transfer: %v", err)
+	}
+
+	// Исправление суммы и удаление сторнируют прежние записи, а не стирают их.
+	amount := domain.Money(4000)
+	if _, err := app.UpdateTransaction(ctx, food.ID, domain.TransactionPatch{Amount: &amount}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	taxi, err := app.AddTransaction(ctx, domain.Transaction{AccountID: cash.ID, Amount: 700, Category: "такси", Date: day(4)})
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err := app.DeleteTransaction(ctx, taxi.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	// Остаток на открытии датирован днём создания счёта, поэтому — на сегодня.
+	tb, err := app.TrialBalance(ctx, time.Time{})
+	if err != nil {
+		t.Fatalf("trial balance: %v", err)
+	}
+	if !tb.Balanced() {
+		t.Fatalf("trial balance must balance: %+v", tb.Lines)
+	}
+	balances := map[string]domain.Money{}
+	for _, l := range tb.Lines {
+		balances[l.Account] = l.Balance()
+	}
+	main := domain.AssetAccount(salary.AccountID)
+	if balances[main] != 100000-4000-20000 || balances[domain.AssetAccount(cash.ID)] != 5000+20000 ||
+		balances["expenses:еда"] != 4000 || balances["expenses:такси"] != 0 ||
+		balances["income:зарплата"] != -100000 || balances[domain.EquityTransfers] != 0 || balances[domain.EquityOpening] != -5000 {
+		t.Fatalf("unexpected balances: %+v", balances)
+	}
+
+	st, err := app.AccountStatement(ctx, main, "", day(2), day(31))
+	if err != nil {
+		t.Fatalf("statement: %v", err)
+	}
+	// Расход, перевод, сторно расхода и исправленный расход.
+	if st.Currency != "RUB" || st.Opening != 100000 || st.Closing != balances[main] || len(st.Lines) != 4 ||
+		st.Lines[0].Balance != 97000 || st.Lines[3].Balance != st.Closing {
+		t.Fatalf("unexpected statement: %+v", st)
+	}
+
+	st, err = app.AccountStatement(ctx, "expenses: Такси ", "", day(1), day(31))
+	if err != nil {
+		t.Fatalf("statement: %v", err)
+	}
+	if st.Account != "expenses:такси" || len(st.Lines) != 2 || st.Closing != 0 {
+		t.Fatalf("deleted transaction must stay in the journal: %+v", st)
+	}
+
+	if _, err := app.AccountStatement(other, main, "", day(1), day(31)); err != ErrAccountNotFound {
+		t.Fatalf("expected ErrAccountNotFound, got %v", err)
+	}
+	if _, err := app.AccountStatement(ctx, "wallet", "", day(1), day(31)); err == nil {
+		t.Fatalf("expected invalid ledger account")
+	}
+	tb, err = app.TrialBalance(other, day(31))
+	if err != nil || len(tb.Lines) != 0 {
+		t.Fatalf("other user must see an empty trial balance, got %+v %v", tb, err)
+	}
+}
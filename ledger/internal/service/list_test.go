@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"final/ledger/internal/domain"
+	"final/ledger/internal/grpcx"
+)
+
+func TestListTransactionsPagination(t *testing.T) {
+	app, _ := newMemApp()
+	ctx := grpcx.WithUserID(context.Background(), "u1")
+	other := grpcx.WithUserID(context.Background(), "u2")
+
+	for day := 1; day <= 5; day++ {
+		date := time.Date(2025, 12, day, 0, 0, 0, 0, time.UTC)
+		if _, err := app.AddTransaction(ctx, domain.Transaction{Amount: domain.Money(day * 10), Category: "Еда", Description: "обед", Date: date}); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+		if _, err := app.AddTransaction(ctx, domain.Transaction{Amount: 1, Category: "такси", Description: "поездка", Date: date}); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+	if _, err := app.AddTransaction(other, domain.Transaction{Amount: 1, Category: "еда", Date: time.Date(2025, 12, 3, 0, 0, 0, 0, time.UTC)}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	t.Run("pages_cover_all", func(t *testing.T) {
+		f := domain.TransactionFilter{Categories: []string{" ЕДА "}, PageSize: 2}
+		var amounts []domain.Money
+		for pages := 0; ; pages++ {
+			if pages > 5 {
+				t.Fatalf("too many pages")
+			}
+			page, err := app.ListTransactions(ctx, f)
+			if err != nil {
+				t.Fatalf("list: %v", err)
+			}
+			for _, tx := range page.Items {
+				amounts = append(amounts, tx.Amount)
+			}
+			if page.NextPageToken == "" {
+				break
+			}
+			f.PageToken = page.NextPageToken
+		}
+		want := []domain.Money{50, 40, 30, 20, 10}
+		if len(amounts) != len(want) {
+			t.Fatalf("expected %v, got %v", want, amounts)
+		}
+		for i := range want {
+			if amounts[i] != want[i] {
+				t.Fatalf("expected %v, got %v", want, amounts)
+			}
+		}
+	})
+
+	t.Run("range_amount_query", func(t *testing.T) {
+		minAmount, maxAmount := domain.Money(15), domain.Money(40)
+		page, err := app.ListTransactions(ctx, domain.TransactionFilter{
+			From:      time.Date(2025, 12, 2, 0, 0, 0, 0, time.UTC),
+			To:        time.Date(2025, 12, 4, 0, 0, 0, 0, time.UTC),
+			MinAmount: &minAmount,
+			MaxAmount: &maxAmount,
+			Query:     "ОБЕД",
+			Sort:      domain.SortAmountAsc,
+		})
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		if len(page.Items) != 3 || page.Items[0].Amount != 20 || page.Items[2].Amount != 40 || page.NextPageToken != "" {
+			t.Fatalf("unexpected page: %+v", page)
+		}
+	})
+
+	t.Run("token_bound_to_sort", func(t *testing.T) {
+		page, err := app.ListTransactions(ctx, domain.TransactionFilter{PageSize: 1})
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		_, err = app.ListTransactions(ctx, domain.TransactionFilter{PageSize: 1, Sort: domain.SortAmountAsc, PageToken: page.NextPageToken})
+		if err == nil || err.Error() != "invalid page token" {
+			t.Fatalf("expected invalid page token, got %v", err)
+		}
+	})
+
+	t.Run("other_user_isolated", func(t *testing.T) {
+		page, err := app.ListTransactions(other, domain.TransactionFilter{})
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		if len(page.Items) != 1 {
+			t.Fatalf("expected 1 transaction, got %d", len(page.Items))
+		}
+	})
+}
+
+func TestStreamTransactionsBatches(t *testing.T) {
+	app, _ := newMemApp()
+	ctx := grpcx.WithUserID(context.Background(), "u1")
+
+	const n = streamBatchSize*2 + 7
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		// По две транзакции на дату, чтобы курсор опирался на id при равных датах.
+		date := start.AddDate(0, 0, i/2)
+		if _, err := app.AddTransaction(ctx, domain.Transaction{Amount: 1, Category: "еда", Date: date}); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+
+	seen := map[int]bool{}
+	var prev domain.Transaction
+	err := app.StreamTransactions(ctx, domain.TransactionFilter{Sort: domain.SortDateAsc, PageSize: 1}, func(tx domain.Transaction) error {
+		if seen[tx.ID] {
+			t.Fatalf("duplicate id %d", tx.ID)
+		}
+		if len(seen) > 0 && !domain.SortLess(domain.SortDateAsc, prev, tx) {
+			t.Fatalf("out of order: %d after %d", tx.ID, prev.ID)
+		}
+		seen[tx.ID] = true
+		prev = tx
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+	if len(seen) != n {
+		t.Fatalf("expected %d transactions, got %d", n, len(seen))
+	}
+
+	// Без page_size и page_token список не режется до DefaultPageSize.
+	page, err := app.ListTransactions(ctx, domain.TransactionFilter{})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(page.Items) != n || page.NextPageToken != "" {
+		t.Fatalf("expected all %d transactions on one page, got %d (token %q)", n, len(page.Items), page.NextPageToken)
+	}
+
+	if err := app.StreamTransactions(context.Background(), domain.TransactionFilter{}, func(domain.Transaction) error { return nil }); err != ErrUnauthenticated {
+		t.Fatalf("expected ErrUnauthenticated, got %v", err)
+	}
+}
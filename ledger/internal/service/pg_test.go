@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"final/ledger/internal/db"
+	"final/ledger/internal/domain"
+	"final/ledger/internal/grpcx"
+	"final/ledger/internal/journal"
+	"final/ledger/internal/repository/pg"
+)
+
+// pgUserID — пользователь тестов на Postgres: user_id в схеме — UUID.
+const pgUserID = "00000000-0000-0000-0000-000000000001"
+
+// newPGApp собирает App на настоящем Postgres из LEDGER_TEST_DATABASE_URL
+// (URL вида postgres://...). Каждый тест получает свою схему с применёнными
+// миграциями; без переменной тест пропускается.
+func newPGApp(tb testing.TB) (*App, *sql.DB) {
+	tb.Helper()
+	dsn := os.Getenv("LEDGER_TEST_DATABASE_URL")
+	if dsn == "" {
+		tb.Skip("LEDGER_TEST_DATABASE_URL is not set")
+	}
+
+	admin, err := db.Open(dsn)
+	if err != nil {
+		tb.Fatalf("open: %v", err)
+	}
+	schema := "ledger_test_" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		tb.Fatalf("create schema: %v", err)
+	}
+	tb.Cleanup(func() {
+		_, _ = admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		_ = admin.Close()
+	})
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		tb.Fatalf("parse dsn: %v", err)
+	}
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+	conn, err := db.Open(u.String())
+	if err != nil {
+		tb.Fatalf("open: %v", err)
+	}
+	tb.Cleanup(func() { _ = conn.Close() })
+	migrateUp(tb, conn)
+
+	txm := pg.NewTxManager(conn)
+	j := pg.NewJournalRepo(conn)
+	app := New(pg.NewBudgetRepo(conn),
+		journal.NewExpenseRepo(pg.NewExpenseRepo(conn), j, txm),
+		journal.NewAccountRepo(pg.NewAccountRepo(conn), j, txm),
+		j, pg.NewRecurringRepo(conn),
+		journal.NewCategoryRepo(pg.NewCategoryRepo(conn), j, txm),
+		pg.NewRuleRepo(conn), pg.NewSettingsRepo(conn), pg.NewRateRepo(conn), txm)
+	return app, conn
+}
+
+// migrateUp применяет секции goose Up всех миграций по порядку.
+func migrateUp(tb testing.TB, conn *sql.DB) {
+	tb.Helper()
+	files, err := filepath.Glob("../../migrations/*.sql")
+	if err != nil || len(files) == 0 {
+		tb.Fatalf("migrations not found: %v", err)
+	}
+	sort.Strings(files)
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			tb.Fatalf("read %s: %v", f, err)
+		}
+		up, _, _ := strings.Cut(string(b), "-- +goose Down")
+		if _, err := conn.Exec(up); err != nil {
+			tb.Fatalf("migrate %s: %v", filepath.Base(f), err)
+		}
+	}
+}
+
+func TestPGDeleteTransfer(t *testing.T) {
+	app, _ := newPGApp(t)
+	ctx := grpcx.WithUserID(context.Background(), pgUserID)
+	day := time.Date(2025, 12, 3, 0, 0, 0, 0, time.UTC)
+
+	from, err := app.CreateAccount(ctx, domain.Account{Name: "карта", OpeningBalance: 100000})
+	if err != nil {
+		t.Fatalf("create account: %v", err)
+	}
+	to, err := app.CreateAccount(ctx, domain.Account{Name: "наличные"})
+	if err != nil {
+		t.Fatalf("create account: %v", err)
+	}
+	out, _, err := app.Transfer(ctx, domain.Transfer{FromAccountID: from.ID, ToAccountID: to.ID, Amount: 50000, Date: day})
+	if err != nil {
+		t.Fatalf("transfer: %v", err)
+	}
+
+	if err := app.DeleteTransaction(ctx, out.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	page, err := app.ListTransactions(ctx, domain.TransactionFilter{Kinds: []string{domain.KindTransfer}})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(page.Items) != 0 {
+		t.Fatalf("expected no transfer legs, got %+v", page.Items)
+	}
+}
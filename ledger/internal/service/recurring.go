@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"final/ledger/internal/domain"
+	"final/ledger/internal/grpcx"
+)
+
+// MaxPreviewCount — сколько повторений можно запросить в предпросмотре.
+const MaxPreviewCount = 100
+
+// CreateRecurring сохраняет шаблон. Счёт и валюта фиксируются при создании:
+// без счёта — счёт по умолчанию, без валюты — валюта счёта.
+func (a *App) CreateRecurring(ctx context.Context, r domain.Recurring) (domain.Recurring, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.Recurring{}, err
+	}
+	if r.Kind == "" {
+		r.Kind = domain.KindExpense
+	}
+	if r.Kind == domain.KindTransfer {
+		return domain.Recurring{}, errors.New("invalid transaction kind")
+	}
+	if r.Interval == 0 {
+		r.Interval = 1
+	}
+	if err := r.Validate(); err != nil {
+		return domain.Recurring{}, err
+	}
+
+	r.UserID = uid
+	if r.Category, err = a.resolveCategory(ctx, uid, r.Category); err != nil {
+		return domain.Recurring{}, err
+	}
+	tree, err := a.categoryTree(ctx, uid)
+	if err != nil {
+		return domain.Recurring{}, err
+	}
+	if err := checkCategoryActive(tree, r.Category); err != nil {
+		return domain.Recurring{}, err
+	}
+	r.Description = strings.TrimSpace(r.Description)
+	acc, err := a.resolveAccount(ctx, uid, r.AccountID)
+	if err != nil {
+		return domain.Recurring{}, err
+	}
+	r.AccountID = acc.ID
+	r.Currency = domain.NormalizeCurrency(r.Currency)
+	if r.Currency == "" {
+		r.Currency = acc.Currency
+	}
+	r.NextDate, _ = r.NextOn(r.StartDate)
+	return a.recurring.Insert(ctx, r)
+}
+
+func (a *App) ListRecurring(ctx context.Context) ([]domain.Recurring, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return a.recurring.List(ctx, uid)
+}
+
+// SetRecurringPaused ставит шаблон на паузу или снимает с неё. Повторения,
+// пропущенные за время паузы, не создаются: после снятия с паузы шаблон
+// продолжает с ближайшей даты начиная с сегодня.
+func (a *App) SetRecurringPaused(ctx context.Context, id int, paused bool) (domain.Recurring, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.Recurring{}, err
+	}
+	var r domain.Recurring
+	err = a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Шаблон блокируется на время правки, как в ClaimDue.
+		var ok bool
+		if r, ok, err = a.recurring.GetForUpdate(ctx, uid, id); err != nil {
+			return err
+		}
+		if !ok {
+			return ErrNotFound
+		}
+		if r.Paused == paused {
+			return nil
+		}
+		r.Paused = paused
+		if !paused && !r.NextDate.IsZero() {
+			r.NextDate, _ = r.NextOn(maxDate(r.NextDate, today()))
+		}
+		return a.recurring.Update(ctx, r)
+	})
+	if err != nil {
+		return domain.Recurring{}, err
+	}
+	return r, nil
+}
+
+// PreviewRecurring возвращает до count ближайших дат, на которые шаблон
+// создаст транзакции; для шаблона на паузе — какими они будут после снятия с паузы.
+func (a *App) PreviewRecurring(ctx context.Context, id, count int) ([]time.Time, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		count = 5
+	}
+	if count < 0 || count > MaxPreviewCount {
+		return nil, errors.New("invalid count")
+	}
+	r, err := a.ownedRecurring(ctx, uid, id)
+	if err != nil {
+		return nil, err
+	}
+	if r.NextDate.IsZero() {
+		return []time.Time{}, nil
+	}
+	from := r.NextDate
+	if r.Paused {
+		from = maxDate(from, today())
+	}
+	return r.Upcoming(from, count), nil
+}
+
+func (a *App) ownedRecurring(ctx context.Context, uid string, id int) (domain.Recurring, error) {
+	r, ok, err := a.recurring.Get(ctx, id)
+	if err != nil {
+		return domain.Recurring{}, err
+	}
+	// Как и со счетами, чужой шаблон не выдаёт себя кодом ошибки.
+	if !ok || r.UserID != uid {
+		return domain.Recurring{}, ErrNotFound
+	}
+	return r, nil
+}
+
+// MaterializeDue создаёт транзакции всех повторений с датой не позже on и
+// возвращает их число. Каждый шаблон обрабатывается в своей транзакции БД:
+// шаблон блокируется, транзакции создаются через AddTransaction, повторения
+// отмечаются, NextDate сдвигается — поэтому повторный или параллельный запуск
+// не создаёт дублей. Повторение, отклонённое жёстким бюджетом, из-за
+// отсутствия курса или архивной категории, отмечается с ошибкой и пропускается.
+// Шаблон с любой другой ошибкой откатывается и до следующего запуска не
+// берётся, чтобы не останавливать остальные; его ошибки возвращаются вместе.
+func (a *App) MaterializeDue(ctx context.Context, on time.Time) (int, error) {
+	var (
+		created int
+		skip    []int
+		errs    []error
+	)
+	for {
+		var claimed, n int
+		err := a.tx.WithinTx(ctx, func(ctx context.Context) error {
+			r, ok, err := a.recurring.ClaimDue(ctx, on, skip)
+			if err != nil || !ok {
+				return err
+			}
+			claimed = r.ID
+			// Транзакции создаются от имени владельца шаблона.
+			ctx = grpcx.WithUserID(ctx, r.UserID)
+			for !r.NextDate.IsZero() && !r.NextDate.After(on) {
+				ok, err := a.materialize(ctx, r)
+				if err != nil {
+					return err
+				}
+				if ok {
+					n++
+				}
+				r.NextDate, _ = r.NextOn(r.NextDate.AddDate(0, 0, 1))
+			}
+			return a.recurring.Update(ctx, r)
+		})
+		switch {
+		case claimed == 0 || ctx.Err() != nil:
+			return created, errors.Join(append(errs, err)...)
+		case err != nil:
+			skip = append(skip, claimed)
+			errs = append(errs, fmt.Errorf("recurring %d: %w", claimed, err))
+		default:
+			created += n
+		}
+	}
+}
+
+// materialize создаёт транзакцию повторения на r.NextDate, если оно ещё не
+// занято; true — транзакция создана.
+func (a *App) materialize(ctx context.Context, r domain.Recurring) (bool, error) {
+	ok, err := a.recurring.ClaimOccurrence(ctx, r.ID, r.NextDate)
+	if err != nil || !ok {
+		return false, err
+	}
+	var (
+		txID   int
+		errMsg string
+	)
+	t, err := a.AddTransaction(ctx, r.Transaction(r.NextDate))
+	switch {
+	case err == nil:
+		txID = t.ID
+	case errors.Is(err, ErrBudgetExceeded), errors.Is(err, ErrNoRate), errors.Is(err, ErrCategoryArchived):
+		errMsg = err.Error()
+	default:
+		return false, err
+	}
+	return txID != 0, a.recurring.SetOccurrenceResult(ctx, r.ID, r.NextDate, txID, errMsg)
+}
+
+// RunScheduler раз в every создаёт наступившие повторения, пока не отменён ctx.
+func (a *App) RunScheduler(ctx context.Context, every time.Duration) {
+	t := time.NewTicker(every)
+	defer t.Stop()
+	for {
+		n, err := a.MaterializeDue(ctx, today())
+		if err != nil && ctx.Err() == nil {
+			log.Printf("[ledger] recurring: %v", err)
+		}
+		if n > 0 {
+			log.Printf("[ledger] recurring: created %d transactions", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+func today() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func maxDate(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
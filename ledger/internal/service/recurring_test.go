@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"final/ledger/internal/domain"
+	"final/ledger/internal/grpcx"
+)
+
+func TestRecurring(t *testing.T) {
+	app, store := newMemApp()
+	ctx := grpcx.WithUserID(context.Background(), "u1")
+	other := grpcx.WithUserID(context.Background(), "u2")
+
+	day := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
+
+	rent, err := app.CreateRecurring(ctx, domain.Recurring{
+		Amount: 3000000, Category: " Аренда ", Frequency: domain.FreqMonthly, StartDate: day(1, 31),
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if rent.Kind != domain.KindExpense || rent.Interval != 1 || rent.Category != "аренда" || rent.Currency != "RUB" ||
+		rent.AccountID == 0 || !rent.NextDate.Equal(day(1, 31)) {
+		t.Fatalf("unexpected template: %+v", rent)
+	}
+	if _, err := app.CreateRecurring(ctx, domain.Recurring{Amount: 1, Category: "x", Frequency: "hourly", StartDate: day(1, 1)}); err == nil {
+		t.Fatalf("expected invalid frequency")
+	}
+
+	dates, err := app.PreviewRecurring(ctx, rent.ID, 3)
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	if len(dates) != 3 || !dates[1].Equal(day(2, 28)) || !dates[2].Equal(day(3, 31)) {
+		t.Fatalf("unexpected preview: %v", dates)
+	}
+	if _, err := app.PreviewRecurring(other, rent.ID, 3); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := app.SetRecurringPaused(other, rent.ID, true); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	// Пропущенные повторения догоняются, повторный запуск дублей не создаёт.
+	n, err := app.MaterializeDue(context.Background(), day(3, 31))
+	if err != nil || n != 3 {
+		t.Fatalf("expected 3 transactions, got %d (%v)", n, err)
+	}
+	if n, err := app.MaterializeDue(context.Background(), day(4, 29)); err != nil || n != 0 {
+		t.Fatalf("expected no transactions, got %d (%v)", n, err)
+	}
+	page, err := app.ListTransactions(ctx, domain.TransactionFilter{})
+	if err != nil || len(page.Items) != 3 || !page.Items[1].Date.Equal(day(2, 28)) || page.Items[0].Amount != 3000000 {
+		t.Fatalf("unexpected transactions: %+v (%v)", page.Items, err)
+	}
+
+	// Повторение сверх жёсткого бюджета пропускается, остальные создаются.
+	if _, err := app.SetBudget(ctx, domain.Budget{Category: "аренда", Limit: 3000000, Period: domain.PeriodMonthly}); err != nil {
+		t.Fatalf("set budget: %v", err)
+	}
+	if _, err := app.AddTransaction(ctx, domain.Transaction{Amount: 1, Category: "аренда", Date: day(4, 1)}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if n, err := app.MaterializeDue(context.Background(), day(5, 31)); err != nil || n != 1 {
+		t.Fatalf("expected 1 transaction, got %d (%v)", n, err)
+	}
+	if store.occurrences[occurrenceKey(rent.ID, day(4, 30))] != 0 || store.occurrences[occurrenceKey(rent.ID, day(5, 31))] == 0 {
+		t.Fatalf("unexpected occurrences: %v", store.occurrences)
+	}
+
+	// На паузе повторения не создаются и после снятия с паузы не догоняются.
+	paused, err := app.SetRecurringPaused(ctx, rent.ID, true)
+	if err != nil || !paused.Paused {
+		t.Fatalf("pause: %+v (%v)", paused, err)
+	}
+	if n, err := app.MaterializeDue(context.Background(), day(7, 31)); err != nil || n != 0 {
+		t.Fatalf("paused template must be skipped, got %d (%v)", n, err)
+	}
+	resumed, err := app.SetRecurringPaused(ctx, rent.ID, false)
+	if err != nil || resumed.Paused || resumed.NextDate.Before(today()) {
+		t.Fatalf("resume: %+v (%v)", resumed, err)
+	}
+
+	items, err := app.ListRecurring(other)
+	if err != nil || len(items) != 0 {
+		t.Fatalf("other user must see no templates, got %v (%v)", items, err)
+	}
+}
+
+func TestRecurringBrokenTemplate(t *testing.T) {
+	app, store := newMemApp()
+	ctx := grpcx.WithUserID(context.Background(), "u1")
+	day := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	broken, err := app.CreateRecurring(ctx, domain.Recurring{Amount: 100, Category: "связь", Frequency: domain.FreqMonthly, StartDate: day})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := app.CreateRecurring(ctx, domain.Recurring{Amount: 200, Category: "аренда", Frequency: domain.FreqMonthly, StartDate: day}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	// Шаблон, который AddTransaction отклонит не из-за бюджета, курса или категории.
+	store.recurring[broken.ID-1].Kind = "gift"
+
+	n, err := app.MaterializeDue(context.Background(), day)
+	if n != 1 || err == nil {
+		t.Fatalf("expected 1 transaction and an error, got %d (%v)", n, err)
+	}
+	page, err := app.ListTransactions(ctx, domain.TransactionFilter{})
+	if err != nil || len(page.Items) != 1 || page.Items[0].Category != "аренда" {
+		t.Fatalf("unexpected transactions: %+v (%v)", page.Items, err)
+	}
+}
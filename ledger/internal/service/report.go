@@ -3,82 +3,370 @@ package service
 import (
 	"context"
 	"errors"
-	"log"
-	"sync"
 	"time"
+
+	"final/ledger/internal/domain"
 )
 
-func (a *App) ReportSummary(ctx context.Context, from, to time.Time) (map[string]float64, error) {
+func (a *App) ReportSummary(ctx context.Context, from, to time.Time) (map[string]domain.Money, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out, err := a.spendingByCategory(ctx, uid, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Сумма родительской категории включает суммы подкатегорий.
+	tree, err := a.categoryTree(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return tree.RollUp(out), nil
+}
+
+// spendingByCategory возвращает траты каждой категории без подкатегорий в
+// базовой валюте.
+func (a *App) spendingByCategory(ctx context.Context, uid string, from, to time.Time) (map[string]domain.Money, error) {
 	if from.After(to) {
 		return nil, errors.New("from must be <= to")
 	}
+	base, err := a.baseCurrency(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	byCat, err := a.expenses.SpendingByCategoryInRange(ctx, uid, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return a.convertGroups(ctx, byCat, base)
+}
 
-	cats, err := a.expenses.ListCategoriesInRange(ctx, from, to)
+// convertGroups пересчитывает траты каждой группы в валюту base; курсы для
+// всех групп читаются одним запросом.
+func (a *App) convertGroups(ctx context.Context, groups map[string][]domain.DatedAmount, base string) (map[string]domain.Money, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var all []domain.DatedAmount
+	for _, amounts := range groups {
+		all = append(all, amounts...)
+	}
+	rates, err := a.loadRates(ctx, all, base)
 	if err != nil {
 		return nil, err
 	}
+	out := make(map[string]domain.Money, len(groups))
+	for name, amounts := range groups {
+		if out[name], err = sumWith(rates, amounts, base); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// CashFlow считает доходы, расходы и их разницу по периодам в базовой
+// валюте пользователя, пересчитывая каждую сумму по курсу на её дату.
+func (a *App) CashFlow(ctx context.Context, from, to time.Time, granularity string) (domain.CashFlow, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.CashFlow{}, err
+	}
+	cf, err := domain.NewCashFlow(from, to, granularity)
+	if err != nil {
+		return domain.CashFlow{}, err
+	}
 
-	out := make(map[string]float64, len(cats))
-	if len(cats) == 0 {
-		return out, nil
+	base, err := a.baseCurrency(ctx, uid)
+	if err != nil {
+		return domain.CashFlow{}, err
 	}
+	cf.Currency = base
 
-	done := make(chan struct{})
-	ticker := time.NewTicker(400 * time.Millisecond)
-	go func() {
-		defer ticker.Stop()
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-done:
-				return
-			case <-ticker.C:
-				log.Printf("report summary calculating")
-			}
+	amounts, err := a.expenses.AmountsByKindInRange(ctx, uid, cf.Total.From, cf.Total.To)
+	if err != nil {
+		return domain.CashFlow{}, err
+	}
+	rates, err := a.loadRates(ctx, amounts, base)
+	if err != nil {
+		return domain.CashFlow{}, err
+	}
+	for _, am := range amounts {
+		v, err := convertWith(rates, am.Amount, am.Currency, base, am.Date)
+		if err != nil {
+			return domain.CashFlow{}, err
 		}
-	}()
+		cf.Add(am, v)
+	}
+	return cf, nil
+}
 
-	type res struct {
-		cat string
-		sum float64
-		err error
+// ReportByTag суммирует траты по меткам в базовой валюте пользователя.
+// Транзакция с несколькими метками учитывается в каждой из них.
+func (a *App) ReportByTag(ctx context.Context, from, to time.Time) (map[string]domain.Money, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if from.After(to) {
+		return nil, errors.New("from must be <= to")
 	}
 
-	ch := make(chan res, len(cats))
-	var wg sync.WaitGroup
-	wg.Add(len(cats))
+	base, err := a.baseCurrency(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	byTag, err := a.expenses.AmountsByTagInRange(ctx, uid, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return a.convertGroups(ctx, byTag, base)
+}
 
-	for _, c := range cats {
-		c := c
-		go func() {
-			defer wg.Done()
-			if ctx.Err() != nil {
-				ch <- res{cat: c, err: ctx.Err()}
-				return
-			}
-			s, err := a.expenses.SumByCategoryInRange(ctx, c, from, to)
-			ch <- res{cat: c, sum: s, err: err}
-		}()
+// BudgetProgress считает исполнение каждого бюджета за даты from..to:
+// траты категории вместе с подкатегориями в валюте бюджета против части
+// лимита, приходящейся на диапазон.
+func (a *App) BudgetProgress(ctx context.Context, from, to time.Time) (domain.ReportWithBudgetProgress, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.ReportWithBudgetProgress{}, err
+	}
+	if from.After(to) {
+		return domain.ReportWithBudgetProgress{}, errors.New("from must be <= to")
+	}
+
+	budgets, err := a.budgets.List(ctx, uid)
+	if err != nil {
+		return domain.ReportWithBudgetProgress{}, err
+	}
+	tree, err := a.categoryTree(ctx, uid)
+	if err != nil {
+		return domain.ReportWithBudgetProgress{}, err
+	}
+
+	byCat, err := a.expenses.SpendingByCategoryInRange(ctx, uid, from, to)
+	if err != nil {
+		return domain.ReportWithBudgetProgress{}, err
+	}
+
+	now := time.Now()
+	var (
+		all        []domain.DatedAmount
+		currencies []string
+	)
+	for _, amounts := range byCat {
+		all = append(all, amounts...)
+	}
+	for _, b := range budgets {
+		currencies = append(currencies, b.Currency)
+	}
+	rates, err := a.loadRates(ctx, all, currencies...)
+	if err != nil {
+		return domain.ReportWithBudgetProgress{}, err
+	}
+
+	out := domain.ReportWithBudgetProgress{From: from, To: to, Progress: make([]domain.BudgetProgressItem, 0, len(budgets))}
+	for _, b := range budgets {
+		var amounts []domain.DatedAmount
+		for _, c := range tree.Subtree(b.Category) {
+			amounts = append(amounts, byCat[c]...)
+		}
+		spent, err := sumWith(rates, amounts, b.Currency)
+		if err != nil {
+			return domain.ReportWithBudgetProgress{}, err
+		}
+		out.Progress = append(out.Progress, domain.NewBudgetProgress(b, spent, from, to, now))
+	}
+	return out, nil
+}
+
+// SpendingTimeSeries раскладывает траты по корзинам дат в базовой валюте
+// пользователя, по желанию с разбивкой по категориям.
+func (a *App) SpendingTimeSeries(ctx context.Context, q domain.SeriesQuery) (domain.TimeSeries, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.TimeSeries{}, err
+	}
+	loc, err := q.Location()
+	if err != nil {
+		return domain.TimeSeries{}, err
+	}
+	if q.To.IsZero() {
+		now := time.Now().In(loc)
+		q.To = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 	}
+	s, err := domain.NewTimeSeries(q.From, q.To, q.Bucket)
+	if err != nil {
+		return domain.TimeSeries{}, err
+	}
+	from, to := s.Points[0].From, s.Points[len(s.Points)-1].To
 
-	wg.Wait()
-	close(done)
-	close(ch)
+	base, err := a.baseCurrency(ctx, uid)
+	if err != nil {
+		return domain.TimeSeries{}, err
+	}
+	s.Currency = base
 
-	for r := range ch {
-		if r.err != nil {
-			if ctx.Err() != nil {
-				return nil, ctx.Err()
+	byCat, err := a.expenses.SpendingByCategoryInRange(ctx, uid, from, to)
+	if err != nil {
+		return domain.TimeSeries{}, err
+	}
+	if q.ByCategory {
+		cats := make([]string, 0, len(byCat))
+		for c := range byCat {
+			cats = append(cats, c)
+		}
+		s.SplitBy(cats)
+	}
+	var all []domain.DatedAmount
+	for _, amounts := range byCat {
+		all = append(all, amounts...)
+	}
+	rates, err := a.loadRates(ctx, all, base)
+	if err != nil {
+		return domain.TimeSeries{}, err
+	}
+	for cat, amounts := range byCat {
+		for _, am := range amounts {
+			v, err := convertWith(rates, am.Amount, am.Currency, base, am.Date)
+			if err != nil {
+				return domain.TimeSeries{}, err
 			}
-			return nil, r.err
+			s.Add(cat, am.Date, v)
+		}
+	}
+	return s, nil
+}
+
+// ComparePeriods сравнивает траты по категориям за from..to с тратами за
+// prevFrom..prevTo; без второго диапазона — с предыдущим периодом той же
+// длины. Суммы считаются так же, как в ReportSummary.
+func (a *App) ComparePeriods(ctx context.Context, from, to, prevFrom, prevTo time.Time) (domain.Comparison, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.Comparison{}, err
+	}
+	if prevFrom.IsZero() != prevTo.IsZero() {
+		return domain.Comparison{}, errors.New("compare range is incomplete")
+	}
+	if prevFrom.IsZero() {
+		if prevFrom, prevTo, err = domain.PreviousRange(from, to); err != nil {
+			return domain.Comparison{}, err
+		}
+	}
+
+	current, err := a.spendingByCategory(ctx, uid, from, to)
+	if err != nil {
+		return domain.Comparison{}, err
+	}
+	previous, err := a.spendingByCategory(ctx, uid, prevFrom, prevTo)
+	if err != nil {
+		return domain.Comparison{}, err
+	}
+	tree, err := a.categoryTree(ctx, uid)
+	if err != nil {
+		return domain.Comparison{}, err
+	}
+	base, err := a.baseCurrency(ctx, uid)
+	if err != nil {
+		return domain.Comparison{}, err
+	}
+
+	c := domain.Compare(tree, current, previous)
+	c.From, c.To, c.PreviousFrom, c.PreviousTo = from, to, prevFrom, prevTo
+	c.Currency = base
+	return c, nil
+}
+
+// ListAnomalies ищет необычные траты за [from, to] по истории категорий за
+// domain.AnomalyHistoryWeeks недель; суммы — в базовой валюте.
+func (a *App) ListAnomalies(ctx context.Context, from, to time.Time) ([]domain.Anomaly, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if from.After(to) {
+		return nil, errors.New("from must be <= to")
+	}
+	base, err := a.baseCurrency(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	txs, err := a.spendingHistory(ctx, uid, base, nil, domain.AnomalyHistoryFrom(from), to)
+	if err != nil {
+		return nil, err
+	}
+	return domain.FindAnomalies(txs, from, to), nil
+}
+
+// transactionAnomaly сравнивает ещё не сохранённый расход с расходами его
+// категории за domain.AnomalyHistoryWeeks недель до его даты. Проверка —
+// подсказка, а не условие вставки: если суммы не пересчитать в базовую
+// валюту, аномалии нет.
+func (a *App) transactionAnomaly(ctx context.Context, uid string, t domain.Transaction) (*domain.Anomaly, error) {
+	if t.Kind != domain.KindExpense {
+		return nil, nil
+	}
+	base, err := a.baseCurrency(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	day := time.Date(t.Date.Year(), t.Date.Month(), t.Date.Day(), 0, 0, 0, 0, time.UTC)
+	txs, err := a.spendingHistory(ctx, uid, base, []string{t.Category}, day.AddDate(0, 0, -7*domain.AnomalyHistoryWeeks), day.AddDate(0, 0, -1))
+	if errors.Is(err, ErrNoRate) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var history []domain.Money
+	for _, h := range txs {
+		if h.Kind == domain.KindExpense {
+			history = append(history, h.Amount)
 		}
-		out[r.cat] = r.sum
 	}
+	if t.Amount, err = a.convert(ctx, t.Amount, t.Currency, base, t.Date); errors.Is(err, ErrNoRate) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	t.Currency = base
+	an, ok := domain.CheckTransaction(t, history)
+	if !ok {
+		return nil, nil
+	}
+	return &an, nil
+}
 
-	if ctx.Err() != nil {
-		return nil, ctx.Err()
+// spendingHistory возвращает расходы и возвраты за [from, to] с суммами,
+// пересчитанными в base; пустой categories — все категории.
+func (a *App) spendingHistory(ctx context.Context, uid, base string, categories []string, from, to time.Time) ([]domain.Transaction, error) {
+	f := domain.TransactionFilter{
+		From:       from,
+		To:         to,
+		Kinds:      []string{domain.KindExpense, domain.KindRefund},
+		Categories: categories,
+	}.Normalize()
+
+	out, err := a.listAll(ctx, uid, f)
+	if err != nil {
+		return nil, err
 	}
 
+	amounts := make([]domain.DatedAmount, len(out))
+	for i, t := range out {
+		amounts[i] = domain.DatedAmount{Currency: t.Currency, Date: t.Date, Amount: t.Amount}
+	}
+	rates, err := a.loadRates(ctx, amounts, base)
+	if err != nil {
+		return nil, err
+	}
+	for i := range out {
+		if out[i].Amount, err = convertWith(rates, out[i].Amount, out[i].Currency, base, out[i].Date); err != nil {
+			return nil, err
+		}
+		out[i].Currency = base
+	}
 	return out, nil
 }
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	"final/ledger/internal/domain"
+	"final/ledger/internal/grpcx"
+	"final/ledger/internal/repository/pg"
+)
+
+// seedReport заводит на fake-хранилище n категорий через fillReport.
+func seedReport(tb testing.TB, n int) (*App, context.Context, *memStore) {
+	tb.Helper()
+	app, store := newMemApp()
+	ctx := grpcx.WithUserID(context.Background(), "u1")
+	fillReport(tb, app, ctx, memRates{store}, n)
+	return app, ctx, store
+}
+
+// fillReport заводит n категорий с тратой в рублях; в чётных категориях
+// есть ещё трата в евро по курсу 100.
+func fillReport(tb testing.TB, app *App, ctx context.Context, rates domain.RateRepo, n int) {
+	tb.Helper()
+	day := func(d int) time.Time { return time.Date(2025, 12, d, 0, 0, 0, 0, time.UTC) }
+	if err := rates.Upsert(ctx, []domain.ExchangeRate{
+		{Base: "EUR", Quote: "RUB", Date: day(1), Rate: big.NewRat(100, 1)},
+	}); err != nil {
+		tb.Fatalf("rates: %v", err)
+	}
+	for i := 0; i < n; i++ {
+		cat := "c" + strconv.Itoa(i)
+		if _, err := app.AddTransaction(ctx, domain.Transaction{Amount: 1000, Category: cat, Date: day(1 + i%28)}); err != nil {
+			tb.Fatalf("add: %v", err)
+		}
+		if i%2 == 1 {
+			continue
+		}
+		if _, err := app.AddTransaction(ctx, domain.Transaction{Amount: 100, Currency: "EUR", Category: cat, Date: day(1 + i%28)}); err != nil {
+			tb.Fatalf("add: %v", err)
+		}
+	}
+}
+
+func TestReportSummaryManyCategories(t *testing.T) {
+	app, ctx, store := seedReport(t, 50)
+	store.spendingQueries, store.rateQueries = 0, 0
+	totals, err := app.ReportSummary(ctx, time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if len(totals) != 50 {
+		t.Fatalf("expected 50 categories, got %d", len(totals))
+	}
+	// 10 руб. и в чётных категориях ещё 1 EUR по курсу 100.
+	for i := 0; i < 50; i++ {
+		cat, want := "c"+strconv.Itoa(i), domain.Money(11000)
+		if i%2 == 1 {
+			want = 1000
+		}
+		if totals[cat] != want {
+			t.Fatalf("%s: expected %s, got %s", cat, want, totals[cat])
+		}
+	}
+	// Траты читаются одним сгруппированным запросом, курсы — одним запросом.
+	if store.spendingQueries != 1 || store.rateQueries != 1 {
+		t.Fatalf("expected 1 spending and 1 rate query, got %d and %d", store.spendingQueries, store.rateQueries)
+	}
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		if _, err := app.ReportSummary(ctx, time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)); err == nil {
+			t.Fatal("expected error on canceled context")
+		}
+	})
+}
+
+func BenchmarkReportSummary(b *testing.B) {
+	app, ctx, _ := seedReport(b, 200)
+	benchReportSummary(b, app, ctx)
+}
+
+// BenchmarkPGReportSummary — тот же отчёт на Postgres из
+// LEDGER_TEST_DATABASE_URL.
+func BenchmarkPGReportSummary(b *testing.B) {
+	app, conn := newPGApp(b)
+	ctx := grpcx.WithUserID(context.Background(), pgUserID)
+	fillReport(b, app, ctx, pg.NewRateRepo(conn), 200)
+	benchReportSummary(b, app, ctx)
+}
+
+func benchReportSummary(b *testing.B, app *App, ctx context.Context) {
+	from, to := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := app.ReportSummary(ctx, from, to); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestAnomalies(t *testing.T) {
+	app, _ := newMemApp()
+	ctx := grpcx.WithUserID(context.Background(), "u1")
+
+	// Шесть недель истории с понедельника 6 октября.
+	week := func(i int) time.Time { return time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 7*i) }
+	for i, amount := range []domain.Money{1000, 1100, 900, 1000, 1050, 950} {
+		if _, err := app.AddTransaction(ctx, domain.Transaction{Amount: amount, Category: "еда", Date: week(i)}); err != nil {
+			t.Fatalf("add: %v", err)
+		}
+	}
+
+	usual, err := app.AddTransaction(ctx, domain.Transaction{Amount: 1000, Category: "еда", Date: week(6), CheckAnomaly: true})
+	if err != nil || usual.Anomaly != nil {
+		t.Fatalf("usual expense must not be flagged: %+v (%v)", usual.Anomaly, err)
+	}
+	large, err := app.AddTransaction(ctx, domain.Transaction{Amount: 5000, Category: "еда", Date: week(6).AddDate(0, 0, 1), CheckAnomaly: true})
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if a := large.Anomaly; a == nil || a.TransactionID != large.ID || a.Typical != 1000 || a.Currency != "RUB" {
+		t.Fatalf("unexpected anomaly: %+v", a)
+	}
+
+	got, err := app.ListAnomalies(ctx, week(6), week(6).AddDate(0, 0, 6))
+	if err != nil {
+		t.Fatalf("list anomalies: %v", err)
+	}
+	if len(got) == 0 || got[0].Kind != domain.AnomalyTransaction || got[0].TransactionID != large.ID {
+		t.Fatalf("unexpected anomalies: %+v", got)
+	}
+	if _, err := app.ListAnomalies(ctx, week(6), week(5)); err == nil {
+		t.Fatal("expected range error")
+	}
+
+	// Без курса проверка пропускается, а расход сохраняется.
+	noRate, err := app.AddTransaction(ctx, domain.Transaction{Amount: 5000, Currency: "USD", Category: "еда", Date: week(6).AddDate(0, 0, 2), CheckAnomaly: true})
+	if err != nil || noRate.Anomaly != nil {
+		t.Fatalf("expected insert without anomaly, got %+v (%v)", noRate.Anomaly, err)
+	}
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"final/ledger/internal/domain"
+)
+
+func (a *App) CreateRule(ctx context.Context, r domain.Rule) (domain.Rule, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.Rule{}, err
+	}
+	if err := a.prepareRule(ctx, uid, &r); err != nil {
+		return domain.Rule{}, err
+	}
+	return a.rules.Insert(ctx, r)
+}
+
+func (a *App) ListRules(ctx context.Context) ([]domain.Rule, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return a.rules.List(ctx, uid)
+}
+
+// UpdateRule заменяет правило целиком.
+func (a *App) UpdateRule(ctx context.Context, r domain.Rule) (domain.Rule, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.Rule{}, err
+	}
+	if err := a.prepareRule(ctx, uid, &r); err != nil {
+		return domain.Rule{}, err
+	}
+	err = a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := a.ownedRule(ctx, uid, r.ID); err != nil {
+			return err
+		}
+		return a.rules.Update(ctx, r)
+	})
+	if err != nil {
+		return domain.Rule{}, err
+	}
+	return r, nil
+}
+
+func (a *App) DeleteRule(ctx context.Context, id int) error {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return err
+	}
+	return a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := a.ownedRule(ctx, uid, id); err != nil {
+			return err
+		}
+		return a.rules.Delete(ctx, id)
+	})
+}
+
+// TestRules показывает, какие правила подошли бы к транзакции, ничего не
+// сохраняя; срабатывает первое из них.
+func (a *App) TestRules(ctx context.Context, t domain.Transaction) ([]domain.Rule, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := a.ruleSet(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return rules.Matching(t), nil
+}
+
+func (a *App) prepareRule(ctx context.Context, uid string, r *domain.Rule) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	r.UserID = uid
+	r.Name = strings.TrimSpace(r.Name)
+	r.Tags = domain.NormalizeTags(r.Tags)
+	var err error
+	r.Category, err = a.resolveCategory(ctx, uid, r.Category)
+	return err
+}
+
+func (a *App) ownedRule(ctx context.Context, uid string, id int) (domain.Rule, error) {
+	r, ok, err := a.rules.Get(ctx, id)
+	if err != nil {
+		return domain.Rule{}, err
+	}
+	// Чужое правило — тот же ErrNotFound.
+	if !ok || r.UserID != uid {
+		return domain.Rule{}, ErrNotFound
+	}
+	return r, nil
+}
+
+func (a *App) ruleSet(ctx context.Context, uid string) (domain.RuleSet, error) {
+	rules, err := a.rules.List(ctx, uid)
+	if err != nil {
+		return domain.RuleSet{}, err
+	}
+	return domain.NewRuleSet(rules), nil
+}
+
+// needsRules сообщает, что категорию транзакции назначают правила.
+func needsRules(t domain.Transaction) bool {
+	return t.AutoCategory || strings.TrimSpace(t.Category) == ""
+}
+
+// autoCategorize назначает категорию первого подходящего правила и
+// добавляет его метки. Если ни одно не подошло, транзакция остаётся как есть.
+func autoCategorize(rules domain.RuleSet, t *domain.Transaction) {
+	if !needsRules(*t) {
+		return
+	}
+	if r, ok := rules.Match(*t); ok {
+		t.Category = r.Category
+		t.Tags = domain.NormalizeTags(slices.Concat(t.Tags, r.Tags))
+	}
+	t.AutoCategory = false
+}
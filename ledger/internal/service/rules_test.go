@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"final/ledger/internal/domain"
+	"final/ledger/internal/grpcx"
+)
+
+func TestRules(t *testing.T) {
+	app, _ := newMemApp()
+	ctx := grpcx.WithUserID(context.Background(), "u1")
+	other := grpcx.WithUserID(context.Background(), "u2")
+	day := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, err := app.SetCategoryAlias(ctx, "taxi", "такси"); err != nil {
+		t.Fatalf("set alias: %v", err)
+	}
+	taxi, err := app.CreateRule(ctx, domain.Rule{Name: " Такси ", Merchant: "yandex taxi", Category: "Taxi", Tags: []string{"Поездки"}})
+	if err != nil || taxi.Category != "такси" || taxi.Name != "Такси" || taxi.Tags[0] != "поездки" {
+		t.Fatalf("create rule: %+v (%v)", taxi, err)
+	}
+	food, err := app.CreateRule(ctx, domain.Rule{Priority: -1, DescriptionRegex: `pyaterochka|magnit`, Category: "продукты"})
+	if err != nil {
+		t.Fatalf("create rule: %v", err)
+	}
+	if _, err := app.CreateRule(ctx, domain.Rule{DescriptionRegex: "(", Category: "x"}); err == nil || err.Error() != "invalid regex" {
+		t.Fatalf("expected invalid regex, got %v", err)
+	}
+
+	// Пустая категория назначается правилом.
+	created, err := app.AddTransaction(ctx, domain.Transaction{Amount: 500, Description: "YANDEX*TAXI", Date: day})
+	if err != nil || created.Category != "такси" {
+		t.Fatalf("expected rule category, got %+v (%v)", created, err)
+	}
+	// Без подходящего правила пустая категория — ошибка, как и раньше.
+	if _, err := app.AddTransaction(ctx, domain.Transaction{Amount: 500, Description: "cinema", Date: day}); err == nil || err.Error() != "transaction category is empty" {
+		t.Fatalf("expected transaction category is empty, got %v", err)
+	}
+	// AutoCategory: правило важнее присланной категории, она — запасная.
+	created, err = app.AddTransaction(ctx, domain.Transaction{Amount: 500, Description: "MAGNIT 12", Category: "Супермаркеты", AutoCategory: true, Date: day})
+	if err != nil || created.Category != "продукты" {
+		t.Fatalf("expected rule category, got %+v (%v)", created, err)
+	}
+	created, err = app.AddTransaction(ctx, domain.Transaction{Amount: 500, Description: "cinema", Category: "Кино", AutoCategory: true, Date: day})
+	if err != nil || created.Category != "кино" {
+		t.Fatalf("expected fallback category, got %+v (%v)", created, err)
+	}
+	created, err = app.AddTransaction(ctx, domain.Transaction{Amount: 500, Description: "MAGNIT 12", Category: "Супермаркеты", Date: day})
+	if err != nil || created.Category != "супермаркеты" {
+		t.Fatalf("explicit category must win without auto-assign, got %+v (%v)", created, err)
+	}
+
+	summary, err := app.BulkImportTransactions(ctx, []domain.ImportItem{
+		{Index: 0, Tx: domain.Transaction{Amount: 100, Description: "Pyaterochka 55", Date: day}},
+		{Index: 1, Tx: domain.Transaction{Amount: 100, Description: "???", Date: day}},
+		{Index: 2, Tx: domain.Transaction{Amount: 100, Description: "Yandex Taxi", Category: "транспорт", AutoCategory: true, Date: day}},
+	}, 2)
+	if err != nil || summary.Accepted != 2 || summary.Rejected != 1 || summary.Errors[0].Index != 1 {
+		t.Fatalf("unexpected import: %+v (%v)", summary, err)
+	}
+	page, err := app.ListTransactions(ctx, domain.TransactionFilter{Categories: []string{"такси"}})
+	if err != nil || len(page.Items) != 2 {
+		t.Fatalf("expected 2 taxi rides, got %d (%v)", len(page.Items), err)
+	}
+
+	matching, err := app.TestRules(ctx, domain.Transaction{Description: "magnit yandex taxi", Amount: 1})
+	if err != nil || len(matching) != 2 || matching[0].ID != food.ID || matching[1].ID != taxi.ID {
+		t.Fatalf("unexpected dry run: %+v (%v)", matching, err)
+	}
+
+	food.Disabled = true
+	if _, err := app.UpdateRule(ctx, food); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if _, err := app.UpdateRule(other, food); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if matching, _ = app.TestRules(ctx, domain.Transaction{Description: "magnit"}); len(matching) != 0 {
+		t.Fatalf("disabled rule must not match: %+v", matching)
+	}
+	if err := app.DeleteRule(ctx, food.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := app.DeleteRule(ctx, food.ID); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	// Переименование категории переносит и правила.
+	c, err := app.CreateCategory(ctx, domain.Category{Name: "такси"})
+	if err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	name := "Транспорт"
+	if _, err := app.UpdateCategory(ctx, c.ID, domain.CategoryPatch{Name: &name}); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	rules, _ := app.ListRules(ctx)
+	if len(rules) != 1 || rules[0].Category != "транспорт" {
+		t.Fatalf("rule must follow renamed category: %+v", rules)
+	}
+}
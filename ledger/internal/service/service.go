@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"final/ledger/internal/domain"
+	"final/ledger/internal/grpcx"
 )
 
 type Deps struct {
@@ -14,35 +15,131 @@ type Deps struct {
 	Cache        Cache
 }
 
-var ErrBudgetExceeded = errors.New("budget exceeded")
+var (
+	ErrBudgetExceeded  = errors.New("budget exceeded")
+	ErrUnauthenticated = errors.New("unauthenticated")
+	ErrNotFound        = errors.New("not found")
+	ErrForbidden       = errors.New("forbidden")
+	ErrNoRate          = errors.New("exchange rate not found")
+	ErrAccountNotFound = errors.New("account not found")
+	// ErrCategoryNotFound — категории нет в дереве пользователя.
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrCategoryArchived = errors.New("category is archived")
+)
 
 type Service interface {
 	SetBudget(ctx context.Context, b domain.Budget) (domain.Budget, error)
 	ListBudgets(ctx context.Context) ([]domain.Budget, error)
 
 	AddTransaction(ctx context.Context, t domain.Transaction) (domain.Transaction, error)
-	ListTransactions(ctx context.Context) ([]domain.Transaction, error)
+	ListTransactions(ctx context.Context, f domain.TransactionFilter) (domain.TransactionPage, error)
+	StreamTransactions(ctx context.Context, f domain.TransactionFilter, send func(domain.Transaction) error) error
+	UpdateTransaction(ctx context.Context, id int, p domain.TransactionPatch) (domain.Transaction, error)
+	DeleteTransaction(ctx context.Context, id int) error
+
+	CreateAccount(ctx context.Context, a domain.Account) (domain.Account, error)
+	ListAccounts(ctx context.Context) ([]domain.Account, error)
+	Transfer(ctx context.Context, tr domain.Transfer) (domain.Transaction, domain.Transaction, error)
+	GetBalances(ctx context.Context, on time.Time) (domain.Balances, error)
+
+	TrialBalance(ctx context.Context, on time.Time) (domain.TrialBalance, error)
+	AccountStatement(ctx context.Context, account, currency string, from, to time.Time) (domain.Statement, error)
+
+	CreateCategory(ctx context.Context, c domain.Category) (domain.Category, error)
+	ListCategories(ctx context.Context) ([]domain.Category, error)
+	UpdateCategory(ctx context.Context, id int, p domain.CategoryPatch) (domain.Category, error)
+	MergeCategory(ctx context.Context, fromID, intoID int) (domain.Category, error)
+	MergeCategoryNames(ctx context.Context, from, into string) (domain.Category, error)
+	SetCategoryAlias(ctx context.Context, alias, category string) (domain.CategoryAlias, error)
+	ListCategoryAliases(ctx context.Context) ([]domain.CategoryAlias, error)
+	DeleteCategoryAlias(ctx context.Context, alias string) error
+	SuggestCategoryMerges(ctx context.Context) ([]domain.MergeSuggestion, error)
+
+	CreateRule(ctx context.Context, r domain.Rule) (domain.Rule, error)
+	ListRules(ctx context.Context) ([]domain.Rule, error)
+	UpdateRule(ctx context.Context, r domain.Rule) (domain.Rule, error)
+	DeleteRule(ctx context.Context, id int) error
+	TestRules(ctx context.Context, t domain.Transaction) ([]domain.Rule, error)
 
-	ReportSummary(ctx context.Context, from, to time.Time) (map[string]float64, error)
+	CreateRecurring(ctx context.Context, r domain.Recurring) (domain.Recurring, error)
+	ListRecurring(ctx context.Context) ([]domain.Recurring, error)
+	SetRecurringPaused(ctx context.Context, id int, paused bool) (domain.Recurring, error)
+	PreviewRecurring(ctx context.Context, id, count int) ([]time.Time, error)
+
+	GetSettings(ctx context.Context) (domain.UserSettings, error)
+	UpdateSettings(ctx context.Context, s domain.UserSettings) (domain.UserSettings, error)
+
+	ReportSummary(ctx context.Context, from, to time.Time) (map[string]domain.Money, error)
+	CashFlow(ctx context.Context, from, to time.Time, granularity string) (domain.CashFlow, error)
+	ReportByTag(ctx context.Context, from, to time.Time) (map[string]domain.Money, error)
+	BudgetProgress(ctx context.Context, from, to time.Time) (domain.ReportWithBudgetProgress, error)
+	SpendingTimeSeries(ctx context.Context, q domain.SeriesQuery) (domain.TimeSeries, error)
+	ComparePeriods(ctx context.Context, from, to, prevFrom, prevTo time.Time) (domain.Comparison, error)
+	ListAnomalies(ctx context.Context, from, to time.Time) ([]domain.Anomaly, error)
 	BulkImportTransactions(ctx context.Context, items []domain.ImportItem, workers int) (domain.ImportSummary, error)
 }
 
 type App struct {
-	budgets  domain.BudgetRepo
-	expenses domain.ExpenseRepo
+	budgets    domain.BudgetRepo
+	expenses   domain.ExpenseRepo
+	accounts   domain.AccountRepo
+	journal    domain.JournalRepo
+	recurring  domain.RecurringRepo
+	categories domain.CategoryRepo
+	rules      domain.RuleRepo
+	settings   domain.SettingsRepo
+	rates      domain.RateRepo
+	tx         domain.Transactor
 }
 
-func New(b domain.BudgetRepo, e domain.ExpenseRepo) *App {
-	return &App{budgets: b, expenses: e}
+func New(b domain.BudgetRepo, e domain.ExpenseRepo, acc domain.AccountRepo, j domain.JournalRepo, rec domain.RecurringRepo, c domain.CategoryRepo, ru domain.RuleRepo, s domain.SettingsRepo, r domain.RateRepo, tx domain.Transactor) *App {
+	return &App{budgets: b, expenses: e, accounts: acc, journal: j, recurring: rec, categories: c, rules: ru, settings: s, rates: r, tx: tx}
+}
+
+func userIDFrom(ctx context.Context) (string, error) {
+	uid, ok := grpcx.UserIDFromContext(ctx)
+	if !ok {
+		return "", ErrUnauthenticated
+	}
+	return uid, nil
 }
 
 func (a *App) SetBudget(ctx context.Context, b domain.Budget) (domain.Budget, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.Budget{}, err
+	}
 	if err := b.Validate(); err != nil {
 		return domain.Budget{}, err
 	}
-	b.Category = domain.NormalizeCategory(b.Category)
+	b.UserID = uid
+	if b.Category, err = a.resolveCategory(ctx, uid, b.Category); err != nil {
+		return domain.Budget{}, err
+	}
+	tree, err := a.categoryTree(ctx, uid)
+	if err != nil {
+		return domain.Budget{}, err
+	}
+	if err := checkCategoryActive(tree, b.Category); err != nil {
+		return domain.Budget{}, err
+	}
 	if b.Period == "" {
-		b.Period = "fixed"
+		b.Period = domain.PeriodFixed
+	}
+	if b.StartDay == 0 {
+		b.StartDay = 1
+	}
+	if b.Timezone == "" {
+		b.Timezone = "UTC"
+	}
+	if b.Enforcement == "" {
+		b.Enforcement = domain.EnforcementHard
+	}
+	b.Currency = domain.NormalizeCurrency(b.Currency)
+	if b.Currency == "" {
+		if b.Currency, err = a.baseCurrency(ctx, uid); err != nil {
+			return domain.Budget{}, err
+		}
 	}
 	if err := a.budgets.Upsert(ctx, b); err != nil {
 		return domain.Budget{}, err
@@ -51,40 +148,161 @@ func (a *App) SetBudget(ctx context.Context, b domain.Budget) (domain.Budget, er
 }
 
 func (a *App) ListBudgets(ctx context.Context) ([]domain.Budget, error) {
-	return a.budgets.List(ctx)
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return a.budgets.List(ctx, uid)
 }
 
 func (a *App) AddTransaction(ctx context.Context, t domain.Transaction) (domain.Transaction, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+	if needsRules(t) {
+		rules, err := a.ruleSet(ctx, uid)
+		if err != nil {
+			return domain.Transaction{}, err
+		}
+		autoCategorize(rules, &t)
+	}
+	t.Tags = domain.NormalizeTags(t.Tags)
 	if err := t.Validate(); err != nil {
 		return domain.Transaction{}, err
 	}
+	// Половины перевода создаёт только Transfer, связанными парой.
+	if t.Kind == domain.KindTransfer {
+		return domain.Transaction{}, errors.New("invalid transaction kind")
+	}
 
-	t.Category = domain.NormalizeCategory(t.Category)
-
-	limit, hasBudget, err := a.budgets.GetLimit(ctx, t.Category)
+	t.UserID = uid
+	if t.Kind == "" {
+		t.Kind = domain.KindExpense
+	}
+	if t.Category, err = a.resolveCategory(ctx, uid, t.Category); err != nil {
+		return domain.Transaction{}, err
+	}
+	acc, err := a.resolveAccount(ctx, uid, t.AccountID)
 	if err != nil {
 		return domain.Transaction{}, err
 	}
+	t.AccountID = acc.ID
+	t.Currency = domain.NormalizeCurrency(t.Currency)
+	if t.Currency == "" {
+		t.Currency = acc.Currency
+	}
+	if t.CheckAnomaly {
+		if t.Anomaly, err = a.transactionAnomaly(ctx, uid, t); err != nil {
+			return domain.Transaction{}, err
+		}
+	}
 
-	if hasBudget {
-		spent, err := a.expenses.SumByCategory(ctx, t.Category)
+	// Проверка лимита и вставка выполняются в одной транзакции: строки
+	// бюджетов блокируются, поэтому параллельные вставки в категорию
+	// не могут вместе превысить лимит. Лимит проверяется только для
+	// расходов: возврат, доход и перевод траты не увеличивают. Трата
+	// проверяется по бюджетам категории и всех её предков: бюджет
+	// родителя учитывает траты подкатегорий.
+	err = a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		tree, err := a.categoryTree(ctx, uid)
 		if err != nil {
-			return domain.Transaction{}, err
+			return err
 		}
-		if spent+t.Amount > limit {
-			return domain.Transaction{}, ErrBudgetExceeded
+		if err := checkCategoryActive(tree, t.Category); err != nil {
+			return err
+		}
+
+		if t.Spending() > 0 {
+			for _, cat := range tree.Lineage(t.Category) {
+				budget, hasBudget, err := a.budgets.GetForUpdate(ctx, uid, cat)
+				if err != nil {
+					return err
+				}
+				if !hasBudget {
+					continue
+				}
+				spent, err := a.spentInPeriod(ctx, tree, budget, t.Date)
+				if err != nil {
+					return err
+				}
+				amount, err := a.convert(ctx, t.Spending(), t.Currency, budget.Currency, t.Date)
+				if err != nil {
+					return err
+				}
+				warnings, reject := budget.Evaluate(spent, amount)
+				if reject {
+					return ErrBudgetExceeded
+				}
+				t.Warnings = append(t.Warnings, warnings...)
+			}
 		}
-	}
 
-	id, err := a.expenses.Insert(ctx, t)
+		id, err := a.expenses.Insert(ctx, t)
+		if err != nil {
+			return err
+		}
+		t.ID = id
+		return nil
+	})
 	if err != nil {
 		return domain.Transaction{}, err
 	}
+	if t.Anomaly != nil {
+		t.Anomaly.TransactionID = t.ID
+	}
 
-	t.ID = id
 	return t, nil
 }
 
-func (a *App) ListTransactions(ctx context.Context) ([]domain.Transaction, error) {
-	return a.expenses.List(ctx)
+// spentInPeriod возвращает траты периода бюджета в валюте бюджета вместе
+// с тратами подкатегорий.
+func (a *App) spentInPeriod(ctx context.Context, tree domain.CategoryTree, b domain.Budget, at time.Time) (domain.Money, error) {
+	var (
+		amounts []domain.DatedAmount
+		err     error
+	)
+	cats := tree.Subtree(b.Category)
+	if from, to, bounded := b.PeriodRange(at); bounded {
+		amounts, err = a.expenses.AmountsByCategoryInRange(ctx, b.UserID, cats, from, to)
+	} else {
+		amounts, err = a.expenses.AmountsByCategory(ctx, b.UserID, cats)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return a.convertAll(ctx, amounts, b.Currency)
+}
+
+func (a *App) ListTransactions(ctx context.Context, f domain.TransactionFilter) (domain.TransactionPage, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.TransactionPage{}, err
+	}
+	if err := f.Validate(); err != nil {
+		return domain.TransactionPage{}, err
+	}
+	paged := f.PageSize != 0 || f.PageToken != ""
+	f = f.Normalize()
+
+	if !paged {
+		items, err := a.listAll(ctx, uid, f)
+		if err != nil {
+			return domain.TransactionPage{}, err
+		}
+		return domain.TransactionPage{Items: items}, nil
+	}
+
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница.
+	items, err := a.expenses.List(ctx, uid, f, f.PageSize+1)
+	if err != nil {
+		return domain.TransactionPage{}, err
+	}
+
+	page := domain.TransactionPage{Items: items}
+	if len(items) > f.PageSize {
+		page.Items = items[:f.PageSize]
+		page.NextPageToken = domain.CursorAfter(f.Sort, page.Items[f.PageSize-1]).Encode()
+	}
+	return page, nil
 }
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+
+	"final/ledger/internal/domain"
+)
+
+// streamBatchSize — сколько строк читается из БД за один запрос при стриминге.
+const streamBatchSize = 500
+
+// StreamTransactions отдаёт все транзакции под фильтром через send, читая их
+// из БД пачками по keyset-курсору, чтобы не держать в памяти всю историю.
+// PageSize фильтра игнорируется, PageToken позволяет продолжить выгрузку.
+func (a *App) StreamTransactions(ctx context.Context, f domain.TransactionFilter, send func(domain.Transaction) error) error {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return err
+	}
+	f.PageSize = 0
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	f = f.Normalize()
+
+	for {
+		items, err := a.expenses.List(ctx, uid, f, streamBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, t := range items {
+			if err := send(t); err != nil {
+				return err
+			}
+		}
+		if len(items) < streamBatchSize {
+			return nil
+		}
+		f.PageToken = domain.CursorAfter(f.Sort, items[len(items)-1]).Encode()
+	}
+}
+
+// listAll читает все транзакции под нормализованным фильтром пачками по
+// streamBatchSize.
+func (a *App) listAll(ctx context.Context, uid string, f domain.TransactionFilter) ([]domain.Transaction, error) {
+	out := []domain.Transaction{}
+	for {
+		items, err := a.expenses.List(ctx, uid, f, streamBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, items...)
+		if len(items) < streamBatchSize {
+			return out, nil
+		}
+		f.PageToken = domain.CursorAfter(f.Sort, items[len(items)-1]).Encode()
+	}
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"final/ledger/internal/domain"
+	"final/ledger/internal/grpcx"
+)
+
+func TestTags(t *testing.T) {
+	app, _ := newMemApp()
+	ctx := grpcx.WithUserID(context.Background(), "u1")
+	other := grpcx.WithUserID(context.Background(), "u2")
+	day := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	trip, err := app.AddTransaction(ctx, domain.Transaction{Amount: 3000, Category: "еда", Tags: []string{" Отпуск ", "кафе", "отпуск"}, Date: day})
+	if err != nil || !slices.Equal(trip.Tags, []string{"отпуск", "кафе"}) {
+		t.Fatalf("create: %+v (%v)", trip, err)
+	}
+	if _, err := app.AddTransaction(ctx, domain.Transaction{Amount: 1, Category: "еда", Tags: []string{" "}, Date: day}); err != nil {
+		t.Fatalf("blank tags must be dropped: %v", err)
+	}
+	if _, err := app.AddTransaction(ctx, domain.Transaction{Amount: 1, Category: "еда", Tags: make([]string, domain.MaxTags+1), Date: day}); err != nil {
+		t.Fatalf("blank tags must be dropped before the limit check: %v", err)
+	}
+	many := make([]string, 0, domain.MaxTags+1)
+	for i := range domain.MaxTags + 1 {
+		many = append(many, string(rune('a'+i)))
+	}
+	if _, err := app.AddTransaction(ctx, domain.Transaction{Amount: 1, Category: "еда", Tags: many, Date: day}); err == nil || err.Error() != "too many tags" {
+		t.Fatalf("expected too many tags, got %v", err)
+	}
+
+	// Метки сработавшего правила добавляются к присланным.
+	if _, err := app.CreateRule(ctx, domain.Rule{Merchant: "yandex taxi", Category: "такси", Tags: []string{"Поездки", "отпуск"}}); err != nil {
+		t.Fatalf("create rule: %v", err)
+	}
+	taxi, err := app.AddTransaction(ctx, domain.Transaction{Amount: 500, Description: "Yandex Taxi", Tags: []string{"аэропорт"}, Date: day.AddDate(0, 0, 1)})
+	if err != nil || taxi.Category != "такси" || !slices.Equal(taxi.Tags, []string{"аэропорт", "поездки", "отпуск"}) {
+		t.Fatalf("expected rule tags, got %+v (%v)", taxi, err)
+	}
+	if _, err := app.AddTransaction(other, domain.Transaction{Amount: 700, Category: "еда", Tags: []string{"отпуск"}, Date: day}); err != nil {
+		t.Fatalf("other user: %v", err)
+	}
+
+	page, err := app.ListTransactions(ctx, domain.TransactionFilter{Tags: []string{"Отпуск"}})
+	if err != nil || len(page.Items) != 2 {
+		t.Fatalf("expected 2 vacation transactions, got %+v (%v)", page.Items, err)
+	}
+
+	// Патч без меток их не трогает, пустой список снимает все.
+	amount := domain.Money(3500)
+	updated, err := app.UpdateTransaction(ctx, trip.ID, domain.TransactionPatch{Amount: &amount})
+	if err != nil || !slices.Equal(updated.Tags, trip.Tags) {
+		t.Fatalf("patch must keep tags: %+v (%v)", updated, err)
+	}
+	none := []string{}
+	if updated, err = app.UpdateTransaction(ctx, taxi.ID, domain.TransactionPatch{Tags: &none}); err != nil || len(updated.Tags) != 0 {
+		t.Fatalf("patch must clear tags: %+v (%v)", updated, err)
+	}
+	retag := []string{"Кафе", "отпуск"}
+	if _, err = app.UpdateTransaction(ctx, taxi.ID, domain.TransactionPatch{Tags: &retag}); err != nil {
+		t.Fatalf("retag: %v", err)
+	}
+
+	// Возврат уменьшает траты по метке.
+	if _, err := app.AddTransaction(ctx, domain.Transaction{Kind: domain.KindRefund, Amount: 200, Category: "еда", Tags: []string{"кафе"}, Date: day}); err != nil {
+		t.Fatalf("refund: %v", err)
+	}
+	report, err := app.ReportByTag(ctx, day, day.AddDate(0, 0, 30))
+	if err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	want := map[string]domain.Money{"отпуск": 4000, "кафе": 3800}
+	if len(report) != len(want) || report["отпуск"] != want["отпуск"] || report["кафе"] != want["кафе"] {
+		t.Fatalf("unexpected report: %v", report)
+	}
+	if _, err := app.ReportByTag(ctx, day, day.AddDate(0, 0, -1)); err == nil {
+		t.Fatalf("expected range error")
+	}
+}
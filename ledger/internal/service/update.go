@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"final/ledger/internal/domain"
+)
+
+func (a *App) UpdateTransaction(ctx context.Context, id int, p domain.TransactionPatch) (domain.Transaction, error) {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+
+	var updated domain.Transaction
+	err = a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		old, err := a.ownedForUpdate(ctx, uid, id)
+		if err != nil {
+			return err
+		}
+		// Половины перевода меняются только вместе: перевод удаляют и создают заново.
+		if old.TransferID != 0 || old.Kind == domain.KindTransfer {
+			return errors.New("transfer cannot be edited")
+		}
+		if p.Kind != nil && *p.Kind == domain.KindTransfer {
+			return errors.New("invalid transaction kind")
+		}
+
+		t := p.Apply(old)
+		if p.Tags != nil {
+			t.Tags = domain.NormalizeTags(t.Tags)
+		}
+		if err := t.Validate(); err != nil {
+			return err
+		}
+		if t.Kind == "" {
+			t.Kind = old.Kind
+		}
+		if t.AccountID != old.AccountID {
+			acc, err := a.resolveAccount(ctx, uid, t.AccountID)
+			if err != nil {
+				return err
+			}
+			t.AccountID = acc.ID
+		}
+		if p.Category != nil {
+			if t.Category, err = a.resolveCategory(ctx, uid, t.Category); err != nil {
+				return err
+			}
+		}
+		t.Currency = domain.NormalizeCurrency(t.Currency)
+		if t.Currency == "" {
+			t.Currency = old.Currency
+		}
+
+		tree, err := a.categoryTree(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if t.Category != old.Category {
+			if err := checkCategoryActive(tree, t.Category); err != nil {
+				return err
+			}
+		}
+
+		// Лимит перепроверяется, только если правка увеличивает траты
+		// в периоде бюджета; уменьшать траты можно всегда. Как и при
+		// добавлении, проверяются бюджеты категории и всех её предков.
+		for _, cat := range tree.Lineage(t.Category) {
+			budget, hasBudget, err := a.budgets.GetForUpdate(ctx, uid, cat)
+			if err != nil {
+				return err
+			}
+			if !hasBudget {
+				continue
+			}
+			newAmount, err := a.convert(ctx, t.Spending(), t.Currency, budget.Currency, t.Date)
+			if err != nil {
+				return err
+			}
+			oldAmount, err := a.convert(ctx, contribution(tree, budget, old, t.Date), old.Currency, budget.Currency, old.Date)
+			if err != nil {
+				return err
+			}
+			delta := newAmount - oldAmount
+			if delta > 0 {
+				spent, err := a.spentInPeriod(ctx, tree, budget, t.Date)
+				if err != nil {
+					return err
+				}
+				warnings, reject := budget.Evaluate(spent, delta)
+				if reject {
+					return ErrBudgetExceeded
+				}
+				t.Warnings = append(t.Warnings, warnings...)
+			}
+		}
+
+		if err := a.expenses.Update(ctx, t); err != nil {
+			return err
+		}
+		updated = t
+		return nil
+	})
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+	return updated, nil
+}
+
+func (a *App) DeleteTransaction(ctx context.Context, id int) error {
+	uid, err := userIDFrom(ctx)
+	if err != nil {
+		return err
+	}
+
+	return a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		t, err := a.ownedForUpdate(ctx, uid, id)
+		if err != nil {
+			return err
+		}
+		// Перевод удаляется целиком, иначе баланс одного из счетов разойдётся.
+		if t.TransferID != 0 {
+			if err := a.expenses.Delete(ctx, uid, t.TransferID); err != nil {
+				return err
+			}
+		}
+		return a.expenses.Delete(ctx, uid, id)
+	})
+}
+
+func (a *App) ownedForUpdate(ctx context.Context, uid string, id int) (domain.Transaction, error) {
+	// Чужую транзакцию репозиторий не находит, как и несуществующую.
+	t, ok, err := a.expenses.GetForUpdate(ctx, uid, id)
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+	if !ok {
+		return domain.Transaction{}, ErrNotFound
+	}
+	return t, nil
+}
+
+// contribution — сколько старая версия транзакции уже учтена в периоде
+// бюджета, в который попадает дата at (в валюте транзакции).
+func contribution(tree domain.CategoryTree, b domain.Budget, old domain.Transaction, at time.Time) domain.Money {
+	if !slices.Contains(tree.Subtree(b.Category), old.Category) {
+		return 0
+	}
+	from, to, bounded := b.PeriodRange(at)
+	if !bounded {
+		return old.Spending()
+	}
+	d := time.Date(old.Date.Year(), old.Date.Month(), old.Date.Day(), 0, 0, 0, 0, time.UTC)
+	if d.Before(from) || d.After(to) {
+		return 0
+	}
+	return old.Spending()
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"final/ledger/internal/domain"
+	"final/ledger/internal/grpcx"
+)
+
+func TestUpdateTransaction(t *testing.T) {
+	app, _ := newMemApp()
+	ctx := grpcx.WithUserID(context.Background(), "u1")
+	other := grpcx.WithUserID(context.Background(), "u2")
+
+	if _, err := app.SetBudget(ctx, domain.Budget{Category: "еда", Limit: 100}); err != nil {
+		t.Fatalf("set budget: %v", err)
+	}
+	date := time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC)
+	a, err := app.AddTransaction(ctx, domain.Transaction{Amount: 60, Category: "еда", Date: date})
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if _, err := app.AddTransaction(ctx, domain.Transaction{Amount: 30, Category: "еда", Date: date}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	amount := func(v domain.Money) *domain.Money { return &v }
+
+	t.Run("increase_within_limit", func(t *testing.T) {
+		got, err := app.UpdateTransaction(ctx, a.ID, domain.TransactionPatch{Amount: amount(70)})
+		if err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+		if got.Amount != 70 || got.Category != "еда" {
+			t.Fatalf("unexpected result: %+v", got)
+		}
+	})
+
+	t.Run("increase_over_limit", func(t *testing.T) {
+		_, err := app.UpdateTransaction(ctx, a.ID, domain.TransactionPatch{Amount: amount(71)})
+		if err != ErrBudgetExceeded {
+			t.Fatalf("expected ErrBudgetExceeded, got %v", err)
+		}
+	})
+
+	t.Run("decrease_always_allowed", func(t *testing.T) {
+		if _, err := app.UpdateTransaction(ctx, a.ID, domain.TransactionPatch{Amount: amount(10)}); err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+	})
+
+	t.Run("other_user_not_found", func(t *testing.T) {
+		_, err := app.UpdateTransaction(other, a.ID, domain.TransactionPatch{Amount: amount(1)})
+		if err != ErrNotFound {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+		if err := app.DeleteTransaction(other, a.ID); err != ErrNotFound {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if err := app.DeleteTransaction(ctx, a.ID); err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+		if err := app.DeleteTransaction(ctx, a.ID); err != ErrNotFound {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
@@ -10,14 +10,56 @@ import (
 
 type Service = service.Service
 
+type Money = domain.Money
+
 type Transaction = domain.Transaction
 type Budget = domain.Budget
+type TransactionPatch = domain.TransactionPatch
+type TransactionFilter = domain.TransactionFilter
+
+type Account = domain.Account
+type Transfer = domain.Transfer
+
+type Recurring = domain.Recurring
+
+type Category = domain.Category
+type CategoryPatch = domain.CategoryPatch
+type CategoryAlias = domain.CategoryAlias
+
+type Rule = domain.Rule
+
+type CashFlow = domain.CashFlow
+type CashFlowPeriod = domain.CashFlowPeriod
+
+type SeriesQuery = domain.SeriesQuery
+type CategoryDelta = domain.CategoryDelta
+type Anomaly = domain.Anomaly
 
 type ImportItem = domain.ImportItem
 type ImportSummary = domain.ImportSummary
 type ImportError = domain.ImportError
+type ImportWarning = domain.ImportWarning
 
-var ErrBudgetExceeded = service.ErrBudgetExceeded
+type BudgetWarning = domain.BudgetWarning
+
+type UserSettings = domain.UserSettings
+
+var (
+	ErrBudgetExceeded  = service.ErrBudgetExceeded
+	ErrUnauthenticated = service.ErrUnauthenticated
+	ErrNotFound        = service.ErrNotFound
+	ErrForbidden       = service.ErrForbidden
+	ErrNoRate          = service.ErrNoRate
+	ErrAccountNotFound = service.ErrAccountNotFound
+
+	ErrCategoryNotFound = service.ErrCategoryNotFound
+	ErrCategoryArchived = service.ErrCategoryArchived
+)
+
+var (
+	ParseMoney = domain.ParseMoney
+	ParseDay   = domain.ParseDay
+)
 
 func New(ctx context.Context) (Service, func() error, error) {
 	return app.Build(ctx)
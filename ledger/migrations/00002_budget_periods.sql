@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE budgets ADD COLUMN IF NOT EXISTS start_day INT NOT NULL DEFAULT 1;
+ALTER TABLE budgets ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT 'UTC';
+
+-- +goose Down
+ALTER TABLE budgets DROP COLUMN IF EXISTS timezone;
+ALTER TABLE budgets DROP COLUMN IF EXISTS start_day;
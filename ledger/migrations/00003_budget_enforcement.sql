@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE budgets ADD COLUMN IF NOT EXISTS enforcement TEXT NOT NULL DEFAULT 'hard';
+ALTER TABLE budgets ADD COLUMN IF NOT EXISTS warn_thresholds JSONB NOT NULL DEFAULT '[]';
+
+-- +goose Down
+ALTER TABLE budgets DROP COLUMN IF EXISTS warn_thresholds;
+ALTER TABLE budgets DROP COLUMN IF EXISTS enforcement;
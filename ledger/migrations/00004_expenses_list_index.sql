@@ -0,0 +1,5 @@
+-- +goose Up
+CREATE INDEX IF NOT EXISTS idx_expenses_user_date_id ON expenses(user_id, date, id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_expenses_user_date_id;
@@ -0,0 +1,22 @@
+-- +goose Up
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS currency TEXT NOT NULL DEFAULT 'RUB';
+ALTER TABLE budgets ADD COLUMN IF NOT EXISTS currency TEXT NOT NULL DEFAULT 'RUB';
+
+CREATE TABLE IF NOT EXISTS user_settings (
+    user_id UUID PRIMARY KEY,
+    base_currency TEXT NOT NULL DEFAULT 'RUB'
+);
+
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    base TEXT NOT NULL,
+    quote TEXT NOT NULL,
+    date DATE NOT NULL,
+    rate NUMERIC(24,10) NOT NULL CHECK (rate > 0),
+    PRIMARY KEY (base, quote, date)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS exchange_rates;
+DROP TABLE IF EXISTS user_settings;
+ALTER TABLE budgets DROP COLUMN IF EXISTS currency;
+ALTER TABLE expenses DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Существующие записи — расходы. Сумма расхода, дохода и возврата
+-- положительна, у перевода знак задаёт направление.
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS kind TEXT NOT NULL DEFAULT 'expense';
+ALTER TABLE expenses ADD CONSTRAINT expenses_kind_check CHECK (
+    kind IN ('expense', 'income', 'refund', 'transfer')
+    AND (kind = 'transfer' OR amount > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_expenses_user_kind_date ON expenses(user_id, kind, date);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_expenses_user_kind_date;
+ALTER TABLE expenses DROP CONSTRAINT IF EXISTS expenses_kind_check;
+ALTER TABLE expenses DROP COLUMN IF EXISTS kind;
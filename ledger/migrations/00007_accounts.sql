@@ -0,0 +1,34 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS accounts (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    currency TEXT NOT NULL DEFAULT 'RUB',
+    opening_balance NUMERIC(14,2) NOT NULL DEFAULT 0,
+    is_default BOOLEAN NOT NULL DEFAULT false,
+    UNIQUE (user_id, name)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_accounts_user_default ON accounts(user_id) WHERE is_default;
+
+-- Уже записанные транзакции переносятся на счёт по умолчанию в базовой валюте.
+INSERT INTO accounts(user_id, name, currency, is_default)
+SELECT u.user_id, 'main', COALESCE(s.base_currency, 'RUB'), true
+FROM (SELECT DISTINCT user_id FROM expenses) u
+LEFT JOIN user_settings s ON s.user_id = u.user_id
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS account_id INT REFERENCES accounts(id);
+UPDATE expenses e SET account_id = a.id FROM accounts a WHERE a.user_id = e.user_id AND a.is_default;
+ALTER TABLE expenses ALTER COLUMN account_id SET NOT NULL;
+
+-- Половины перевода между счетами ссылаются друг на друга.
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS transfer_id INT REFERENCES expenses(id);
+
+CREATE INDEX IF NOT EXISTS idx_expenses_account_date ON expenses(account_id, date);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_expenses_account_date;
+ALTER TABLE expenses DROP COLUMN IF EXISTS transfer_id;
+ALTER TABLE expenses DROP COLUMN IF EXISTS account_id;
+DROP TABLE IF EXISTS accounts;
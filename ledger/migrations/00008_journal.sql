@@ -0,0 +1,95 @@
+-- +goose Up
+-- Журнал двойной записи. transaction_id без внешнего ключа: записи
+-- остаются в журнале после удаления транзакции.
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL,
+    transaction_id INT,
+    date DATE NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    recorded_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS postings (
+    id SERIAL PRIMARY KEY,
+    entry_id INT NOT NULL REFERENCES journal_entries(id),
+    account TEXT NOT NULL,
+    currency TEXT NOT NULL,
+    amount NUMERIC(14,2) NOT NULL CHECK (amount <> 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_journal_entries_user_date ON journal_entries(user_id, date);
+CREATE INDEX IF NOT EXISTS idx_postings_entry ON postings(entry_id);
+CREATE INDEX IF NOT EXISTS idx_postings_account ON postings(account, currency);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION journal_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'journal is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER journal_entries_append_only BEFORE UPDATE OR DELETE ON journal_entries
+    FOR EACH ROW EXECUTE FUNCTION journal_append_only();
+CREATE TRIGGER postings_append_only BEFORE UPDATE OR DELETE ON postings
+    FOR EACH ROW EXECUTE FUNCTION journal_append_only();
+
+-- Баланс записи проверяется в конце транзакции, когда вставлены все её строки.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION journal_entry_balanced() RETURNS trigger AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM postings WHERE entry_id = NEW.entry_id
+        GROUP BY currency HAVING SUM(amount) <> 0
+    ) THEN
+        RAISE EXCEPTION 'unbalanced journal entry %', NEW.entry_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE CONSTRAINT TRIGGER postings_balanced AFTER INSERT ON postings
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION journal_entry_balanced();
+
+-- Остатки на открытии и уже записанные транзакции переносятся в журнал.
+INSERT INTO journal_entries(user_id, transaction_id, date, description)
+SELECT user_id, NULL, CURRENT_DATE, 'opening balance: ' || name
+FROM accounts WHERE opening_balance <> 0 ORDER BY id;
+
+INSERT INTO postings(entry_id, account, currency, amount)
+SELECT j.id, x.account, a.currency, x.amount
+FROM accounts a
+JOIN journal_entries j ON j.user_id = a.user_id AND j.transaction_id IS NULL AND j.description = 'opening balance: ' || a.name
+CROSS JOIN LATERAL (VALUES
+    ('assets:' || a.id, a.opening_balance),
+    ('equity:opening', -a.opening_balance)
+) AS x(account, amount)
+WHERE a.opening_balance <> 0;
+
+INSERT INTO journal_entries(user_id, transaction_id, date, description)
+SELECT user_id, id, date, description FROM expenses ORDER BY date, id;
+
+INSERT INTO postings(entry_id, account, currency, amount)
+SELECT j.id, x.account, e.currency, x.amount
+FROM expenses e
+JOIN journal_entries j ON j.transaction_id = e.id
+CROSS JOIN LATERAL (
+    SELECT CASE WHEN e.kind = 'expense' THEN -e.amount ELSE e.amount END AS effect
+) b
+CROSS JOIN LATERAL (VALUES
+    ('assets:' || e.account_id, b.effect),
+    (CASE e.kind
+        WHEN 'income' THEN 'income:' || e.category
+        WHEN 'transfer' THEN 'equity:transfers'
+        ELSE 'expenses:' || e.category
+     END, -b.effect)
+) AS x(account, amount);
+
+-- +goose Down
+DROP TABLE IF EXISTS postings;
+DROP TABLE IF EXISTS journal_entries;
+DROP FUNCTION IF EXISTS journal_entry_balanced();
+DROP FUNCTION IF EXISTS journal_append_only();
@@ -0,0 +1,37 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS recurring_transactions (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL,
+    account_id INT NOT NULL REFERENCES accounts(id),
+    kind TEXT NOT NULL,
+    amount NUMERIC(14,2) NOT NULL,
+    currency TEXT NOT NULL,
+    category TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    frequency TEXT NOT NULL,
+    interval INT NOT NULL DEFAULT 1 CHECK (interval > 0),
+    start_date DATE NOT NULL,
+    end_date DATE,
+    -- NULL — повторения закончились.
+    next_date DATE,
+    paused BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_recurring_user ON recurring_transactions(user_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_due ON recurring_transactions(next_date) WHERE NOT paused;
+
+-- Первичный ключ не даёт создать одно повторение дважды. transaction_id
+-- пуст, если транзакцию создать не удалось (error).
+CREATE TABLE IF NOT EXISTS recurring_occurrences (
+    recurring_id INT NOT NULL REFERENCES recurring_transactions(id),
+    date DATE NOT NULL,
+    transaction_id INT REFERENCES expenses(id) ON DELETE SET NULL,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (recurring_id, date)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS recurring_occurrences;
+DROP TABLE IF EXISTS recurring_transactions;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Дерево категорий. Транзакции и бюджеты ссылаются на категорию по имени,
+-- поэтому имя уникально в пределах пользователя.
+CREATE TABLE IF NOT EXISTS categories (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    parent_id INT REFERENCES categories(id),
+    icon TEXT NOT NULL DEFAULT '',
+    archived BOOLEAN NOT NULL DEFAULT false,
+    UNIQUE (user_id, name),
+    CHECK (parent_id <> id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_categories_parent ON categories(parent_id);
+CREATE INDEX IF NOT EXISTS idx_expenses_user_category ON expenses(user_id, category);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_expenses_user_category;
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- Алиасы категорий: alias и category хранятся уже нормализованными.
+CREATE TABLE IF NOT EXISTS category_aliases (
+    user_id UUID NOT NULL,
+    alias TEXT NOT NULL,
+    category TEXT NOT NULL,
+    PRIMARY KEY (user_id, alias),
+    CHECK (alias <> category)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS category_aliases;
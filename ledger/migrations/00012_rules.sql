@@ -0,0 +1,23 @@
+-- +goose Up
+-- Правила автокатегоризации. Пустое условие не проверяется, 0 в границах
+-- суммы — граница не задана.
+CREATE TABLE IF NOT EXISTS category_rules (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    priority INT NOT NULL DEFAULT 0,
+    description_contains TEXT NOT NULL DEFAULT '',
+    description_regex TEXT NOT NULL DEFAULT '',
+    merchant TEXT NOT NULL DEFAULT '',
+    min_amount NUMERIC(14,2) NOT NULL DEFAULT 0,
+    max_amount NUMERIC(14,2) NOT NULL DEFAULT 0,
+    category TEXT NOT NULL,
+    tags JSONB NOT NULL DEFAULT '[]',
+    disabled BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_category_rules_user ON category_rules(user_id, priority, id);
+
+-- +goose Down
+DROP TABLE IF EXISTS category_rules;
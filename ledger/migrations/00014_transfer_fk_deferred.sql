@@ -0,0 +1,11 @@
+-- +goose Up
+-- Половины перевода ссылаются друг на друга, поэтому удалить их можно только
+-- вместе: ссылка проверяется при фиксации транзакции, а не после каждого DELETE.
+ALTER TABLE expenses DROP CONSTRAINT IF EXISTS expenses_transfer_id_fkey;
+ALTER TABLE expenses ADD CONSTRAINT expenses_transfer_id_fkey
+    FOREIGN KEY (transfer_id) REFERENCES expenses(id) DEFERRABLE INITIALLY DEFERRED;
+
+-- +goose Down
+ALTER TABLE expenses DROP CONSTRAINT IF EXISTS expenses_transfer_id_fkey;
+ALTER TABLE expenses ADD CONSTRAINT expenses_transfer_id_fkey
+    FOREIGN KEY (transfer_id) REFERENCES expenses(id);
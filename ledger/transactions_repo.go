@@ -25,7 +25,7 @@ func AddTransaction(tx Transaction) (Transaction, error) {
 	}
 
 	if hasBudget {
-		var spent float64
+		var spent Money
 		if err := db.QueryRowContext(ctx,
 			`SELECT COALESCE(SUM(amount),0) FROM expenses WHERE category=$1`,
 			tx.Category,